@@ -67,31 +67,9 @@ func (m model) executeCommand(name string) (tea.Model, tea.Cmd) {
 		m.settingsPreviousScreen = m.screen
 		m.closeAllModals()
 		m.settingsTab = 0
-		m.settingsFocusIndex = 0
+		m.settingsFocusIndex = settingsBaseBranchIndex
 		// Load current settings
-		if config, err := LoadConfig(); err == nil {
-			m.settingsExcludePatterns.SetValue(config.ExcludePatterns)
-			pipelineRegex := config.PipelineJobsRegex
-			if pipelineRegex == "" {
-				pipelineRegex = defaultPipelineJobsRegex
-			}
-			m.settingsPipelineRegex.SetValue(pipelineRegex)
-			// Load base branch
-			baseBranch := config.BaseBranch
-			if baseBranch == "" {
-				baseBranch = "root"
-			}
-			m.settingsBaseBranch.SetValue(baseBranch)
-			// Load environment settings
-			envs := config.Environments
-			if len(envs) == 0 {
-				envs = defaultEnvironments()
-			}
-			for i := 0; i < 4 && i < len(envs); i++ {
-				m.settingsEnvNames[i].SetValue(strings.ToUpper(envs[i].Name))
-				m.settingsEnvBranches[i].SetValue(envs[i].BranchName)
-			}
-		}
+		(&m).loadSettingsFromConfig()
 		(&m).updateTextareaTheme()
 		m.screen = screenSettings
 		(&m).initSettingsViewport()
@@ -2,15 +2,20 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 )
 
 const (
-	configDir       = ".relix"
-	configFileName  = "config.json"
-	releaseFileName = "release.json"
+	configDir             = ".relix"
+	configFileName        = "config.json"
+	pendingReleasesDir    = "pending"      // In-progress release states, one file per project and environment
+	legacyReleaseFileName = "release.json" // Single release state of older versions
 )
 
 // getConfigDir returns the path to the config directory, creating it if needed
@@ -35,13 +40,37 @@ func getConfigPath() (string, error) {
 	return filepath.Join(dir, configFileName), nil
 }
 
-// getReleaseStatePath returns the path to the release state file
-func getReleaseStatePath() (string, error) {
+// getReleaseStatesDir returns the directory holding in-progress release states, creating it if needed
+func getReleaseStatesDir() (string, error) {
 	dir, err := getConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dir, releaseFileName), nil
+	dir = filepath.Join(dir, pendingReleasesDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// safeFileName escapes the characters not allowed in file names (e.g. "/" of branch names)
+// as %XX. The escaping is reversible, so different names never share a file.
+func safeFileName(name string) string {
+	return url.PathEscape(name)
+}
+
+// releaseStateKey returns the file name key of a release state: one release per project and environment
+func releaseStateKey(projectID int, envBranch string) string {
+	return fmt.Sprintf("%d-%s", projectID, safeFileName(envBranch))
+}
+
+// getReleaseStatePath returns the path to the state file of the release of a project to an environment
+func getReleaseStatePath(projectID int, envBranch string) (string, error) {
+	dir, err := getReleaseStatesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, releaseStateKey(projectID, envBranch)+".json"), nil
 }
 
 // LoadConfig loads the application configuration from file
@@ -126,35 +155,120 @@ func SaveSelectedProject(project *Project) error {
 	return SaveConfig(config)
 }
 
-// LoadReleaseState loads the release state from file
-func LoadReleaseState() (*ReleaseState, error) {
-	path, err := getReleaseStatePath()
+// readReleaseState reads a release state file
+func readReleaseState(path string) (*ReleaseState, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	var state ReleaseState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
 
-	data, err := os.ReadFile(path)
+// migrateLegacyReleaseState moves the release states of older versions to their files in the
+// pending releases dir: the single release.json and the states named with "_" for any
+// character not allowed in file names
+func migrateLegacyReleaseState() error {
+	dir, err := getConfigDir()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil // No release in progress
+		return err
+	}
+	legacyPath := filepath.Join(dir, legacyReleaseFileName)
+	state, err := readReleaseState(legacyPath)
+	switch {
+	case err == nil:
+		if err := SaveReleaseState(state); err != nil {
+			return err
+		}
+		if err := os.Remove(legacyPath); err != nil {
+			return err
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	statesDir, err := getReleaseStatesDir()
+	if err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(statesDir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		state, err := readReleaseState(path)
+		if err != nil {
+			continue
+		}
+		want := filepath.Join(statesDir, releaseStateKey(state.ProjectID, state.Environment.BranchName)+".json")
+		if path == want {
+			continue
+		}
+		if _, err := os.Stat(want); err == nil {
+			continue // Never overwrite a state saved under the new name
+		}
+		if err := os.Rename(path, want); err != nil {
+			return err
 		}
+	}
+	return nil
+}
+
+// LoadReleaseState loads the in-progress release of a project to an environment
+func LoadReleaseState(projectID int, envBranch string) (*ReleaseState, error) {
+	if err := migrateLegacyReleaseState(); err != nil {
+		return nil, err
+	}
+	path, err := getReleaseStatePath(projectID, envBranch)
+	if err != nil {
 		return nil, err
 	}
+	state, err := readReleaseState(path)
+	if os.IsNotExist(err) {
+		return nil, nil // No release in progress
+	}
+	return state, err
+}
 
-	var state ReleaseState
-	if err := json.Unmarshal(data, &state); err != nil {
+// LoadReleaseStates loads all in-progress releases, most recently updated first
+func LoadReleaseStates() ([]*ReleaseState, error) {
+	if err := migrateLegacyReleaseState(); err != nil {
 		return nil, err
 	}
-	return &state, nil
+	dir, err := getReleaseStatesDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var states []*ReleaseState
+	for _, path := range paths {
+		state, err := readReleaseState(path)
+		if err != nil {
+			continue // Skip unreadable files
+		}
+		states = append(states, state)
+	}
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i].UpdatedAt.After(states[j].UpdatedAt)
+	})
+	return states, nil
 }
 
-// SaveReleaseState saves the release state to file
+// SaveReleaseState saves the release state to its project and environment file
 func SaveReleaseState(state *ReleaseState) error {
-	path, err := getReleaseStatePath()
+	path, err := getReleaseStatePath(state.ProjectID, state.Environment.BranchName)
 	if err != nil {
 		return err
 	}
 
+	state.UpdatedAt = time.Now()
 	data, err := json.MarshalIndent(state, "", "  ")
 	if err != nil {
 		return err
@@ -162,9 +276,9 @@ func SaveReleaseState(state *ReleaseState) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
-// ClearReleaseState removes the release state file
-func ClearReleaseState() error {
-	path, err := getReleaseStatePath()
+// ClearReleaseState removes the state file of the release
+func ClearReleaseState(state *ReleaseState) error {
+	path, err := getReleaseStatePath(state.ProjectID, state.Environment.BranchName)
 	if err != nil {
 		return err
 	}
@@ -175,15 +289,30 @@ func ClearReleaseState() error {
 	return err
 }
 
-// getBaseBranch loads config and returns the configured base branch with "root" fallback
+// getBaseBranch returns the base branch of the selected project with "root" fallback
 func getBaseBranch() string {
-	config, err := LoadConfig()
-	if err != nil || config.BaseBranch == "" {
+	config, _ := LoadSelectedProjectConfig()
+	if config == nil || config.BaseBranch == "" {
 		return "root"
 	}
 	return config.BaseBranch
 }
 
+// getNamingTemplates returns the naming templates of the selected project with defaults for unset ones
+func getNamingTemplates() NamingTemplates {
+	config, _ := LoadSelectedProjectConfig()
+	if config == nil {
+		return defaultNamingTemplates()
+	}
+	return config.Naming.withDefaults()
+}
+
+// getGitLabRelease returns whether releases of the selected project create a GitLab Release
+func getGitLabRelease() bool {
+	config, _ := LoadSelectedProjectConfig()
+	return config != nil && config.GitLabRelease
+}
+
 // defaultEnvironments returns the default environment configurations
 func defaultEnvironments() []EnvConfig {
 	return []EnvConfig{
@@ -194,14 +323,41 @@ func defaultEnvironments() []EnvConfig {
 	}
 }
 
+// getEnvironmentConfigs returns the environments of the selected project, falling back to the defaults
+func getEnvironmentConfigs() []EnvConfig {
+	config, _ := LoadSelectedProjectConfig()
+	if config == nil || len(config.Environments) == 0 {
+		return defaultEnvironments()
+	}
+	return config.Environments
+}
+
 // getEnvironments loads config and converts EnvConfig to runtime Environment slice
 func getEnvironments() []Environment {
-	config, err := LoadConfig()
-	if err != nil || len(config.Environments) == 0 {
-		// Fallback to defaults
-		return envsFromConfig(defaultEnvironments())
+	return envsFromConfig(getEnvironmentConfigs())
+}
+
+// envConfigByBranch returns the configured environment with the given branch, or nil
+func envConfigByBranch(configs []EnvConfig, branchName string) *EnvConfig {
+	for i := range configs {
+		if configs[i].BranchName == branchName {
+			return &configs[i]
+		}
+	}
+	return nil
+}
+
+// releaseExcludePatterns returns the global exclude patterns followed by the
+// extra patterns of the environment the release goes to
+func releaseExcludePatterns(config *AppConfig, envBranch string) []string {
+	if config == nil {
+		return nil
+	}
+	patterns := strings.Split(config.ExcludePatterns, "\n")
+	if env := envConfigByBranch(config.Environments, envBranch); env != nil && env.ExcludePatterns != "" {
+		patterns = append(patterns, strings.Split(env.ExcludePatterns, "\n")...)
 	}
-	return envsFromConfig(config.Environments)
+	return patterns
 }
 
 // envsFromConfig converts []EnvConfig to []Environment, uppercasing Name
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeFileName(t *testing.T) {
+	seen := make(map[string]string)
+	for _, name := range []string{"feat/x", "feat_x", "feat%2Fx", "feat-x", "testing"} {
+		file := safeFileName(name)
+		if other, ok := seen[file]; ok {
+			t.Errorf("%q and %q share file name %q", name, other, file)
+		}
+		seen[file] = name
+		if filepath.Base(file) != file {
+			t.Errorf("safeFileName(%q) = %q is not a single path element", name, file)
+		}
+	}
+	if got := safeFileName("testing"); got != "testing" {
+		t.Errorf("safeFileName(testing) = %q, want it unchanged", got)
+	}
+}
+
+func TestReleaseStatesOfSimilarBranches(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, branch := range []string{"env/test", "env_test"} {
+		if err := SaveReleaseState(&ReleaseState{ProjectID: 1, Environment: Environment{BranchName: branch}, Version: branch}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, branch := range []string{"env/test", "env_test"} {
+		state, err := LoadReleaseState(1, branch)
+		if err != nil || state == nil || state.Version != branch {
+			t.Errorf("release to %s = %+v, %v", branch, state, err)
+		}
+	}
+}
+
+func TestMigrateLegacyReleaseStateNames(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir, err := getReleaseStatesDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Saved by an older version that mapped "/" to "_"
+	if err := os.WriteFile(filepath.Join(dir, "1-env_test.json"), []byte(`{"project_id":1,"environment":{"BranchName":"env/test"},"version":"1.0.0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	states, err := LoadReleaseStates()
+	if err != nil || len(states) != 1 {
+		t.Fatalf("states = %v, %v", states, err)
+	}
+	state, err := LoadReleaseState(1, "env/test")
+	if err != nil || state == nil || state.Version != "1.0.0" {
+		t.Fatalf("migrated release = %+v, %v", state, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "1-env_test.json")); !os.IsNotExist(err) {
+		t.Errorf("old state file is left: %v", err)
+	}
+}
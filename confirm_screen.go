@@ -33,6 +33,11 @@ func (m *model) initConfirmViewport() {
 func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+q":
+		// Go back to pre-flight checks if they ran
+		if m.needsPreflight() {
+			m.screen = screenPreflight
+			return m, nil
+		}
 		// Go back to root merge screen, restore button index based on selection
 		m.screen = screenRootMerge
 		if m.rootMergeSelection {
@@ -42,12 +47,17 @@ func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "enter":
-		// Block release when source branch check is in progress
-		if m.sourceBranchRemoteStatus == "checking" {
+		// Block release when source branch check or simulation is in progress
+		if m.sourceBranchRemoteStatus == "checking" || m.confirmSimulating || m.confirmPreparingWorktree {
 			return m, nil
 		}
 		// Start the release process
 		return m.startRelease()
+	case "s":
+		if m.sourceBranchRemoteStatus == "checking" || m.confirmSimulating || m.confirmPreparingWorktree {
+			return m, nil
+		}
+		return m.startSimulation()
 	}
 
 	// Handle viewport scrolling
@@ -96,31 +106,35 @@ func (m model) viewConfirm() string {
 	if m.sourceBranchRemoteStatus == "checking" {
 		helpText = "C+q: back • /: commands • C+c: quit"
 	} else {
-		helpText = "↓/↑/j/k: scroll • enter: release • C+q: back • /: commands • C+c: quit"
+		helpText = "↓/↑/j/k: scroll • enter: release • s: simulate • C+q: back • /: commands • C+c: quit"
 	}
 	help := helpStyle.Width(m.width).Align(lipgloss.Center).Render(helpText)
 
 	return lipgloss.JoinVertical(lipgloss.Left, main, help)
 }
 
-// renderTripleSidebar renders MRs, Environment, and Version sidebars stacked vertically
-func (m model) renderTripleSidebar(width int, availableHeight int) string {
-	// Collect branch names from selected MRs or release state
-	var branches []string
+// wizardMRBranches returns the MR branches of the release: from the release state (for resume
+// scenario), the release being promoted or the MRs selected in the list
+func (m model) wizardMRBranches() []string {
 	if m.releaseState != nil && len(m.releaseState.MRBranches) > 0 {
-		// Use branches from release state (for resume scenario)
-		branches = m.releaseState.MRBranches
-	} else {
-		// Get branches from list
-		items := m.list.Items()
-		for _, item := range items {
-			if mr, ok := item.(mrListItem); ok {
-				if m.selectedMRs[mr.MR().IID] {
-					branches = append(branches, mr.MR().SourceBranch)
-				}
-			}
+		return m.releaseState.MRBranches
+	}
+	if m.promotion != nil {
+		return m.promotion.MRBranches
+	}
+	var branches []string
+	for _, item := range m.list.Items() {
+		if mr, ok := item.(mrListItem); ok && m.selectedMRs[mr.MR().IID] {
+			branches = append(branches, mr.MR().SourceBranch)
 		}
 	}
+	return branches
+}
+
+// renderTripleSidebar renders MRs, Environment, and Version sidebars stacked vertically
+func (m model) renderTripleSidebar(width int, availableHeight int) string {
+	// Collect branch names from selected MRs or release state
+	branches := m.wizardMRBranches()
 
 	// Each bordered box adds 2 lines for top/bottom border
 	// We have 3 boxes, so total border overhead is 6 lines
@@ -176,19 +190,7 @@ func (m model) renderTripleSidebar(width int, availableHeight int) string {
 
 // renderSixSidebar renders all 6 sidebars: MRs, Environment, Version, Source branch, Env merge, and Root merge
 func (m model) renderSixSidebar(width int, availableHeight int) string {
-	var branches []string
-	if m.releaseState != nil && len(m.releaseState.MRBranches) > 0 {
-		branches = m.releaseState.MRBranches
-	} else {
-		items := m.list.Items()
-		for _, item := range items {
-			if mr, ok := item.(mrListItem); ok {
-				if m.selectedMRs[mr.MR().IID] {
-					branches = append(branches, mr.MR().SourceBranch)
-				}
-			}
-		}
-	}
+	branches := m.wizardMRBranches()
 
 	totalContentHeight := availableHeight - 12
 
@@ -251,8 +253,13 @@ func (m model) renderConfirmContent(width int, availableHeight int) string {
 	// Render viewport
 	viewportContent := m.confirmViewport.View()
 
-	// Render button with margins
+	// Render button with margins (spinner while simulating)
 	button := buttonActiveStyle.Render("Release it")
+	if m.confirmSimulating {
+		button = m.spinner.View() + " " + lipgloss.NewStyle().Foreground(currentTheme.Foreground).Render("Simulating release in a temporary worktree...")
+	} else if m.confirmPreparingWorktree {
+		button = m.spinner.View() + " " + lipgloss.NewStyle().Foreground(currentTheme.Foreground).Render("Preparing release worktree...")
+	}
 	buttonLine := "\n" + lipgloss.NewStyle().Width(width).Align(lipgloss.Center).Render(button)
 
 	return viewportContent + buttonLine
@@ -314,18 +321,24 @@ func (m model) renderConfirmMarkdown(width int) string {
 	}
 
 	// Get next v-number for display
+	naming := getNamingTemplates()
 	vNumber := 1
 	if workDir, err := FindProjectRoot(); err == nil && envBranch != "" {
-		if n, err := GetNextVersionNumber(workDir, envBranch, version); err == nil {
+		if n, err := GetNextVersionNumber(workDir, envBranch, version, naming.CommitTitle); err == nil {
 			vNumber = n
 		}
 	}
+	namingVars := NamingVars{Version: version, Env: envName, EnvBranch: envBranch, N: vNumber}
+	envReleaseBranch := renderNamingTemplate(naming.EnvBranch, namingVars)
 
 	// Determine step 1 text based on whether source branch exists remotely
 	// Note: when "checking", the spinner is shown above viewport in renderConfirmContent
 	step1Text := ""
 	sourceBranchExists := m.sourceBranchRemoteStatus == "exists-same" || m.sourceBranchRemoteStatus == "exists-diff" || m.sourceBranchRemoteStatus == "exists"
-	if sourceBranchExists {
+	if m.promotion != nil {
+		step1Text = fmt.Sprintf("1. Use remote branch **%s** at **%s** released to **%s** as is, without merging MRs again",
+			sourceBranch, shortSHA(m.promotion.SourceBranchSHA), m.promotion.Environment)
+	} else if sourceBranchExists {
 		step1Text = fmt.Sprintf("1. Use remote branch **%s** as cumulative one", sourceBranch)
 	} else {
 		// Default to "Create" for new branches or when still checking
@@ -335,13 +348,12 @@ func (m model) renderConfirmMarkdown(width int) string {
 	// Create non-breaking versions of branch names for ATTENTION line (prevents word wrap at hyphens)
 	nbHyphen := "‑" // U+2011 non-breaking hyphen
 	sourceBranchNB := strings.ReplaceAll(sourceBranch, "-", nbHyphen)
-	versionNB := strings.ReplaceAll(version, "-", nbHyphen)
-	envBranchNB := strings.ReplaceAll(envBranch, "-", nbHyphen)
+	envReleaseBranchNB := strings.ReplaceAll(envReleaseBranch, "-", nbHyphen)
 
 	// Build tag name
-	tagName := fmt.Sprintf("%s-%s-v%d", strings.ToLower(envName), version, vNumber)
+	tagName := renderNamingTemplate(naming.Tag, namingVars)
 
-	hasMRs := len(m.selectedMRs) > 0
+	hasMRs := len(m.selectedMRs) > 0 && m.promotion == nil
 
 	// Build steps with dynamic numbering (step 1 is always the cumulative branch)
 	stepNum := 2
@@ -354,16 +366,24 @@ func (m model) renderConfirmMarkdown(width int) string {
 	}
 
 	// Step: create env release branch
-	envStep := fmt.Sprintf(`%d. Create environment release branch **release/rpb-%s-%s** from current **%s**`, stepNum, version, envBranch, envBranch)
+	envStep := fmt.Sprintf(`%d. Create environment release branch **%s** from current **%s**`, stepNum, envReleaseBranch, envBranch)
 	stepNum++
 
 	// Step: copy content / merge
 	step4And5 := ""
 	if m.envMergeSelection == 1 {
 		// Regular merge mode
-		step4And5 = fmt.Sprintf(`%d. Merge **%s** to **release/rpb-%s-%s** via regular git merge (may require ~~conflict resolution~~)`,
-			stepNum, sourceBranch, version, envBranch)
+		step4And5 = fmt.Sprintf(`%d. Merge **%s** to **%s** via regular git merge (may require ~~conflict resolution~~)`,
+			stepNum, sourceBranch, envReleaseBranch)
 		stepNum++
+	} else if m.envMergeSelection == 2 {
+		// Cherry-pick mode
+		step4And5 = fmt.Sprintf(`%d. Cherry-pick the commits of the selected MRs one by one to **%s** in merge order, without the rest of **%s**: an MR already merged to base is picked as its merge commit, each pick may require ~~conflict resolution~~
+
+%d. Restore files matching exclude patterns from app settings changed by the picks (from env branch or remove) in a fix-up commit`,
+			stepNum, envReleaseBranch, sourceBranch,
+			stepNum+1)
+		stepNum += 2
 	} else {
 		// Squash merge mode (default)
 		copyDesc := "Copy new composed MRs' content"
@@ -372,20 +392,20 @@ func (m model) renderConfirmMarkdown(width int) string {
 		} else {
 			copyDesc = fmt.Sprintf("Copy new composed MRs' content from **%s**", sourceBranch)
 		}
-		step4And5 = fmt.Sprintf(`%d. %s via `+"`git checkout -- .`"+` to **release/rpb-%s-%s** as a new independent ordinal commit with its next number **v%d** from previous within version **%s**
+		step4And5 = fmt.Sprintf(`%d. %s via `+"`git checkout -- .`"+` to **%s** as a new independent ordinal commit with its next number **v%d** from previous within version **%s**
 
 %d. Exclude from release commit files matching patterns from app settings (restore from env branch or remove)`,
-			stepNum, copyDesc, version, envBranch, vNumber, version,
+			stepNum, copyDesc, envReleaseBranch, vNumber, version,
 			stepNum+1)
 		stepNum += 2
 	}
 
 	// Step: push env branch
-	pushStep := fmt.Sprintf(`%d. ~~Confirm~~ and push **release/rpb-%s-%s** to remote`, stepNum, version, envBranch)
+	pushStep := fmt.Sprintf(`%d. ~~Confirm~~ and push **%s** to remote`, stepNum, envReleaseBranch)
 	stepNum++
 
 	// Step: create MR
-	mrStep := fmt.Sprintf(`%d. Create new merge request from **release/rpb-%s-%s** to **%s**`, stepNum, version, envBranch, envBranch)
+	mrStep := fmt.Sprintf(`%d. Create new merge request from **%s** to **%s**`, stepNum, envReleaseBranch, envBranch)
 	stepNum++
 
 	// Steps: open MR, push root branches
@@ -404,9 +424,37 @@ func (m model) renderConfirmMarkdown(width int) string {
 			stepNum, stepNum+1, sourceBranch, tagName)
 	}
 
+	// Linked issues of the selected MRs with a warning if any is not ready for the environment
+	issuesSection := ""
+	if issues := m.selectedMRsIssues(); len(issues) > 0 {
+		env := m.selectedEnvConfig()
+		notAllowed := 0
+		for _, issue := range issues {
+			if m.issueNotAllowed(issue, env) {
+				notAllowed++
+			}
+		}
+		if notAllowed > 0 {
+			issuesSection = fmt.Sprintf("*WARNING!* ~~%d of linked issues are not in a status allowed for~~ **%s** ~~environment~~\n\n",
+				notAllowed, envName)
+		}
+		issuesSection += "Linked issues of selected MRs:\n\n" + m.trackerIssuesListMarkdown(issues, env) + "\n"
+	}
+
+	// Release notes preview, used as the environment MR description
+	notesSection := ""
+	if hasMRs {
+		notesSection = "\n---\n\nRelease notes (environment MR description):\n\n" + m.releaseNotes()
+	} else if m.promotion != nil && m.promotion.ReleaseNotes != "" {
+		notesSection = "\n---\n\nRelease notes (environment MR description):\n\n" + m.promotion.ReleaseNotes
+	}
+
 	// Build header
 	header := ""
-	if hasMRs {
+	if m.promotion != nil {
+		header = fmt.Sprintf(`[ We are ready ]()to promote **%s** as **%s v%d** to **%s** environment!`,
+			historyReleaseName(m.promotion), version, vNumber, envName)
+	} else if hasMRs {
 		header = fmt.Sprintf(`[ We are ready ]()to release **%s v%d** of selected MRs to **%s** environment!`, version, vNumber, envName)
 	} else {
 		header = fmt.Sprintf(`[ We are ready ]()to release **%s v%d** to **%s** environment!`, version, vNumber, envName)
@@ -428,10 +476,10 @@ This release will go through the following steps:
 
 %s
 
-*ATTENTION!* ~~If there are existing local branches under mentioned names~~ *%s* ~~or~~ *release/rpb‑%s‑%s*~~, then they will be removed and recreated with pointer at current root or remote source branch and current environment branch respectively~~
+%s*ATTENTION!* ~~If there are existing local branches under mentioned names~~ *%s* ~~or~~ *%s*~~, then they will be removed and recreated with pointer at current root or remote source branch and current environment branch respectively~~
 
-If you agree, press enter and release it.
-`,
+If you agree, press enter and release it, or press *s* to simulate it first.
+%s%s`,
 		header,
 		step1Text, mergeStep,
 		envStep,
@@ -439,7 +487,10 @@ If you agree, press enter and release it.
 		pushStep,
 		mrStep,
 		step8And9,
-		sourceBranchNB, versionNB, envBranchNB,
+		issuesSection,
+		sourceBranchNB, envReleaseBranchNB,
+		m.renderSimulationMarkdown(),
+		notesSection,
 	)
 
 	style := styles.DarkStyleConfig
@@ -459,6 +510,8 @@ If you agree, press enter and release it.
 	style.LinkText.BackgroundColor = stringPtr(string(currentTheme.Accent))
 	style.LinkText.Bold = boolPtr(true)
 	style.H1.Prefix = ""
+	style.H3.Prefix = ""
+	style.H3.Color = stringPtr(string(currentTheme.Accent))
 
 	renderer, err := glamour.NewTermRenderer(
 		glamour.WithStyles(style),
@@ -476,3 +529,77 @@ If you agree, press enter and release it.
 
 	return rendered
 }
+
+// startSimulation runs the merge and copy-content steps in a temporary worktree
+func (m model) startSimulation() (tea.Model, tea.Cmd) {
+	workDir, err := FindProjectRoot()
+	if err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Failed to find project root: %v", err)
+		return m, nil
+	}
+
+	state := m.buildReleaseState(workDir)
+	m.confirmSimulating = true
+	m.confirmSimulation = nil
+
+	return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+		config, _ := LoadReleaseConfig(state)
+		patterns := releaseExcludePatterns(config, state.Environment.BranchName)
+		simulation, err := SimulateRelease(state, patterns)
+		return releaseSimulationMsg{simulation: simulation, err: err}
+	})
+}
+
+// renderSimulationMarkdown renders the last simulation result as a markdown section
+func (m model) renderSimulationMarkdown() string {
+	sim := m.confirmSimulation
+	if sim == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n---\n\n[ Simulation ]()ran in a temporary worktree, nothing was pushed\n\n")
+
+	if len(sim.MRs) > 0 {
+		if conflicts := sim.ConflictCount(); conflicts > 0 {
+			sb.WriteString(fmt.Sprintf("~~%d of %d MRs can't be merged:~~\n\n", conflicts, len(sim.MRs)))
+		} else {
+			sb.WriteString(fmt.Sprintf("All %d MRs merge cleanly:\n\n", len(sim.MRs)))
+		}
+		for _, mr := range sim.MRs {
+			switch {
+			case mr.Error != "":
+				sb.WriteString(fmt.Sprintf("- **%s** ~~fails to merge:~~ %s\n", mr.Branch, mr.Error))
+			case len(mr.Conflicts) > 0:
+				sb.WriteString(fmt.Sprintf("- **%s** ~~conflicts in~~ `%s`\n", mr.Branch, strings.Join(mr.Conflicts, "`, `")))
+			case mr.AlreadyMerged:
+				sb.WriteString(fmt.Sprintf("- **%s** is already merged\n", mr.Branch))
+			default:
+				sb.WriteString(fmt.Sprintf("- **%s** merges cleanly\n", mr.Branch))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(sim.EnvConflicts) > 0 {
+		mergeDesc := "Regular merge to environment conflicts in"
+		if m.envMergeSelection == 2 {
+			mergeDesc = "Cherry-picks to environment conflict in"
+		}
+		sb.WriteString(fmt.Sprintf("~~%s~~ `%s`\n\n", mergeDesc, strings.Join(sim.EnvConflicts, "`, `")))
+	} else if sim.DiffStat == "" {
+		sb.WriteString("Environment release commit would be *empty*\n\n")
+	} else {
+		sb.WriteString("Environment release commit:\n\n```\n" + sim.DiffStat + "\n```\n\n")
+	}
+
+	if len(sim.Restored) > 0 {
+		sb.WriteString(fmt.Sprintf("Excluded files restored from environment branch: `%s`\n\n", strings.Join(sim.Restored, "`, `")))
+	}
+	if len(sim.Removed) > 0 {
+		sb.WriteString(fmt.Sprintf("Excluded files removed (missing in environment branch): `%s`\n\n", strings.Join(sim.Removed, "`, `")))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,632 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// conflictContextLines is the number of unchanged lines shown around each conflict hunk
+const conflictContextLines = 3
+
+// conflictHunk is a single conflict marker block of a file
+type conflictHunk struct {
+	StartLine   int      // 1-based line number of the <<<<<<< marker
+	Before      []string // Context lines before the hunk
+	Ours        []string
+	Base        []string // Only present with merge.conflictStyle=diff3
+	Theirs      []string
+	After       []string // Context lines after the hunk
+	OursLabel   string
+	TheirsLabel string
+}
+
+// parseConflictHunks extracts conflict marker blocks with surrounding context from file content
+func parseConflictHunks(content string, context int) []conflictHunk {
+	lines := strings.Split(content, "\n")
+	var hunks []conflictHunk
+
+	const (
+		sectionNone = iota
+		sectionOurs
+		sectionBase
+		sectionTheirs
+	)
+	section := sectionNone
+	var current conflictHunk
+
+	for i, line := range lines {
+		switch {
+		case section == sectionNone && strings.HasPrefix(line, "<<<<<<<"):
+			current = conflictHunk{
+				StartLine: i + 1,
+				OursLabel: strings.TrimSpace(strings.TrimPrefix(line, "<<<<<<<")),
+			}
+			from := i - context
+			if from < 0 {
+				from = 0
+			}
+			current.Before = append([]string{}, lines[from:i]...)
+			section = sectionOurs
+
+		case section == sectionOurs && strings.HasPrefix(line, "|||||||"):
+			section = sectionBase
+
+		case (section == sectionOurs || section == sectionBase) && strings.HasPrefix(line, "======="):
+			section = sectionTheirs
+
+		case section == sectionTheirs && strings.HasPrefix(line, ">>>>>>>"):
+			current.TheirsLabel = strings.TrimSpace(strings.TrimPrefix(line, ">>>>>>>"))
+			to := i + 1 + context
+			if to > len(lines) {
+				to = len(lines)
+			}
+			current.After = append([]string{}, lines[i+1:to]...)
+			hunks = append(hunks, current)
+			section = sectionNone
+
+		case section == sectionOurs:
+			current.Ours = append(current.Ours, line)
+
+		case section == sectionBase:
+			current.Base = append(current.Base, line)
+
+		case section == sectionTheirs:
+			current.Theirs = append(current.Theirs, line)
+		}
+	}
+
+	return hunks
+}
+
+// openConflictScreen lists the unmerged paths of the suspended merge and shows the conflict screen
+func (m model) openConflictScreen() (tea.Model, tea.Cmd) {
+	if m.releaseState == nil {
+		return m, nil
+	}
+
+	files, err := GetConflictedFiles(m.releaseState.WorkDir)
+	if err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+		return m, nil
+	}
+
+	m.conflictFiles = files
+	m.conflictUnmerged = make(map[string]bool)
+	for _, file := range files {
+		m.conflictUnmerged[file] = true
+	}
+	m.conflictIndex = 0
+	m.showConflictDropConfirm = false
+	m.conflictDropConfirmIndex = 0
+	m.screen = screenConflict
+	m.initConflictScreen()
+	return m, nil
+}
+
+// initConflictScreen initializes the hunk preview viewport
+func (m *model) initConflictScreen() {
+	if m.width == 0 || m.height == 0 {
+		return
+	}
+
+	sidebarW := sidebarWidth(m.width)
+	contentWidth := m.width - sidebarW - 4
+	contentHeight := m.height - 4
+
+	// Layout: header 2 + empty 1 + hrline 1 + viewport border 2 = 6
+	viewportHeight := contentHeight - 6
+	if viewportHeight < 1 {
+		viewportHeight = 1
+	}
+
+	m.conflictViewport = viewport.New(contentWidth-4, viewportHeight)
+	m.updateConflictViewport()
+}
+
+// refreshConflictFiles re-reads the unmerged paths, keeping already resolved files in the list
+func (m *model) refreshConflictFiles() error {
+	if m.releaseState == nil {
+		return nil
+	}
+
+	files, err := GetConflictedFiles(m.releaseState.WorkDir)
+	if err != nil {
+		return err
+	}
+
+	m.conflictUnmerged = make(map[string]bool)
+	for _, file := range files {
+		m.conflictUnmerged[file] = true
+		known := false
+		for _, existing := range m.conflictFiles {
+			if existing == file {
+				known = true
+				break
+			}
+		}
+		if !known {
+			m.conflictFiles = append(m.conflictFiles, file)
+		}
+	}
+	return nil
+}
+
+// selectNextUnmergedFile moves the selection to the next unmerged file, wrapping around
+func (m *model) selectNextUnmergedFile() {
+	count := len(m.conflictFiles)
+	for offset := 1; offset <= count; offset++ {
+		index := (m.conflictIndex + offset) % count
+		if m.conflictUnmerged[m.conflictFiles[index]] {
+			m.conflictIndex = index
+			return
+		}
+	}
+}
+
+// selectedConflictFile returns the selected path or empty string if the list is empty
+func (m model) selectedConflictFile() string {
+	if m.conflictIndex < 0 || m.conflictIndex >= len(m.conflictFiles) {
+		return ""
+	}
+	return m.conflictFiles[m.conflictIndex]
+}
+
+// conflictSides returns the branch names of "ours" and "theirs" for the suspended merge
+func (m model) conflictSides() (string, string) {
+	state := m.releaseState
+	if state == nil {
+		return "", ""
+	}
+
+	engine := NewReleaseEngine(state, nil, nil)
+	if state.CurrentStep == ReleaseStepCopyContent && state.EnvMergeMode == "regular" {
+		// Regular env merge: source branch merged into env release branch
+		return engine.EnvReleaseBranch(), state.SourceBranch
+	}
+	if pick := state.currentCherryPick(); pick != nil {
+		// Cherry-pick env merge: MR commit picked onto env release branch
+		return engine.EnvReleaseBranch(), fmt.Sprintf("%s@%s", pick.Branch, shortSHA(pick.SHA))
+	}
+
+	branch := ""
+	if state.CurrentMRIndex < len(state.MRBranches) {
+		branch = state.MRBranches[state.CurrentMRIndex]
+	}
+	return engine.RootBranch(), branch
+}
+
+// canDropConflictedMR reports whether the conflict belongs to an MR merge that can be dropped
+func (m model) canDropConflictedMR() bool {
+	return m.releaseState != nil &&
+		m.releaseState.CurrentStep == ReleaseStepMergeBranches &&
+		m.releaseState.CurrentMRIndex < len(m.releaseState.MRBranches)
+}
+
+// updateConflict handles key events on the conflict resolution screen
+func (m model) updateConflict(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Handle drop MR confirmation modal
+	if m.showConflictDropConfirm {
+		switch msg.String() {
+		case "y", "Y":
+			m.showConflictDropConfirm = false
+			m.conflictDropConfirmIndex = 0
+			return m.dropConflictedMR()
+		case "enter":
+			m.showConflictDropConfirm = false
+			drop := m.conflictDropConfirmIndex == 0
+			m.conflictDropConfirmIndex = 0
+			if drop {
+				return m.dropConflictedMR()
+			}
+			return m, nil
+		case "n", "N", "esc":
+			m.showConflictDropConfirm = false
+			m.conflictDropConfirmIndex = 0
+			return m, nil
+		case "tab", "right", "l", "shift+tab", "left", "h":
+			m.conflictDropConfirmIndex = 1 - m.conflictDropConfirmIndex
+			return m, nil
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+q":
+		// Back to the suspended release
+		m.screen = screenRelease
+		m.initReleaseScreen()
+		return m, nil
+
+	case "up", "k":
+		if m.conflictIndex > 0 {
+			m.conflictIndex--
+			m.updateConflictViewport()
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.conflictIndex < len(m.conflictFiles)-1 {
+			m.conflictIndex++
+			m.updateConflictViewport()
+		}
+		return m, nil
+
+	case "d", "pgdown":
+		m.conflictViewport.HalfViewDown()
+		return m, nil
+
+	case "u", "pgup":
+		m.conflictViewport.HalfViewUp()
+		return m, nil
+
+	case "o":
+		return m.resolveConflictWith("ours")
+
+	case "t":
+		return m.resolveConflictWith("theirs")
+
+	case "e":
+		return m.openConflictEditor()
+
+	case "r":
+		return m.markConflictResolved()
+
+	case "x":
+		if m.canDropConflictedMR() {
+			m.showConflictDropConfirm = true
+			m.conflictDropConfirmIndex = 0
+		}
+		return m, nil
+
+	case "c":
+		return m.continueAfterConflicts()
+	}
+
+	var cmd tea.Cmd
+	m.conflictViewport, cmd = m.conflictViewport.Update(msg)
+	return m, cmd
+}
+
+// resolveConflictWith resolves the selected file with one side of the merge
+func (m model) resolveConflictWith(side string) (tea.Model, tea.Cmd) {
+	file := m.selectedConflictFile()
+	if file == "" || !m.conflictUnmerged[file] {
+		return m, nil
+	}
+
+	if err := ResolveConflictFile(m.releaseState.WorkDir, file, side); err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Failed to take %s for %s: %s", side, file, err.Error())
+		return m, nil
+	}
+	return m.afterConflictFileResolved()
+}
+
+// markConflictResolved stages the selected file after manual resolution
+func (m model) markConflictResolved() (tea.Model, tea.Cmd) {
+	file := m.selectedConflictFile()
+	if file == "" || !m.conflictUnmerged[file] {
+		return m, nil
+	}
+
+	// Refuse to stage leftover conflict markers
+	if content, err := os.ReadFile(filepath.Join(m.releaseState.WorkDir, file)); err == nil {
+		if len(parseConflictHunks(string(content), 0)) > 0 {
+			m.showErrorModal = true
+			m.errorModalMsg = fmt.Sprintf("%s still contains conflict markers", file)
+			return m, nil
+		}
+	}
+
+	if err := MarkConflictResolved(m.releaseState.WorkDir, file); err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Failed to mark %s resolved: %s", file, err.Error())
+		return m, nil
+	}
+	return m.afterConflictFileResolved()
+}
+
+// afterConflictFileResolved refreshes the file list and moves to the next unmerged file
+func (m model) afterConflictFileResolved() (tea.Model, tea.Cmd) {
+	if err := m.refreshConflictFiles(); err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+		return m, nil
+	}
+	m.selectNextUnmergedFile()
+	m.updateConflictViewport()
+	return m, nil
+}
+
+// openConflictEditor opens the selected file in $EDITOR (vi if unset)
+func (m model) openConflictEditor() (tea.Model, tea.Cmd) {
+	file := m.selectedConflictFile()
+	if file == "" {
+		return m, nil
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	args := append(editor[1:], filepath.Join(m.releaseState.WorkDir, file))
+	cmd := exec.Command(editor[0], args...)
+	cmd.Dir = m.releaseState.WorkDir
+
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return conflictEditorDoneMsg{file: file, err: err}
+	})
+}
+
+// handleConflictEditorDone refreshes the preview after the editor exits
+func (m model) handleConflictEditorDone(msg conflictEditorDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Editor failed for %s: %s", msg.file, msg.err.Error())
+	}
+	if err := m.refreshConflictFiles(); err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+	}
+	m.updateConflictViewport()
+	return m, nil
+}
+
+// dropConflictedMR aborts the conflicted MR merge, skips the MR and continues the release
+func (m model) dropConflictedMR() (tea.Model, tea.Cmd) {
+	if !m.canDropConflictedMR() {
+		return m, nil
+	}
+
+	m.screen = screenRelease
+	m.initReleaseScreen()
+	return m.skipReleaseMR(m.releaseState.CurrentMRIndex, "merge conflict")
+}
+
+// continueAfterConflicts returns to the release and finishes the merge once all files are resolved
+func (m model) continueAfterConflicts() (tea.Model, tea.Cmd) {
+	if err := m.refreshConflictFiles(); err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+		return m, nil
+	}
+
+	if len(m.conflictUnmerged) > 0 {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Resolve all conflicted files before continuing (%d left)", len(m.conflictUnmerged))
+		m.updateConflictViewport()
+		return m, nil
+	}
+
+	m.screen = screenRelease
+	m.initReleaseScreen()
+	return m.retryRelease()
+}
+
+// updateConflictViewport renders the hunk preview of the selected file
+func (m *model) updateConflictViewport() {
+	if m.conflictViewport.Width == 0 {
+		return
+	}
+	m.conflictViewport.SetContent(m.renderConflictPreview())
+	m.conflictViewport.GotoTop()
+}
+
+// renderConflictPreview renders the conflict hunks of the selected file
+func (m model) renderConflictPreview() string {
+	notionStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
+	fgStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+
+	file := m.selectedConflictFile()
+	if file == "" {
+		return notionStyle.Render("No conflicted files left.\nPress \"c\" to finish the merge and continue the release")
+	}
+
+	if !m.conflictUnmerged[file] {
+		return lipgloss.NewStyle().Foreground(currentTheme.Success).Render("Resolved") + "\n\n" +
+			notionStyle.Render("The file is staged and will be part of the merge commit")
+	}
+
+	content, err := os.ReadFile(filepath.Join(m.releaseState.WorkDir, file))
+	if err != nil {
+		return fgStyle.Render("The file was deleted on one side of the merge.") + "\n\n" +
+			notionStyle.Render("Press \"o\" or \"t\" to keep the version from that side\nor delete the file")
+	}
+
+	hunks := parseConflictHunks(string(content), conflictContextLines)
+	if len(hunks) == 0 {
+		return fgStyle.Render("No conflict markers found.") + "\n\n" +
+			notionStyle.Render("The file is binary or was already fixed in the editor.\nPress \"r\" to mark it resolved, or take one side with \"o\" or \"t\"")
+	}
+
+	oursStyle := lipgloss.NewStyle().Foreground(currentTheme.Warning)
+	theirsStyle := lipgloss.NewStyle().Foreground(currentTheme.Accent)
+	ours, theirs := m.conflictSides()
+
+	var sb strings.Builder
+	for i, hunk := range hunks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(notionStyle.Render(fmt.Sprintf("@@ conflict %d of %d at line %d @@", i+1, len(hunks), hunk.StartLine)))
+		sb.WriteString("\n")
+		for _, line := range hunk.Before {
+			sb.WriteString(fgStyle.Render("  " + line))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(oursStyle.Bold(true).Render("<<<<<<< ours: " + ours))
+		sb.WriteString("\n")
+		for _, line := range hunk.Ours {
+			sb.WriteString(oursStyle.Render("  " + line))
+			sb.WriteString("\n")
+		}
+		if len(hunk.Base) > 0 {
+			sb.WriteString(notionStyle.Render("||||||| base"))
+			sb.WriteString("\n")
+			for _, line := range hunk.Base {
+				sb.WriteString(notionStyle.Render("  " + line))
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString(notionStyle.Render("======="))
+		sb.WriteString("\n")
+		for _, line := range hunk.Theirs {
+			sb.WriteString(theirsStyle.Render("  " + line))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(theirsStyle.Bold(true).Render(">>>>>>> theirs: " + theirs))
+		sb.WriteString("\n")
+		for _, line := range hunk.After {
+			sb.WriteString(fgStyle.Render("  " + line))
+			sb.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// viewConflict renders the conflict resolution screen
+func (m model) viewConflict() string {
+	if m.width == 0 || m.height == 0 || m.releaseState == nil {
+		return ""
+	}
+
+	sidebarW := sidebarWidth(m.width)
+	contentWidth := m.width - sidebarW - 4
+	contentHeight := m.height - 4
+
+	sidebar := sidebarStyle.
+		Width(sidebarW).
+		Height(contentHeight).
+		Render(m.renderConflictFileList(sidebarW, contentHeight))
+
+	content := contentStyle.
+		Width(contentWidth).
+		Height(contentHeight).
+		Render(m.renderConflictContent(contentWidth - 2))
+
+	main := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, content)
+
+	helpText := "j/k: file • d/u: scroll • o: take ours • t: take theirs • e: edit • r: mark resolved"
+	if m.canDropConflictedMR() {
+		helpText += " • x: drop MR"
+	}
+	helpText += " • c: continue • esc: back"
+	help := helpStyle.Width(m.width).Align(lipgloss.Center).Render(helpText)
+
+	view := lipgloss.JoinVertical(lipgloss.Left, main, help)
+
+	if m.showConflictDropConfirm {
+		view = m.overlayConflictDropConfirm(view)
+	}
+
+	return view
+}
+
+// renderConflictFileList renders the sidebar with conflicted files and their resolution state
+func (m model) renderConflictFileList(width, height int) string {
+	var sb strings.Builder
+	sb.WriteString(envTitleStyle.Render(fmt.Sprintf(" Conflicted files (%d) ", len(m.conflictFiles))))
+	sb.WriteString("\n\n")
+
+	fileStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+	resolvedStyle := lipgloss.NewStyle().Foreground(currentTheme.Success)
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(currentTheme.Accent).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(currentTheme.Accent).
+		PaddingLeft(1)
+
+	// Keep the selected file visible when the list is taller than the sidebar
+	available := height - 3
+	if available < 1 {
+		available = 1
+	}
+	start := 0
+	if m.conflictIndex >= available {
+		start = m.conflictIndex - available + 1
+	}
+
+	for i := start; i < len(m.conflictFiles) && i < start+available; i++ {
+		file := m.conflictFiles[i]
+		marker := "✗ "
+		if !m.conflictUnmerged[file] {
+			marker = "✓ "
+		}
+		display := truncateWithEllipsis(marker+file, width-6)
+		switch {
+		case i == m.conflictIndex:
+			sb.WriteString(selectedStyle.Render(display))
+		case !m.conflictUnmerged[file]:
+			sb.WriteString(resolvedStyle.Render("  " + display))
+		default:
+			sb.WriteString(fileStyle.Render("  " + display))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// renderConflictContent renders the merge header and the hunk preview
+func (m model) renderConflictContent(width int) string {
+	ours, theirs := m.conflictSides()
+
+	resolved := len(m.conflictFiles) - len(m.conflictUnmerged)
+	header := fmt.Sprintf("%s merging %s into %s\n%s",
+		releaseConflictStyle.Render("CONFLICT"),
+		releaseOrangeStyle.Render(theirs),
+		releaseOrangeStyle.Render(ours),
+		releasePercentStyle.Render(fmt.Sprintf("%d of %d files resolved", resolved, len(m.conflictFiles))),
+	)
+
+	lines := []string{header, ""}
+	lines = append(lines, releaseHorizontalLineStyle.Render(strings.Repeat("─", width)))
+	lines = append(lines, releaseTerminalStyle.Render(m.conflictViewport.View()))
+	return strings.Join(lines, "\n")
+}
+
+// overlayConflictDropConfirm renders the drop MR confirmation modal
+func (m model) overlayConflictDropConfirm(background string) string {
+	var sb strings.Builder
+
+	branch := ""
+	if m.canDropConflictedMR() {
+		branch = m.releaseState.MRBranches[m.releaseState.CurrentMRIndex]
+	}
+
+	sb.WriteString(errorTitleStyle.Render("Drop MR from release?"))
+	sb.WriteString("\n\n")
+	sb.WriteString("The merge of " + releaseOrangeStyle.Render(branch) + "\n")
+	sb.WriteString("will be aborted and the release will\n")
+	sb.WriteString("continue with the remaining MRs.\n\n")
+
+	var dropBtn, cancelBtn string
+	if m.conflictDropConfirmIndex == 0 {
+		dropBtn = buttonDangerStyle.Render("Drop")
+		cancelBtn = buttonStyle.Render("Cancel")
+	} else {
+		dropBtn = buttonStyle.Render("Drop")
+		cancelBtn = buttonActiveStyle.Render("Cancel")
+	}
+	sb.WriteString(fmt.Sprintf("        %s        %s", dropBtn, cancelBtn))
+
+	config := ModalConfig{
+		Width:    ModalWidth{Value: 50, Percent: false},
+		MinWidth: 40,
+		MaxWidth: 60,
+		Style:    errorBoxStyle,
+	}
+
+	modal := renderModal(sb.String(), config, m.width)
+	return placeOverlayCenter(modal, background, m.width, m.height)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// devServerOptions holds parsed arguments of the dev-server subcommand
+type devServerOptions struct {
+	addr     string
+	fixtures string
+	upstream string
+}
+
+// parseDevServerFlags parses arguments of the dev-server subcommand
+func parseDevServerFlags(args []string) (*devServerOptions, error) {
+	opts := &devServerOptions{}
+	fs := flag.NewFlagSet("dev-server", flag.ContinueOnError)
+
+	fs.StringVar(&opts.addr, "addr", "localhost:8929", "Address to listen on")
+	fs.StringVar(&opts.fixtures, "fixtures", "", "Fixtures JSON file (default: built-in demo project)")
+	fs.StringVar(&opts.upstream, "record", "", "GitLab URL to proxy to, recording responses to the fixtures file")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: relix dev-server [--addr <host:port>] [--fixtures <file>] [--record <gitlab url>]\n\n")
+		fmt.Fprintf(os.Stderr, "Serves the GitLab API endpoints relix uses from fixtures, for working offline.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  --addr <host:port>              Address to listen on (default: localhost:8929)\n")
+		fmt.Fprintf(os.Stderr, "  --fixtures <file>               Fixtures JSON file (default: built-in demo project)\n")
+		fmt.Fprintf(os.Stderr, "  --record <gitlab url>           Proxy to a real GitLab and record its responses to --fixtures\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if opts.upstream != "" && opts.fixtures == "" {
+		return nil, fmt.Errorf("--record needs --fixtures to record to")
+	}
+	return opts, nil
+}
+
+// runDevServerCommand runs the dev-server subcommand and returns the process exit code
+func runDevServerCommand(args []string) int {
+	opts, err := parseDevServerFlags(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fixtures := demoGitLabFixtures()
+	if opts.fixtures != "" {
+		fixtures, err = LoadGitLabFixtures(opts.fixtures)
+		if os.IsNotExist(err) && opts.upstream != "" {
+			// Recording starts a new file
+			fixtures, err = &GitLabFixtures{}, nil
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	stub := newGitLabStub(fixtures)
+	stub.Upstream = opts.upstream
+	stub.Path = opts.fixtures
+
+	if opts.upstream != "" {
+		fmt.Printf("Recording %s to %s\n", opts.upstream, opts.fixtures)
+		fmt.Printf("Log in to relix with GitLab URL http://%s and your real token and email\n", opts.addr)
+	} else {
+		fmt.Printf("Serving GitLab fixtures on http://%s\n", opts.addr)
+		fmt.Printf("Log in to relix with GitLab URL http://%s, any token and email %s\n", opts.addr, demoUserEmail)
+	}
+	if err := http.ListenAndServe(opts.addr, stub); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// demoUserEmail is the email of the demo fixtures user
+const demoUserEmail = "dev@example.com"
+
+// demoGitLabFixtures returns the built-in fixtures of the dev server: a demo project with
+// three open MRs
+func demoGitLabFixtures() *GitLabFixtures {
+	const projectID = 1
+	projectURL := gitLabStubBaseURL + "/demo/app"
+	fixtures := &GitLabFixtures{}
+
+	fixtures.Set("GET /api/v4/user/emails", []map[string]string{{"email": demoUserEmail}})
+	fixtures.Set("GET /api/v4/projects?membership=true&per_page=100&order_by=last_activity_at", []Project{{
+		ID:                projectID,
+		Name:              "App",
+		NameWithNamespace: "Demo / App",
+		Path:              "app",
+		PathWithNamespace: "demo/app",
+		WebURL:            projectURL,
+	}})
+
+	author := GitLabUser{ID: 2, Username: "jdoe", Name: "Jane Doe"}
+	reviewer := GitLabUser{ID: 3, Username: "rroe", Name: "Richard Roe"}
+	milestone := &Milestone{ID: 1, IID: 1, Title: "Sprint 12", State: "active", WebURL: projectURL + "/-/milestones/1"}
+	labels := map[string]Label{
+		"feature": {ID: 1, Name: "feature", Color: "#428BCA"},
+		"bug":     {ID: 2, Name: "bug", Color: "#D9534F"},
+		"backend": {ID: 3, Name: "backend", Color: "#5CB85C"},
+	}
+
+	type demoMR struct {
+		iid         int
+		title       string
+		branch      string
+		labels      []string
+		draft       bool
+		commits     int
+		discussions []bool // Resolved state of each review thread
+		approvers   []GitLabUser
+		pipeline    string
+	}
+	demoMRs := []demoMR{
+		{1, "feat(auth): add SSO login", "feature/DEMO-101-sso-login", []string{"feature", "backend"}, false, 4, []bool{true, true}, []GitLabUser{reviewer}, "success"},
+		{2, "fix: keep cart items after session refresh", "fix/DEMO-102-cart-session", []string{"bug"}, false, 2, []bool{true, false}, nil, "failed"},
+		{3, "Draft: chore: bump dependencies", "chore/bump-deps", nil, true, 1, nil, nil, "running"},
+	}
+
+	var list []MergeRequest
+	for i, d := range demoMRs {
+		mr := MergeRequest{
+			ID:           100 + d.iid,
+			IID:          d.iid,
+			ProjectID:    projectID,
+			Title:        d.title,
+			Description:  fmt.Sprintf("Demo merge request !%d served by relix dev-server.", d.iid),
+			State:        "opened",
+			SourceBranch: d.branch,
+			TargetBranch: "root",
+			CreatedAt:    time.Now().Add(-time.Duration(i+1) * 26 * time.Hour),
+			Draft:        d.draft,
+			Labels:       d.labels,
+			Author:       author,
+			Milestone:    milestone,
+			WebURL:       fmt.Sprintf("%s/-/merge_requests/%d", projectURL, d.iid),
+			ChangesCount: fmt.Sprintf("%d", d.commits*3),
+			SHA:          fmt.Sprintf("%040x", d.iid),
+		}
+		list = append(list, mr)
+
+		mrPath := fmt.Sprintf("/api/v4/projects/%d/merge_requests/%d", projectID, d.iid)
+		single := struct {
+			MergeRequest
+			Labels []Label `json:"labels"`
+		}{MergeRequest: mr, Labels: []Label{}}
+		single.HeadPipeline = &Pipeline{ID: 500 + d.iid, Status: d.pipeline, Ref: d.branch, SHA: mr.SHA, WebURL: fmt.Sprintf("%s/-/pipelines/%d", projectURL, 500+d.iid)}
+		for _, name := range d.labels {
+			single.Labels = append(single.Labels, labels[name])
+		}
+		fixtures.Set("GET "+mrPath+"?with_labels_details=true", single)
+		fixtures.Set("GET "+mrPath, mr)
+		fixtures.Set(fmt.Sprintf("GET /api/v4/projects/%d/merge_requests?source_branch=%s&order_by=updated_at&sort=desc&per_page=1", projectID, d.branch), []MergeRequest{mr})
+
+		commits := []Commit{}
+		for n := 1; n <= d.commits; n++ {
+			commits = append(commits, Commit{
+				ID:           fmt.Sprintf("%038x%02d", d.iid, n),
+				ShortID:      fmt.Sprintf("%06x%02d", d.iid, n),
+				Title:        fmt.Sprintf("%s (part %d)", d.title, n),
+				AuthorName:   author.Name,
+				AuthoredDate: mr.CreatedAt.Add(time.Duration(n) * time.Hour),
+			})
+		}
+		fixtures.Set("GET "+mrPath+"/commits?per_page=100", commits)
+
+		discussions := []Discussion{}
+		for n, resolved := range d.discussions {
+			discussions = append(discussions, Discussion{
+				ID:    fmt.Sprintf("d%d-%d", d.iid, n),
+				Notes: []Note{{ID: d.iid*100 + n, Body: "Could this be simpler?", Author: reviewer, Resolvable: true, Resolved: resolved}},
+			})
+		}
+		fixtures.Set("GET "+mrPath+"/discussions?per_page=100", discussions)
+
+		approvals := Approvals{Approved: len(d.approvers) > 0, ApprovalsRequired: 1, ApprovalsLeft: 1}
+		for _, user := range d.approvers {
+			approvals.ApprovedBy = append(approvals.ApprovedBy, struct {
+				User GitLabUser `json:"user"`
+			}{user})
+			approvals.ApprovalsLeft = 0
+		}
+		fixtures.Set("GET "+mrPath+"/approvals", approvals)
+	}
+
+	fixtures.Set(fmt.Sprintf("GET /api/v4/projects/%d/merge_requests?state=opened&per_page=100", projectID), list)
+	fixtures.Set("GET /api/v4/merge_requests?state=opened&scope=all&per_page=100", list)
+	return fixtures
+}
@@ -10,12 +10,28 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// envMergeModes are the env merge modes in the order of the env merge screen options
+var envMergeModes = []string{"squash", "regular", "cherry-pick"}
+
+// envMergeModeIndex returns the env merge screen option of a mode, squash for unknown modes
+func envMergeModeIndex(mode string) int {
+	for i, m := range envMergeModes {
+		if m == mode {
+			return i
+		}
+	}
+	return 0
+}
+
 // updateEnvMerge handles key events on the env merge screen
 func (m model) updateEnvMerge(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+q":
-		// Go back to source branch input
+		// Go back to source branch input, or to env select when promoting a release
 		m.screen = screenSourceBranch
+		if m.promotion != nil {
+			m.screen = screenEnvSelect
+		}
 		return m, nil
 	case "up", "k":
 		if m.envMergeOptionIndex > 0 {
@@ -23,7 +39,7 @@ func (m model) updateEnvMerge(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "down", "j":
-		if m.envMergeOptionIndex < 1 {
+		if m.envMergeOptionIndex < len(envMergeModes)-1 {
 			m.envMergeOptionIndex++
 		}
 		return m, nil
@@ -127,6 +143,20 @@ func (m model) renderEnvMergeContent(width int) string {
 		sb.WriteString(dimDescStyle.Render(desc))
 	}
 
+	sb.WriteString("\n\n")
+
+	// Option 3: Cherry-pick
+	cherryPickDesc := fmt.Sprintf("  Only the commits of the selected MRs will be cherry-picked one by\n  one onto the current %s branch, without the rest of %s.\n  An MR already merged to base is picked as its merge commit.\n  Each commit may conflict; the release stops on it until resolved.", envName, sourceBranch)
+	if m.envMergeOptionIndex == 2 {
+		sb.WriteString(selectedBullet.Render("▸ Cherry-pick"))
+		sb.WriteString("\n")
+		sb.WriteString(descStyle.Render(cherryPickDesc))
+	} else {
+		sb.WriteString(unselectedBullet.Render("▹ Cherry-pick"))
+		sb.WriteString("\n")
+		sb.WriteString(dimDescStyle.Render(cherryPickDesc))
+	}
+
 	// Show commit count section (always visible, regardless of selected option)
 	sb.WriteString("\n\n")
 	activeDescStyle := descStyle
@@ -162,23 +192,25 @@ func (m model) renderEnvMergeSidebarSection(width int, contentHeight int) string
 	if m.screen == screenEnvMerge {
 		envMergeMode = m.envMergeOptionIndex // live preview while cursor is moving
 	} else if m.releaseState != nil && m.releaseState.EnvMergeMode != "" {
-		if m.releaseState.EnvMergeMode == "regular" {
-			envMergeMode = 1
-		} else {
-			envMergeMode = 0
-		}
+		envMergeMode = envMergeModeIndex(m.releaseState.EnvMergeMode)
 	}
 
-	if envMergeMode == 0 {
+	switch envMergeMode {
+	case 0:
 		squashStyle := lipgloss.NewStyle().
 			Foreground(currentTheme.Success).
 			Bold(true)
 		sb.WriteString(squashStyle.Render("Squash"))
-	} else {
+	case 1:
 		regularStyle := lipgloss.NewStyle().
 			Foreground(currentTheme.Warning).
 			Bold(true)
 		sb.WriteString(regularStyle.Render("Regular"))
+	default:
+		cherryPickStyle := lipgloss.NewStyle().
+			Foreground(currentTheme.Accent).
+			Bold(true)
+		sb.WriteString(cherryPickStyle.Render("Cherry-pick"))
 	}
 
 	// Wrap in bordered box
@@ -211,7 +243,7 @@ func (m model) calculateEnvMergeCommitCount() tea.Cmd {
 
 		// If source branch exists remotely, count divergence between env and source branch
 		// If source branch is new, count divergence between env and base branch + MR commits
-		sourceBranchExists := m.sourceBranchRemoteStatus == "exists-same" || m.sourceBranchRemoteStatus == "exists-diff"
+		sourceBranchExists := m.sourceBranchRemoteStatus == "exists-same" || m.sourceBranchRemoteStatus == "exists-diff" || m.sourceBranchRemoteStatus == "exists"
 
 		total := 0
 		if sourceBranchExists {
@@ -34,36 +34,43 @@ var (
 			Foreground(lipgloss.Color("189"))
 )
 
-// envColorByIndex returns the theme color for an environment by its position index
+// envColorByIndex returns the color for an environment by its position index:
+// the environment's own color from config, or the theme palette cycled for any count
 func envColorByIndex(index int) lipgloss.Color {
-	switch index {
-	case 0:
-		return currentTheme.EnvDevelop
-	case 1:
-		return currentTheme.EnvTest
-	case 2:
-		return currentTheme.EnvStage
-	case 3:
-		return currentTheme.EnvProd
-	default:
+	if color := envConfigColor(index); color != "" {
+		return lipgloss.Color(color)
+	}
+	return envPaletteColor(index)
+}
+
+// envPaletteColor returns the theme palette color for an environment position
+func envPaletteColor(index int) lipgloss.Color {
+	palette := currentTheme.EnvColors
+	if index < 0 || len(palette) == 0 {
 		return currentTheme.Foreground
 	}
+	return palette[index%len(palette)]
 }
 
 // envFgByIndex returns the foreground color paired with an environment background by index
 func envFgByIndex(index int) lipgloss.Color {
-	switch index {
-	case 0:
-		return currentTheme.AccentForeground
-	case 1:
-		return currentTheme.WarningForeground
-	case 2:
-		return currentTheme.SuccessForeground
-	case 3:
-		return currentTheme.ErrorForeground
-	default:
+	if color := envConfigColor(index); color != "" {
+		return contrastForeground(color)
+	}
+	fgs := currentTheme.EnvForegrounds
+	if index < 0 || len(fgs) == 0 {
 		return currentTheme.Foreground
 	}
+	return fgs[index%len(fgs)]
+}
+
+// envConfigColor returns the valid custom color of the environment at index, or ""
+func envConfigColor(index int) string {
+	configs := getEnvironmentConfigs()
+	if index < 0 || index >= len(configs) || !isValidHexColor(configs[index].Color) {
+		return ""
+	}
+	return configs[index].Color
 }
 
 // envIndexByName returns the position index for a given environment name
@@ -116,6 +123,11 @@ func getEnvSelectedStyle(envName string) lipgloss.Style {
 func (m model) updateEnvSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+q":
+		if m.promotion != nil {
+			// Leave the promotion back to the promoted release
+			m.cancelPromotion()
+			return m, nil
+		}
 		// Save current selection and go back to MR list
 		m.selectedEnv = &m.environments[m.envSelectIndex]
 		m.screen = screenMain
@@ -133,6 +145,20 @@ func (m model) updateEnvSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		// Save selected environment and proceed to version input
 		m.selectedEnv = &m.environments[m.envSelectIndex]
+		// Preselect the env merge mode configured for the environment
+		if env := envConfigByBranch(getEnvironmentConfigs(), m.selectedEnv.BranchName); env != nil {
+			if env.EnvMergeMode != "" {
+				index := envMergeModeIndex(env.EnvMergeMode)
+				m.envMergeOptionIndex, m.envMergeSelection = index, index
+			}
+		}
+		if m.promotion != nil {
+			// Version and source branch are taken from the promoted release
+			m.screen = screenEnvMerge
+			m.envMergeCommitCount = 0
+			m.envMergeCountLoading = true
+			return m, tea.Batch(m.spinner.Tick, m.calculateEnvMergeCommitCount())
+		}
 		// Only initialize version input if not already done
 		if m.versionInput.CharLimit == 0 {
 			m.versionInput = initVersionInput()
@@ -186,24 +212,18 @@ func (m model) viewEnvSelect() string {
 func (m model) renderSelectedMRsSidebar(width int) string {
 	var sb strings.Builder
 
-	// Count selected MRs
-	selectedCount := len(m.selectedMRs)
+	branches := m.wizardMRBranches()
 
-	title := fmt.Sprintf(" MRs to release (%d) ", selectedCount)
+	title := fmt.Sprintf(" MRs to release (%d) ", len(branches))
 	sb.WriteString(envTitleStepStyle.Render("[1]") +
 		envTitleStyle.Render(title))
 	sb.WriteString("\n\n")
 
 	// List selected MR branch names
-	items := m.list.Items()
-	for _, item := range items {
-		if mr, ok := item.(mrListItem); ok {
-			if m.selectedMRs[mr.MR().IID] {
-				branchName := truncateWithEllipsis(mr.MR().SourceBranch, width-2)
-				sb.WriteString(mrBranchStyle.Render(branchName))
-				sb.WriteString("\n")
-			}
-		}
+	for _, branch := range branches {
+		branchName := truncateWithEllipsis(branch, width-2)
+		sb.WriteString(mrBranchStyle.Render(branchName))
+		sb.WriteString("\n")
 	}
 
 	return sb.String()
@@ -219,6 +239,9 @@ func (m model) renderEnvSelection(width int) string {
 
 	// Prompt
 	prompt := "Select environment to release selected MRs to:"
+	if m.promotion != nil {
+		prompt = fmt.Sprintf("Select environment to promote %s to:", historyReleaseName(m.promotion))
+	}
 	sb.WriteString(envPromptStyle.Render(prompt))
 	sb.WriteString("\n\n")
 
@@ -235,7 +258,7 @@ func (m model) renderEnvSelection(width int) string {
 
 	// Hint with styled parts
 	selectedEnv := m.environments[m.envSelectIndex]
-	branchPart := fmt.Sprintf("release/rpb-<version>-%s", selectedEnv.BranchName)
+	branchPart := renderNamingTemplate(getNamingTemplates().EnvBranch, NamingVars{Version: "<version>", Env: selectedEnv.Name, EnvBranch: selectedEnv.BranchName})
 	hint := envHintBaseStyle.Render("Release branch ") +
 		getEnvBranchStyle(selectedEnv.Name).Render(branchPart) +
 		envHintBaseStyle.Render(" -> ") +
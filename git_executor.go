@@ -176,6 +176,7 @@ type GitExecutor struct {
 	ptyFile *os.File
 	cmd     *exec.Cmd
 	program *tea.Program // For sending messages back to UI
+	output  io.Writer    // Optional raw output stream (headless mode)
 	cols    uint16
 	rows    uint16
 	vterm   *VirtualTerminal
@@ -201,6 +202,13 @@ func (g *GitExecutor) SetSize(cols, rows uint16) {
 	g.rows = rows
 }
 
+// SetOutput sets a writer that receives command headers and raw command output as it is produced
+func (g *GitExecutor) SetOutput(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.output = w
+}
+
 // RunCommand executes a shell command via PTY and streams output through virtual terminal
 func (g *GitExecutor) RunCommand(command string) (string, error) {
 	cmd := exec.Command("sh", "-c", command)
@@ -211,6 +219,9 @@ func (g *GitExecutor) RunCommand(command string) (string, error) {
 	if g.program != nil {
 		g.program.Send(releaseCommandStartMsg{command: command})
 	}
+	if g.output != nil {
+		fmt.Fprintf(g.output, "\n$ %s\n", command)
+	}
 
 	// Start PTY
 	ptmx, err := pty.StartWithSize(cmd, &pty.Winsize{Rows: g.rows, Cols: g.cols})
@@ -225,8 +236,9 @@ func (g *GitExecutor) RunCommand(command string) (string, error) {
 	g.vterm = NewVirtualTerminal(int(g.cols), int(g.rows))
 	g.mu.Unlock()
 
-	// Capture vterm reference for goroutines
+	// Capture vterm and output references for goroutines
 	vterm := g.vterm
+	rawOutput := g.output
 
 	// Read raw bytes from PTY and feed to virtual terminal
 	var outputBuilder strings.Builder
@@ -238,6 +250,9 @@ func (g *GitExecutor) RunCommand(command string) (string, error) {
 			if n > 0 {
 				outputBuilder.Write(buf[:n])
 				vterm.Write(buf[:n])
+				if rawOutput != nil {
+					rawOutput.Write(buf[:n])
+				}
 			}
 			if err != nil {
 				close(readDone)
@@ -348,11 +363,155 @@ func (g *GitExecutor) Close() error {
 	return nil
 }
 
-// DetectMergeConflict checks if there's an unresolved merge conflict
+// DetectMergeConflict checks if there's an unresolved merge conflict in the executor's directory
+func (g *GitExecutor) DetectMergeConflict() bool {
+	return DetectMergeConflict(g.workDir)
+}
+
+// HasUncommittedChanges checks if there are uncommitted changes in the executor's directory
+func (g *GitExecutor) HasUncommittedChanges() (bool, error) {
+	return HasUncommittedChanges(g.workDir)
+}
+
+// IsBranchMerged checks if a branch is already merged into HEAD
+func (g *GitExecutor) IsBranchMerged(branch string) (bool, error) {
+	return IsBranchMerged(g.workDir, branch)
+}
+
+// RemoteBranchExists checks if a remote branch exists
+func (g *GitExecutor) RemoteBranchExists(remoteBranch string) bool {
+	return RemoteBranchExists(g.workDir, remoteBranch)
+}
+
+// GetBranchCommitID returns the full commit ID for a branch or empty string if it doesn't exist
+func (g *GitExecutor) GetBranchCommitID(branch string) string {
+	return GetBranchCommitID(g.workDir, branch)
+}
+
+// RemoteTagCommitID returns the commit a tag points to on origin, empty if there is no such tag
+func (g *GitExecutor) RemoteTagCommitID(tag string) (string, error) {
+	return RemoteTagCommitID(g.workDir, tag)
+}
+
+// GetNextVersionNumber returns the next v-number for the version on the env branch
+func (g *GitExecutor) GetNextVersionNumber(envBranch, currentVersion, titleTemplate string) (int, error) {
+	return GetNextVersionNumber(g.workDir, envBranch, currentVersion, titleTemplate)
+}
+
+// ListCommits returns the non-merge commits of base..head, oldest first
+func (g *GitExecutor) ListCommits(base, head string) ([]string, error) {
+	return ListCommits(g.workDir, base, head)
+}
+
+// FindMergeCommit returns the merge commit on branch that merged head, or empty string
+func (g *GitExecutor) FindMergeCommit(branch, head string) string {
+	return FindMergeCommit(g.workDir, branch, head)
+}
+
+// GetExcludedFiles returns tracked files matching exclusion patterns
+func (g *GitExecutor) GetExcludedFiles(patterns []string) ([]string, error) {
+	return GetExcludedFiles(g.workDir, patterns)
+}
+
+// DetectMergeConflict checks if there's an unresolved merge or cherry-pick conflict.
+// The state files are located with git, as .git is a file in a linked worktree.
 func DetectMergeConflict(workDir string) bool {
-	mergeHeadPath := filepath.Join(workDir, ".git", "MERGE_HEAD")
-	_, err := os.Stat(mergeHeadPath)
-	return err == nil
+	cmd := exec.Command("git", "rev-parse", "--git-path", "MERGE_HEAD", "--git-path", "CHERRY_PICK_HEAD")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	for _, path := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(workDir, path)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConflictedFiles returns the unmerged paths of the merge in progress
+func GetConflictedFiles(workDir string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// conflictStageExists checks if an unmerged file has an index entry for the given stage
+// (2 = ours, 3 = theirs). A missing stage means the file was deleted on that side.
+func conflictStageExists(workDir, file string, stage int) bool {
+	cmd := exec.Command("git", "ls-files", "-u", "--", file)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	// Format: <mode> <object> <stage>\t<path>
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[2] == fmt.Sprintf("%d", stage) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveConflictFile resolves a conflicted file with one side ("ours" or "theirs") and stages it.
+// If the file was deleted on that side, it is removed from the index and working tree.
+func ResolveConflictFile(workDir, file, side string) error {
+	stage := 2
+	if side == "theirs" {
+		stage = 3
+	}
+
+	var commands [][]string
+	if conflictStageExists(workDir, file, stage) {
+		commands = [][]string{
+			{"checkout", "--" + side, "--", file},
+			{"add", "--", file},
+		}
+	} else {
+		commands = [][]string{
+			{"rm", "-f", "--", file},
+		}
+	}
+
+	for _, args := range commands {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s failed: %s", args[0], strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// MarkConflictResolved stages a manually resolved file (including its deletion)
+func MarkConflictResolved(workDir, file string) error {
+	cmd := exec.Command("git", "add", "-A", "--", file)
+	cmd.Dir = workDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
 }
 
 // HasUncommittedChanges checks if there are uncommitted changes in the working directory
@@ -366,8 +525,17 @@ func HasUncommittedChanges(workDir string) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
-// IsBranchMerged checks if a branch is already merged into HEAD
+// IsBranchMerged checks if a branch, or a commit given by its full ID, is already merged into HEAD
 func IsBranchMerged(workDir, branch string) (bool, error) {
+	if isCommitID(branch) {
+		cmd := exec.Command("git", "merge-base", "--is-ancestor", branch, "HEAD")
+		cmd.Dir = workDir
+		err := cmd.Run()
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return err == nil, err
+	}
 	cmd := exec.Command("git", "branch", "--merged", "HEAD")
 	cmd.Dir = workDir
 	output, err := cmd.Output()
@@ -386,6 +554,19 @@ func IsBranchMerged(workDir, branch string) (bool, error) {
 	return false, nil
 }
 
+// isCommitID reports whether ref is a full hexadecimal commit ID
+func isCommitID(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	for _, c := range ref {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
 // BranchExists checks if a local branch exists
 func BranchExists(workDir, branch string) bool {
 	cmd := exec.Command("git", "rev-parse", "--verify", branch)
@@ -404,6 +585,64 @@ func RemoteBranchExists(workDir, remoteBranch string) bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
+// RemoteTagCommitID returns the commit a tag points to on origin, empty if there is no such tag.
+// Annotated tags are peeled to their commit.
+func RemoteTagCommitID(workDir, tag string) (string, error) {
+	ref := "refs/tags/" + tag
+	cmd := exec.Command("git", "ls-remote", "--tags", "origin", ref, ref+"^{}")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	commitID := ""
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		sha, name, ok := strings.Cut(line, "\t")
+		switch {
+		case !ok:
+		case name == ref+"^{}":
+			return sha, nil
+		case name == ref:
+			commitID = sha
+		}
+	}
+	return commitID, nil
+}
+
+// ListCommits returns the non-merge commits reachable from head but not from base, oldest first
+func ListCommits(workDir, base, head string) ([]string, error) {
+	cmd := exec.Command("git", "rev-list", "--reverse", "--no-merges", base+".."+head)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of %s..%s: %w", base, head, err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// FindMergeCommit returns the oldest merge commit on branch whose second parent is head,
+// or empty string if head was not merged into branch with a merge commit
+func FindMergeCommit(workDir, branch, head string) string {
+	headID := GetBranchCommitID(workDir, head)
+	if headID == "" {
+		return ""
+	}
+	cmd := exec.Command("git", "rev-list", "--merges", "--ancestry-path", "--reverse", "--parents", head+".."+branch)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		// Each line is the merge commit followed by its parents
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[2] == headID {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
 // GetBranchCommitID returns the full commit ID for a branch (local or remote like "origin/branch")
 // Returns empty string if branch doesn't exist
 func GetBranchCommitID(workDir, branch string) string {
@@ -455,13 +694,18 @@ func FindProjectRoot() (string, error) {
 type ReleaseCommands struct {
 	workDir              string
 	version              string
-	baseBranch           string   // Base branch (e.g. "root")
+	baseBranch           string // Base branch (e.g. "root")
 	envBranch            string
 	envName              string
 	excludePatterns      []string
-	branches             []string // MR source branches to merge
-	sourceBranch         string   // Custom source branch name (e.g. release/rpb-1.0.0-root)
-	sourceBranchIsRemote bool     // Whether source branch exists on remote
+	branches             []string        // MR source branches to merge
+	sourceBranch         string          // Custom source branch name (e.g. release/rpb-1.0.0-root)
+	sourceBranchIsRemote bool            // Whether source branch exists on remote
+	naming               NamingTemplates // Branch naming templates (defaults if unset)
+	date                 time.Time       // Release start date for the {date} placeholder
+	envReleaseBranch     string          // Env release branch name used instead of the template (rollbacks)
+	mrCommitSHAs         []string        // MR heads pinned at selection, merged instead of the branches
+	inWorktree           bool            // Runs in the release worktree: base, env and develop are used from origin
 }
 
 // NewReleaseCommands creates a new command builder
@@ -492,18 +736,52 @@ func NewReleaseCommandsWithSourceBranch(workDir, version, baseBranch string, env
 	}
 }
 
+// WithNaming sets the naming templates and the release start date used for branch names
+func (r *ReleaseCommands) WithNaming(naming NamingTemplates, date time.Time) *ReleaseCommands {
+	r.naming = naming
+	r.date = date
+	return r
+}
+
+// WithEnvReleaseBranch sets the env release branch name used instead of the naming template
+func (r *ReleaseCommands) WithEnvReleaseBranch(name string) *ReleaseCommands {
+	r.envReleaseBranch = name
+	return r
+}
+
+// WithMRCommitSHAs pins the merges of the MR branches to the commits they had when selected
+func (r *ReleaseCommands) WithMRCommitSHAs(shas []string) *ReleaseCommands {
+	r.mrCommitSHAs = shas
+	return r
+}
+
+// WithWorktree makes the commands leave the base, env and develop branches to the user's
+// checkout: the release worktree works on their origin heads in detached HEAD instead
+func (r *ReleaseCommands) WithWorktree(inWorktree bool) *ReleaseCommands {
+	r.inWorktree = inWorktree
+	return r
+}
+
+// namingVars returns the naming template values of the release
+func (r *ReleaseCommands) namingVars() NamingVars {
+	return NamingVars{Version: r.version, Env: r.envName, EnvBranch: r.envBranch, Date: r.date}
+}
+
 // ReleaseRootBranch returns the release root branch name (source branch for accumulating MRs)
 func (r *ReleaseCommands) ReleaseRootBranch() string {
 	// Use custom source branch if provided
 	if r.sourceBranch != "" {
 		return r.sourceBranch
 	}
-	return fmt.Sprintf("release/rpb-%s-root", r.version)
+	return renderNamingTemplate(r.naming.withDefaults().SourceBranch, r.namingVars())
 }
 
 // EnvReleaseBranch returns the environment release branch name
 func (r *ReleaseCommands) EnvReleaseBranch() string {
-	return fmt.Sprintf("release/rpb-%s-%s", r.version, r.envBranch)
+	if r.envReleaseBranch != "" {
+		return r.envReleaseBranch
+	}
+	return renderNamingTemplate(r.naming.withDefaults().EnvBranch, r.namingVars())
 }
 
 // StepGitFetch returns the command to fetch all remote updates
@@ -520,6 +798,10 @@ func (r *ReleaseCommands) Step1CheckoutRoot() []string {
 		// Source branch exists remotely - checkout from remote to reliably use it locally
 		return []string{fmt.Sprintf("git checkout -B %s origin/%s", r.ReleaseRootBranch(), r.ReleaseRootBranch())}
 	}
+	if r.inWorktree {
+		// The base branch may be checked out by the user, start from its origin head
+		return []string{fmt.Sprintf("git checkout -B %s origin/%s", r.ReleaseRootBranch(), r.baseBranch)}
+	}
 	// Source branch doesn't exist - create from base branch after pull
 	return []string{
 		fmt.Sprintf("git checkout %s", r.baseBranch),
@@ -528,17 +810,35 @@ func (r *ReleaseCommands) Step1CheckoutRoot() []string {
 	}
 }
 
-// Step2MergeBranch returns the command to merge a specific branch
+// PinnedCommit returns the commit a specific branch had when its MR was selected,
+// or "" if it was not recorded
+func (r *ReleaseCommands) PinnedCommit(branchIndex int) string {
+	if branchIndex < len(r.mrCommitSHAs) {
+		return r.mrCommitSHAs[branchIndex]
+	}
+	return ""
+}
+
+// Step2MergeBranch returns the command to merge a specific branch. A pinned commit is
+// merged instead of the remote branch, with the message git gives to merges of the latter.
 func (r *ReleaseCommands) Step2MergeBranch(branchIndex int) string {
 	if branchIndex >= len(r.branches) {
 		return ""
 	}
-	return fmt.Sprintf("GIT_EDITOR=true git merge --no-edit origin/%s", r.branches[branchIndex])
+	pinned := r.PinnedCommit(branchIndex)
+	if pinned == "" {
+		return fmt.Sprintf("GIT_EDITOR=true git merge --no-edit origin/%s", r.branches[branchIndex])
+	}
+	return fmt.Sprintf("GIT_EDITOR=true git merge --no-edit -m \"Merge remote-tracking branch 'origin/%s'\" %s",
+		r.branches[branchIndex], pinned)
 }
 
 // Step3CheckoutEnv returns the command for step 3
 // This handles the case where local env branch might not exist
 func (r *ReleaseCommands) Step3CheckoutEnv() []string {
+	if r.inWorktree {
+		return []string{fmt.Sprintf("git checkout -B %s origin/%s", r.EnvReleaseBranch(), r.envBranch)}
+	}
 	// Try to checkout local branch, if it fails try to create from remote
 	return []string{
 		fmt.Sprintf(`git checkout %s 2>/dev/null || git checkout -b %s origin/%s`, r.envBranch, r.envBranch, r.envBranch),
@@ -568,16 +868,41 @@ func (r *ReleaseCommands) Step6Push() string {
 	return fmt.Sprintf("git push -u origin %s", r.EnvReleaseBranch())
 }
 
-// StepMergeToRoot returns the command to merge source branch to base branch
+// StepMergeToRoot returns the command to merge source branch to base branch.
+// In a worktree a retry keeps the merge commit of the previous attempt when HEAD still is
+// the merge of origin/<base> and the source branch: merging again would make a new commit,
+// so a tag move confirmed for the previous one would never match.
 func (r *ReleaseCommands) StepMergeToRoot() []string {
+	if r.inWorktree {
+		return []string{
+			fmt.Sprintf(`[ "$(git rev-parse HEAD^1 HEAD^2 2>/dev/null)" = "$(git rev-parse origin/%s %s)" ] || { git checkout --detach origin/%s && git merge --no-edit -m "Merge branch '%s' into %s" %s; }`,
+				r.baseBranch, r.ReleaseRootBranch(), r.baseBranch, r.ReleaseRootBranch(), r.baseBranch, r.ReleaseRootBranch()),
+		}
+	}
 	return []string{
 		fmt.Sprintf("git checkout %s", r.baseBranch),
 		fmt.Sprintf("git merge --no-edit %s", r.ReleaseRootBranch()),
 	}
 }
 
+// StepPushBase returns the command to push the base branch with the release merged
+func (r *ReleaseCommands) StepPushBase() string {
+	if r.inWorktree {
+		return fmt.Sprintf("git push origin HEAD:refs/heads/%s --force", r.baseBranch)
+	}
+	return fmt.Sprintf("git push origin %s --force", r.baseBranch)
+}
+
 // StepMergeToDevelop returns the command to merge base branch to develop and push
 func (r *ReleaseCommands) StepMergeToDevelop() []string {
+	if r.inWorktree {
+		return []string{
+			fmt.Sprintf("git fetch origin %s develop", r.baseBranch),
+			"git checkout --detach origin/develop",
+			fmt.Sprintf("git merge --no-edit -m \"Merge branch '%s' into develop\" origin/%s", r.baseBranch, r.baseBranch),
+			"git push origin HEAD:refs/heads/develop",
+		}
+	}
 	return []string{
 		"git checkout develop",
 		"git pull",
@@ -587,27 +912,45 @@ func (r *ReleaseCommands) StepMergeToDevelop() []string {
 }
 
 // ParseVersionNumber extracts version number from release commit title
-// Format: "release:{version} {envBranch} vN"
+// built by the commit title template (default "release:{version} {envBranch} v{n}")
 // Returns (versionStr, vNumber, found)
-func ParseVersionNumber(commitTitle, envBranch string) (string, int, bool) {
-	// Pattern: release:{version} {envBranch} v{N}
-	pattern := `^\s*release\s*:\s*([-0-9.\s]+)\s+.+\s+v?(\d+)$`
-	re := regexp.MustCompile(pattern)
+func ParseVersionNumber(commitTitle, envBranch, titleTemplate string) (string, int, bool) {
+	if titleTemplate == "" {
+		titleTemplate = defaultNamingTemplates().CommitTitle
+	}
+	re, err := regexp.Compile(`^\s*` + namingTemplatePattern(titleTemplate, envBranch) + `\s*$`)
+	if err != nil {
+		return "", 0, false
+	}
 	matches := re.FindStringSubmatch(commitTitle)
-	if len(matches) != 3 {
-		// Try without v-number for legacy format
-		patternNoV := `^\s*release\s*:\s*([-0-9.\s]+)`
-		reNoV := regexp.MustCompile(patternNoV)
+	if matches == nil {
+		// Try without v-number for legacy format: the template up to {version}, e.g. "release:{version}".
+		// A title ending with a number has a v-number in another shape and is not guessed.
+		if releaseTitleNumberRe.MatchString(commitTitle) {
+			return "", 0, false
+		}
+		versionEnd := strings.Index(titleTemplate, namingVersion)
+		if versionEnd < 0 || strings.Contains(titleTemplate[:versionEnd], namingNumber) {
+			return "", 0, false
+		}
+		prefix := titleTemplate[:versionEnd] + namingVersion
+		reNoV, err := regexp.Compile(`^\s*` + namingTemplatePattern(prefix, envBranch) + `(?:\s|$)`)
+		if err != nil {
+			return "", 0, false
+		}
 		matchesNoV := reNoV.FindStringSubmatch(commitTitle)
-		if len(matchesNoV) == 2 {
-			return matchesNoV[1], 1, true // Treat as v1 if no v-number
+		if matchesNoV == nil {
+			return "", 0, false
 		}
-		return "", 0, false
+		return strings.TrimSpace(matchesNoV[reNoV.SubexpIndex("version")]), 1, true // Treat as v1 if no v-number
 	}
-	vNum, _ := strconv.Atoi(matches[2])
-	return matches[1], vNum, true
+	vNum, _ := strconv.Atoi(matches[re.SubexpIndex("n")])
+	return strings.TrimSpace(matches[re.SubexpIndex("version")]), vNum, true
 }
 
+// releaseTitleNumberRe matches a release commit title ending with a v-number
+var releaseTitleNumberRe = regexp.MustCompile(`\sv?\d+\s*$`)
+
 // NormalizeVersion normalizes version string by removing leading zeros
 // 4.05.01 -> 4.5.1
 func NormalizeVersion(version string) string {
@@ -639,9 +982,10 @@ func VersionsMatch(v1, v2 string) bool {
 	return n1 == n2
 }
 
-// GetNextVersionNumber parses git log and returns the next v-number to use
+// GetNextVersionNumber parses git log and returns the next v-number to use.
+// Release commits are recognized by the commit title template.
 // Returns (vNumber, error)
-func GetNextVersionNumber(workDir, envBranch, currentVersion string) (int, error) {
+func GetNextVersionNumber(workDir, envBranch, currentVersion, titleTemplate string) (int, error) {
 	cmd := exec.Command("git", "log", fmt.Sprintf("origin/%s", envBranch), "-n", "10", "--pretty=%s")
 	cmd.Dir = workDir
 	output, err := cmd.Output()
@@ -656,7 +1000,7 @@ func GetNextVersionNumber(workDir, envBranch, currentVersion string) (int, error
 			continue
 		}
 
-		version, vNum, found := ParseVersionNumber(line, envBranch)
+		version, vNum, found := ParseVersionNumber(line, envBranch, titleTemplate)
 		if !found {
 			continue
 		}
@@ -674,13 +1018,20 @@ func GetNextVersionNumber(workDir, envBranch, currentVersion string) (int, error
 	return 1, nil
 }
 
-// BuildCommitMessage builds the commit message for step 4
-func BuildCommitMessage(version, envBranch string, vNumber int, branches []string) (string, string) {
-	title := fmt.Sprintf("release:%s %s v%d", version, envBranch, vNumber)
+// BuildCommitMessage builds the commit message for step 4 from the commit title template.
+// The body lists the released branches and the tracker issues found in them.
+func BuildCommitMessage(titleTemplate string, vars NamingVars, branches []string, rules []TrackerRule) (string, string) {
+	title := renderNamingTemplate(titleTemplate, vars)
 	var body strings.Builder
 	for _, branch := range branches {
 		body.WriteString("- " + branch + "\n")
 	}
+	if issues := findTrackerIssues(rules, branches...); len(issues) > 0 {
+		body.WriteString("\nIssues:\n")
+		for _, issue := range issues {
+			body.WriteString("- " + issue.Key + " " + issue.URL + "\n")
+		}
+	}
 	return title, body.String()
 }
 
@@ -779,10 +1130,7 @@ func matchGlob(path, pattern string) bool {
 }
 
 // DeleteLocalBranches deletes local branches created during release
-func DeleteLocalBranches(workDir, version, envBranch, baseBranch string) error {
-	rootBranch := fmt.Sprintf("release/rpb-%s-root", version)
-	envReleaseBranch := fmt.Sprintf("release/rpb-%s-%s", version, envBranch)
-
+func DeleteLocalBranches(workDir, baseBranch, rootBranch, envReleaseBranch string) error {
 	// Checkout to base branch first to avoid "cannot delete checked out branch"
 	cmd := exec.Command("git", "checkout", baseBranch)
 	cmd.Dir = workDir
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestParseVersionNumber(t *testing.T) {
+	tests := []struct {
+		title    string
+		env      string
+		template string
+		version  string
+		n        int
+		found    bool
+	}{
+		// Default template and the title shapes written before templates existed
+		{"release:1.0.0 testing v5", "testing", "", "1.0.0", 5, true},
+		{"release:1.0.0 testing 5", "testing", "", "1.0.0", 5, true},
+		{"release : 1.0.0 testing v4", "testing", "", "1.0.0", 4, true},
+		{"release: 1.0.0 testing v3", "testing", "", "1.0.0", 3, true},
+		{"  release:2.10.1   testing   v12  ", "testing", "", "2.10.1", 12, true},
+		{"release:1.0.0-1 testing v2", "testing", "", "1.0.0-1", 2, true},
+
+		// Legacy titles without a v-number are v1
+		{"release:1.0.0", "testing", "", "1.0.0", 1, true},
+		{"release: 1.0.0 testing", "testing", "", "1.0.0", 1, true},
+
+		// A trailing number in another shape is not read as v1
+		{"release:1.0.0 other v7", "testing", "", "", 0, false},
+		{"Merge branch 'feat/x'", "testing", "", "", 0, false},
+		{"hotfix: 1.0.0 testing v2", "testing", "", "", 0, false},
+
+		// Custom templates
+		{"deploy 1.2.3 to testing #4", "testing", "deploy {version} to {envBranch} #{n}", "1.2.3", 4, true},
+		{"testing: v1.2.3 build v6", "testing", "{envBranch}: v{version} build v{n}", "1.2.3", 6, true},
+		{"testing: v1.2.3 build 6", "testing", "{envBranch}: v{version} build v{n}", "1.2.3", 6, true},
+	}
+
+	for _, tt := range tests {
+		version, n, found := ParseVersionNumber(tt.title, tt.env, tt.template)
+		if version != tt.version || n != tt.n || found != tt.found {
+			t.Errorf("ParseVersionNumber(%q, %q, %q) = %q, %d, %v; want %q, %d, %v",
+				tt.title, tt.env, tt.template, version, n, found, tt.version, tt.n, tt.found)
+		}
+	}
+}
+
+func TestGetNextVersionNumber(t *testing.T) {
+	h, err := NewGitHarness("root", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Cleanup()
+
+	// Older titles on the env branch, the latest one first in the log
+	for _, title := range []string{"release:1.0.0 test v1", "release : 1.0.0 test 2"} {
+		if _, err := h.Git("commit", "--allow-empty", "-m", title); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := h.Git("push", "-f", "origin", "root:test"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		version string
+		want    int
+	}{
+		{"1.0.0", 3},
+		{"1.00.0", 3},
+		{"1.1.0", 1},
+	}
+	for _, tt := range tests {
+		got, err := GetNextVersionNumber(h.WorkDir, "test", tt.version, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("GetNextVersionNumber(%q) = %d, want %d", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// fakeGitResult is a scripted result for commands starting with prefix
+type fakeGitResult struct {
+	prefix   string
+	output   string
+	err      error
+	conflict bool // Leave a merge in progress after the command
+}
+
+// FakeGitRunner is an in-memory GitRunner that records every call and returns
+// scripted results, so release steps can run without a real repository.
+//
+// Shell commands succeed with empty output unless a result was scripted with
+// OnCommand or OnConflict. Each scripted result is used once, in order, which
+// lets a retry of the same command behave differently from the first attempt.
+type FakeGitRunner struct {
+	mu      sync.Mutex
+	calls   []string
+	results []fakeGitResult
+
+	MergeConflict      bool                // Result of DetectMergeConflict
+	UncommittedChanges bool                // Result of HasUncommittedChanges
+	MergedBranches     map[string]bool     // Branches reported as merged into HEAD
+	RemoteBranches     map[string]bool     // Branches reported as existing on origin
+	CommitIDs          map[string]string   // Commit IDs returned by GetBranchCommitID
+	RemoteTags         map[string]string   // Commits of tags on origin returned by RemoteTagCommitID
+	NextVersion        int                 // Result of GetNextVersionNumber (0 means 1)
+	ExcludedFiles      []string            // Result of GetExcludedFiles
+	Commits            map[string][]string // Results of ListCommits by "base..head"
+	MergeCommits       map[string]string   // Results of FindMergeCommit by "branch..head"
+}
+
+// NewFakeGitRunner creates a fake runner with empty repository state
+func NewFakeGitRunner() *FakeGitRunner {
+	return &FakeGitRunner{
+		MergedBranches: make(map[string]bool),
+		RemoteBranches: make(map[string]bool),
+		CommitIDs:      make(map[string]string),
+		RemoteTags:     make(map[string]string),
+		Commits:        make(map[string][]string),
+		MergeCommits:   make(map[string]string),
+	}
+}
+
+// OnCommand scripts the next command starting with prefix to return output and err
+func (f *FakeGitRunner) OnCommand(prefix, output string, err error) *FakeGitRunner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, fakeGitResult{prefix: prefix, output: output, err: err})
+	return f
+}
+
+// OnConflict scripts the next command starting with prefix to fail with a merge conflict.
+// The merge stays in progress until it is continued, skipped or aborted.
+func (f *FakeGitRunner) OnConflict(prefix string) *FakeGitRunner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, fakeGitResult{
+		prefix:   prefix,
+		output:   "CONFLICT (content): Merge conflict\nAutomatic merge failed; fix conflicts and then commit the result.\n",
+		err:      fmt.Errorf("exit status 1"),
+		conflict: true,
+	})
+	return f
+}
+
+// Calls returns all recorded calls in order.
+// Shell commands are recorded verbatim, helper calls as "Helper arg...".
+func (f *FakeGitRunner) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.calls...)
+}
+
+// Commands returns only the recorded shell commands
+func (f *FakeGitRunner) Commands() []string {
+	var commands []string
+	for _, call := range f.Calls() {
+		if strings.HasPrefix(call, "$ ") {
+			commands = append(commands, strings.TrimPrefix(call, "$ "))
+		}
+	}
+	return commands
+}
+
+// record appends a call to the log; callers must hold the lock
+func (f *FakeGitRunner) record(call string) {
+	f.calls = append(f.calls, call)
+}
+
+// RunCommand records the command and returns its scripted result
+func (f *FakeGitRunner) RunCommand(command string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("$ " + command)
+
+	for i, result := range f.results {
+		if strings.HasPrefix(command, result.prefix) {
+			f.results = append(f.results[:i], f.results[i+1:]...)
+			if result.conflict {
+				f.MergeConflict = true
+			}
+			return result.output, result.err
+		}
+	}
+
+	// Finishing a merge or cherry-pick resolves the conflict
+	for _, finish := range []string{"git merge --continue", "git merge --abort", "git cherry-pick --continue", "git cherry-pick --skip", "git cherry-pick --abort"} {
+		if strings.Contains(command, finish) {
+			f.MergeConflict = false
+		}
+	}
+	return "", nil
+}
+
+// RunCommands runs commands sequentially, stopping on first error
+func (f *FakeGitRunner) RunCommands(commands []string) (string, error) {
+	var output strings.Builder
+	for _, cmd := range commands {
+		out, err := f.RunCommand(cmd)
+		output.WriteString(out)
+		if err != nil {
+			return output.String(), err
+		}
+	}
+	return output.String(), nil
+}
+
+// DetectMergeConflict reports whether a scripted conflict is in progress
+func (f *FakeGitRunner) DetectMergeConflict() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("DetectMergeConflict")
+	return f.MergeConflict
+}
+
+// HasUncommittedChanges reports the scripted working tree state
+func (f *FakeGitRunner) HasUncommittedChanges() (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("HasUncommittedChanges")
+	return f.UncommittedChanges, nil
+}
+
+// IsBranchMerged reports whether the branch is in MergedBranches
+func (f *FakeGitRunner) IsBranchMerged(branch string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("IsBranchMerged " + branch)
+	return f.MergedBranches[branch], nil
+}
+
+// RemoteBranchExists reports whether the branch is in RemoteBranches
+func (f *FakeGitRunner) RemoteBranchExists(remoteBranch string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("RemoteBranchExists " + remoteBranch)
+	return f.RemoteBranches[remoteBranch]
+}
+
+// GetBranchCommitID returns the commit ID from CommitIDs
+func (f *FakeGitRunner) GetBranchCommitID(branch string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("GetBranchCommitID " + branch)
+	return f.CommitIDs[branch]
+}
+
+// RemoteTagCommitID returns the commit from RemoteTags
+func (f *FakeGitRunner) RemoteTagCommitID(tag string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("RemoteTagCommitID " + tag)
+	return f.RemoteTags[tag], nil
+}
+
+// GetNextVersionNumber returns NextVersion (1 if unset)
+func (f *FakeGitRunner) GetNextVersionNumber(envBranch, currentVersion, titleTemplate string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("GetNextVersionNumber " + envBranch + " " + currentVersion)
+	if f.NextVersion == 0 {
+		return 1, nil
+	}
+	return f.NextVersion, nil
+}
+
+// GetExcludedFiles returns ExcludedFiles regardless of patterns
+func (f *FakeGitRunner) GetExcludedFiles(patterns []string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("GetExcludedFiles")
+	return append([]string{}, f.ExcludedFiles...), nil
+}
+
+// ListCommits returns the commits from Commits
+func (f *FakeGitRunner) ListCommits(base, head string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("ListCommits " + base + ".." + head)
+	return append([]string{}, f.Commits[base+".."+head]...), nil
+}
+
+// FindMergeCommit returns the merge commit from MergeCommits
+func (f *FakeGitRunner) FindMergeCommit(branch, head string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("FindMergeCommit " + branch + ".." + head)
+	return f.MergeCommits[branch+".."+head]
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GitHarness builds a throwaway bare origin and a working clone in a temp dir,
+// so release steps can run against real git without touching the user's project.
+type GitHarness struct {
+	Dir        string // Temp root containing both repositories
+	Origin     string // Bare origin repository path
+	WorkDir    string // Working clone path (project root for the release)
+	BaseBranch string // Base branch the other branches start from
+}
+
+// NewGitHarness creates the origin and clone with an initial commit on baseBranch.
+// Each of envBranches is pushed to origin pointing at the initial commit.
+func NewGitHarness(baseBranch string, envBranches ...string) (*GitHarness, error) {
+	dir, err := os.MkdirTemp("", "relix-harness-")
+	if err != nil {
+		return nil, err
+	}
+
+	h := &GitHarness{
+		Dir:        dir,
+		Origin:     filepath.Join(dir, "origin.git"),
+		WorkDir:    filepath.Join(dir, "work"),
+		BaseBranch: baseBranch,
+	}
+
+	setup := [][]string{
+		{"init", "--bare", "--initial-branch=" + baseBranch, h.Origin},
+		{"init", "--initial-branch=" + baseBranch, h.WorkDir},
+	}
+	for _, args := range setup {
+		if _, err := h.run(dir, args...); err != nil {
+			h.Cleanup()
+			return nil, err
+		}
+	}
+
+	setup = [][]string{
+		{"config", "user.name", "Relix Harness"},
+		{"config", "user.email", "harness@relix.local"},
+		{"config", "commit.gpgsign", "false"},
+		{"remote", "add", "origin", h.Origin},
+	}
+	for _, args := range setup {
+		if _, err := h.Git(args...); err != nil {
+			h.Cleanup()
+			return nil, err
+		}
+	}
+
+	if err := h.commitFiles(map[string]string{"README.md": "# harness\n"}, "initial commit"); err != nil {
+		h.Cleanup()
+		return nil, err
+	}
+	if _, err := h.Git("push", "-u", "origin", baseBranch); err != nil {
+		h.Cleanup()
+		return nil, err
+	}
+	for _, branch := range envBranches {
+		if _, err := h.Git("push", "origin", baseBranch+":refs/heads/"+branch); err != nil {
+			h.Cleanup()
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// run executes git in dir and returns trimmed combined output
+func (h *GitHarness) run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Git runs a git command in the working clone
+func (h *GitHarness) Git(args ...string) (string, error) {
+	return h.run(h.WorkDir, args...)
+}
+
+// commitFiles writes files in the working clone and commits them on the current branch
+func (h *GitHarness) commitFiles(files map[string]string, message string) error {
+	// Sorted for deterministic commits
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		full := filepath.Join(h.WorkDir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(full, []byte(files[path]), 0o644); err != nil {
+			return err
+		}
+	}
+	if _, err := h.Git(append([]string{"add", "--"}, paths...)...); err != nil {
+		return err
+	}
+	_, err := h.Git("commit", "-m", message)
+	return err
+}
+
+// PushBranch creates branch from origin/from, commits files on it, pushes it
+// to origin and switches back to the base branch. Returns the new head SHA.
+func (h *GitHarness) PushBranch(branch, from string, files map[string]string, message string) (string, error) {
+	if _, err := h.Git("fetch", "origin"); err != nil {
+		return "", err
+	}
+	if _, err := h.Git("checkout", "-B", branch, "origin/"+from); err != nil {
+		return "", err
+	}
+	if err := h.commitFiles(files, message); err != nil {
+		return "", err
+	}
+	if _, err := h.Git("push", "-f", "origin", branch); err != nil {
+		return "", err
+	}
+	sha, err := h.Git("rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if _, err := h.Git("checkout", h.BaseBranch); err != nil {
+		return "", err
+	}
+	if _, err := h.Git("branch", "-D", branch); err != nil {
+		return "", err
+	}
+	return sha, nil
+}
+
+// RemoteHead returns the SHA of a branch in origin or empty string if it doesn't exist
+func (h *GitHarness) RemoteHead(branch string) string {
+	sha, err := h.run(h.Origin, "rev-parse", "--verify", "-q", "refs/heads/"+branch)
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
+// FileAt returns the content of path at rev in origin
+func (h *GitHarness) FileAt(rev, path string) (string, error) {
+	return h.run(h.Origin, "show", rev+":"+path)
+}
+
+// Executor returns a PTY git executor working in the clone without UI output
+func (h *GitHarness) Executor() *GitExecutor {
+	return NewGitExecutor(h.WorkDir, nil)
+}
+
+// NewState builds an initial release state for the clone, as startRelease would
+func (h *GitHarness) NewState(env Environment, version string, branches []string) *ReleaseState {
+	sourceBranch := renderNamingTemplate(defaultNamingTemplates().SourceBranch, NamingVars{Version: version, Env: env.Name, EnvBranch: env.BranchName})
+	state := &ReleaseState{
+		MRBranches:           branches,
+		Environment:          env,
+		Version:              version,
+		BaseBranch:           h.BaseBranch,
+		SourceBranch:         sourceBranch,
+		SourceBranchIsRemote: h.RemoteHead(sourceBranch) != "",
+		EnvMergeMode:         "squash",
+		CurrentStep:          ReleaseStepGitFetch,
+		LastSuccessStep:      ReleaseStepIdle,
+		MergedBranches:       []string{},
+		WorkDir:              h.WorkDir,
+	}
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+	return state
+}
+
+// Cleanup removes the temp dir with both repositories
+func (h *GitHarness) Cleanup() error {
+	return os.RemoveAll(h.Dir)
+}
@@ -0,0 +1,33 @@
+package main
+
+// GitRunner abstracts git access of the release flow.
+// GitExecutor is the PTY-backed implementation used by the TUI and headless mode;
+// FakeGitRunner records calls and returns scripted results for deterministic runs.
+type GitRunner interface {
+	// RunCommand executes a shell command in the project directory
+	RunCommand(command string) (string, error)
+	// RunCommands executes shell commands sequentially, stopping on first error
+	RunCommands(commands []string) (string, error)
+
+	// DetectMergeConflict checks if a merge or cherry-pick is in progress (MERGE_HEAD or CHERRY_PICK_HEAD exists)
+	DetectMergeConflict() bool
+	// HasUncommittedChanges checks if the working tree is dirty
+	HasUncommittedChanges() (bool, error)
+	// IsBranchMerged checks if a branch is already merged into HEAD
+	IsBranchMerged(branch string) (bool, error)
+	// RemoteBranchExists checks if a branch exists on origin
+	RemoteBranchExists(remoteBranch string) bool
+	// GetBranchCommitID returns the commit ID of a branch or empty string
+	GetBranchCommitID(branch string) string
+	// RemoteTagCommitID returns the commit a tag points to on origin, empty if origin has no such tag
+	RemoteTagCommitID(tag string) (string, error)
+	// GetNextVersionNumber returns the next v-number for the version on the env branch,
+	// recognizing release commits by the commit title template
+	GetNextVersionNumber(envBranch, currentVersion, titleTemplate string) (int, error)
+	// GetExcludedFiles returns tracked files matching exclusion patterns
+	GetExcludedFiles(patterns []string) ([]string, error)
+	// ListCommits returns the non-merge commits reachable from head but not from base, oldest first
+	ListCommits(base, head string) ([]string, error)
+	// FindMergeCommit returns the merge commit on branch whose second parent is head, empty if none
+	FindMergeCommit(branch, head string) string
+}
@@ -1,12 +1,13 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,6 +16,7 @@ type GitLabClient struct {
 	baseURL string
 	token   string
 	client  *http.Client
+	ctx     context.Context // Cancels the requests, see WithContext
 }
 
 // NewGitLabClient creates a new GitLab API client
@@ -22,41 +24,17 @@ func NewGitLabClient(baseURL, token string) *GitLabClient {
 	return &GitLabClient{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		token:   token,
-		client:  &http.Client{Timeout: 10 * time.Second},
+		client:  &http.Client{Timeout: 10 * time.Second}, // Per attempt, see request
 	}
 }
 
 // GetUserEmails retrieves the authenticated user's emails
 func (c *GitLabClient) GetUserEmails() ([]string, error) {
-	url := c.baseURL + "/api/v4/user/emails"
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 401 {
-		return nil, fmt.Errorf("invalid token: authentication failed")
-	}
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
 	var emails []struct {
 		Email string `json:"email"`
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&emails); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if _, err := c.request("GET", c.baseURL+"/api/v4/user/emails", nil, &emails); err != nil {
+		return nil, err
 	}
 
 	result := make([]string, len(emails))
@@ -86,202 +64,161 @@ func ValidateCredentials(creds Credentials) error {
 	return fmt.Errorf("email '%s' not found in your GitLab account", creds.Email)
 }
 
-// GetOpenMergeRequests fetches open merge requests for the current user
-func (c *GitLabClient) GetOpenMergeRequests() ([]*MergeRequestDetails, error) {
-	// Get MRs where user is assignee or reviewer
-	url := c.baseURL + "/api/v4/merge_requests?state=opened&scope=all&per_page=100"
-
-	req, err := http.NewRequest("GET", url, nil)
+// GetOpenMergeRequests fetches all open merge requests visible to the current user.
+// With lazy set only the list fields are filled, details are fetched with GetMergeRequestDetails.
+func (c *GitLabClient) GetOpenMergeRequests(lazy bool) ([]*MergeRequestDetails, error) {
+	mrs, err := getAllPages[MergeRequest](c, c.baseURL+"/api/v4/merge_requests?state=opened&scope=all&per_page=100")
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
-	var mrs []MergeRequest
-	if err := json.NewDecoder(resp.Body).Decode(&mrs); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Fetch additional details for each MR
-	result := make([]*MergeRequestDetails, 0, len(mrs))
-	for _, mr := range mrs {
-		details, err := c.GetMergeRequestDetails(mr)
-		if err != nil {
-			// Skip MRs we can't get details for
-			continue
-		}
-		result = append(result, details)
+		return nil, err
 	}
-
-	return result, nil
+	return c.mergeRequestsDetails(mrs, lazy), nil
 }
 
-// GetMergeRequestDetails fetches detailed info for a merge request
+// GetMergeRequestDetails fetches detailed info for a merge request: changes count, head
+// pipeline, labels, commits, discussions and approvals. Parts that cannot be fetched are
+// left empty with their error set on the details.
 func (c *GitLabClient) GetMergeRequestDetails(mr MergeRequest) (*MergeRequestDetails, error) {
-	details := &MergeRequestDetails{MergeRequest: mr}
+	details := &MergeRequestDetails{MergeRequest: mr, DetailsLoaded: true}
 
-	// Extract project path from web URL
-	// URL format: https://gitlab.com/namespace/project/-/merge_requests/123
-	projectPath := extractProjectPath(mr.WebURL)
-	if projectPath == "" {
-		return details, nil
+	// Single MR includes changes_count, head_pipeline and label details
+	if single, err := c.GetMergeRequest(mr.ProjectID, mr.IID); err != nil {
+		details.ChangesErr = err
+	} else {
+		details.MergeRequest = single.MergeRequest
+		details.LabelDetails = single.LabelDetails
 	}
 
-	encodedPath := strings.ReplaceAll(projectPath, "/", "%2F")
+	details.Commits, details.CommitsErr = c.GetMergeRequestCommits(mr.ProjectID, mr.IID)
+	details.CommitsCount = len(details.Commits)
 
-	// Get single MR details (includes changes_count)
-	mrURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d",
-		c.baseURL, encodedPath, mr.IID)
-	mrData, err := c.fetchJSON(mrURL)
-	if err == nil {
-		if mrMap, ok := mrData.(map[string]interface{}); ok {
-			if changesCount, ok := mrMap["changes_count"].(string); ok {
-				details.ChangesCount = changesCount
-			}
+	// Only count resolvable discussions - actual review threads
+	details.Discussions, details.DiscussionsErr = c.GetMergeRequestDiscussions(mr.ProjectID, mr.IID)
+	for _, discussion := range details.Discussions {
+		if len(discussion.Notes) == 0 || !discussion.Notes[0].Resolvable {
+			continue
 		}
-	}
-
-	// Get commits count
-	commitsURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/commits",
-		c.baseURL, encodedPath, mr.IID)
-	commits, err := c.fetchJSON(commitsURL)
-	if err == nil {
-		if arr, ok := commits.([]interface{}); ok {
-			details.CommitsCount = len(arr)
+		details.DiscussionsTotal++
+		if discussion.Notes[0].Resolved {
+			details.DiscussionsResolved++
 		}
 	}
 
-	// Get discussions stats (only count resolvable discussions - actual review threads)
-	discussionsURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/discussions",
-		c.baseURL, encodedPath, mr.IID)
-	discussions, err := c.fetchJSON(discussionsURL)
-	if err == nil {
-		if arr, ok := discussions.([]interface{}); ok {
-			for _, d := range arr {
-				if disc, ok := d.(map[string]interface{}); ok {
-					if notes, ok := disc["notes"].([]interface{}); ok && len(notes) > 0 {
-						// Check if first note is resolvable (skip system notes)
-						if note, ok := notes[0].(map[string]interface{}); ok {
-							if resolvable, ok := note["resolvable"].(bool); ok && resolvable {
-								details.DiscussionsTotal++
-								if resolved, ok := note["resolved"].(bool); ok && resolved {
-									details.DiscussionsResolved++
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}
+	details.Approvals, details.ApprovalsErr = c.GetMergeRequestApprovals(mr.ProjectID, mr.IID)
 
 	return details, nil
 }
 
-// fetchJSON makes a GET request and returns parsed JSON
-func (c *GitLabClient) fetchJSON(url string) (interface{}, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
+// GetMergeRequest fetches a single merge request. Unlike list items it has the changes
+// count, the head pipeline and the details of its labels.
+func (c *GitLabClient) GetMergeRequest(projectID, mrIID int) (*MergeRequestDetails, error) {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d?with_labels_details=true", c.baseURL, projectID, mrIID)
 
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	// Labels come as objects with details, the less nested field takes them
+	var mr struct {
+		MergeRequest
+		Labels []Label `json:"labels"`
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("status %d", resp.StatusCode)
-	}
-
-	var result interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if _, err := c.request("GET", url, nil, &mr); err != nil {
 		return nil, err
 	}
 
-	return result, nil
-}
-
-// GetProjects fetches projects the user has access to
-func (c *GitLabClient) GetProjects() ([]Project, error) {
-	url := c.baseURL + "/api/v4/projects?membership=true&per_page=100&order_by=last_activity_at"
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	details := &MergeRequestDetails{MergeRequest: mr.MergeRequest, LabelDetails: mr.Labels}
+	for _, label := range mr.Labels {
+		details.Labels = append(details.Labels, label.Name)
 	}
+	return details, nil
+}
 
-	req.Header.Set("PRIVATE-TOKEN", c.token)
+// GetMergeRequestCommits fetches the commits of a merge request
+func (c *GitLabClient) GetMergeRequestCommits(projectID, mrIID int) ([]Commit, error) {
+	return getAllPages[Commit](c, fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/commits?per_page=100", c.baseURL, projectID, mrIID))
+}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
+// GetMergeRequestDiscussions fetches the discussion threads of a merge request
+func (c *GitLabClient) GetMergeRequestDiscussions(projectID, mrIID int) ([]Discussion, error) {
+	return getAllPages[Discussion](c, fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/discussions?per_page=100", c.baseURL, projectID, mrIID))
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
+// GetMergeRequestApprovals fetches the approval state of a merge request
+func (c *GitLabClient) GetMergeRequestApprovals(projectID, mrIID int) (*Approvals, error) {
+	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/approvals", c.baseURL, projectID, mrIID)
 
-	var projects []Project
-	if err := json.NewDecoder(resp.Body).Decode(&projects); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	var approvals Approvals
+	if _, err := c.request("GET", url, nil, &approvals); err != nil {
+		return nil, err
 	}
+	return &approvals, nil
+}
 
-	return projects, nil
+// GetProjectLabels fetches the labels of a project
+func (c *GitLabClient) GetProjectLabels(projectID int) ([]Label, error) {
+	return getAllPages[Label](c, fmt.Sprintf("%s/api/v4/projects/%d/labels?per_page=100", c.baseURL, projectID))
 }
 
-// GetProjectMergeRequests fetches open merge requests for a specific project
-func (c *GitLabClient) GetProjectMergeRequests(projectID int) ([]*MergeRequestDetails, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests?state=opened&per_page=100", c.baseURL, projectID)
+// GetProjectMilestones fetches the active milestones of a project
+func (c *GitLabClient) GetProjectMilestones(projectID int) ([]Milestone, error) {
+	return getAllPages[Milestone](c, fmt.Sprintf("%s/api/v4/projects/%d/milestones?state=active&per_page=100", c.baseURL, projectID))
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+// Limits of list fetching
+const (
+	gitLabDetailWorkers = 8  // Concurrent MR detail fetches
+	gitLabMaxPages      = 50 // Pages followed per list, 5000 items with per_page=100
+)
 
-	req.Header.Set("PRIVATE-TOKEN", c.token)
+// GetProjects fetches all projects the user is a member of
+func (c *GitLabClient) GetProjects() ([]Project, error) {
+	return getAllPages[Project](c, c.baseURL+"/api/v4/projects?membership=true&per_page=100&order_by=last_activity_at")
+}
 
-	resp, err := c.client.Do(req)
+// GetProjectMergeRequests fetches all open merge requests of a project.
+// With lazy set only the list fields are filled, details are fetched with GetMergeRequestDetails.
+func (c *GitLabClient) GetProjectMergeRequests(projectID int, lazy bool) ([]*MergeRequestDetails, error) {
+	mrs, err := getAllPages[MergeRequest](c, fmt.Sprintf("%s/api/v4/projects/%d/merge_requests?state=opened&per_page=100", c.baseURL, projectID))
 	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return c.mergeRequestsDetails(mrs, lazy), nil
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
+// mergeRequestsDetails fetches the details of MRs with at most gitLabDetailWorkers requests
+// in flight, keeping the order. MRs whose details cannot be fetched are skipped.
+// With lazy set the MRs are returned without details.
+func (c *GitLabClient) mergeRequestsDetails(mrs []MergeRequest, lazy bool) []*MergeRequestDetails {
+	details := make([]*MergeRequestDetails, len(mrs))
+	if lazy {
+		for i, mr := range mrs {
+			details[i] = &MergeRequestDetails{MergeRequest: mr}
+		}
+		return details
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(gitLabDetailWorkers, len(mrs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if d, err := c.GetMergeRequestDetails(mrs[i]); err == nil {
+					details[i] = d
+				}
+			}
+		}()
 	}
-
-	var mrs []MergeRequest
-	if err := json.NewDecoder(resp.Body).Decode(&mrs); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	for i := range mrs {
+		jobs <- i
 	}
+	close(jobs)
+	wg.Wait()
 
-	// Fetch additional details for each MR
 	result := make([]*MergeRequestDetails, 0, len(mrs))
-	for _, mr := range mrs {
-		details, err := c.GetMergeRequestDetails(mr)
-		if err != nil {
-			continue
+	for _, d := range details {
+		if d != nil {
+			result = append(result, d)
 		}
-		result = append(result, details)
 	}
-
-	return result, nil
+	return result
 }
 
 // GetMergeRequestBySourceBranch fetches MR details by source branch name (including merged MRs)
@@ -290,26 +227,9 @@ func (c *GitLabClient) GetMergeRequestBySourceBranch(projectID int, sourceBranch
 	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests?source_branch=%s&order_by=updated_at&sort=desc&per_page=1",
 		c.baseURL, projectID, sourceBranch)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
 	var mrs []MergeRequest
-	if err := json.NewDecoder(resp.Body).Decode(&mrs); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if _, err := c.request("GET", url, nil, &mrs); err != nil {
+		return nil, err
 	}
 
 	if len(mrs) == 0 {
@@ -325,60 +245,18 @@ func (c *GitLabClient) GetMergeRequestByIID(projectID, mrIID int) (*MergeRequest
 	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d",
 		c.baseURL, projectID, mrIID)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
 	var mr MergeRequest
-	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if _, err := c.request("GET", url, nil, &mr); err != nil {
+		return nil, err
 	}
 
 	// Get full details
 	return c.GetMergeRequestDetails(mr)
 }
 
-// extractProjectPath extracts project path from MR web URL
-func extractProjectPath(webURL string) string {
-	// URL format: https://gitlab.com/namespace/project/-/merge_requests/123
-	// or: https://gitlab.com/group/subgroup/project/-/merge_requests/123
-	idx := strings.Index(webURL, "/-/merge_requests/")
-	if idx == -1 {
-		return ""
-	}
-
-	// Get everything after the host
-	path := webURL[:idx]
-	// Remove protocol and host
-	if strings.HasPrefix(path, "https://") {
-		path = path[8:]
-	} else if strings.HasPrefix(path, "http://") {
-		path = path[7:]
-	}
-
-	// Remove host part (everything before first /)
-	slashIdx := strings.Index(path, "/")
-	if slashIdx == -1 {
-		return ""
-	}
-
-	return path[slashIdx+1:]
-}
-
-// CreateMergeRequest creates a new merge request in GitLab
+// CreateMergeRequest creates a new merge request in GitLab. If the source branch already
+// has an open MR to the target branch, e.g. created by a request retried after a lost
+// response, that MR is returned.
 func (c *GitLabClient) CreateMergeRequest(projectID int, sourceBranch, targetBranch, title, description string) (*MergeRequest, error) {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests", c.baseURL, projectID)
 
@@ -389,67 +267,44 @@ func (c *GitLabClient) CreateMergeRequest(projectID int, sourceBranch, targetBra
 		"description":   description,
 	}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	var mr MergeRequest
+	_, err := c.request("POST", url, payload, &mr)
+	if errors.Is(err, ErrGitLabConflict) {
+		if existing, findErr := c.findOpenMergeRequest(projectID, sourceBranch, targetBranch); findErr == nil && existing != nil {
+			return existing, nil
+		}
 	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-	req.Header.Set("Content-Type", "application/json")
+	return &mr, nil
+}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+// findOpenMergeRequest returns the open MR from the source to the target branch, or nil
+func (c *GitLabClient) findOpenMergeRequest(projectID int, sourceBranch, targetBranch string) (*MergeRequest, error) {
+	query := url.Values{
+		"state":         {"opened"},
+		"source_branch": {sourceBranch},
+		"target_branch": {targetBranch},
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 201 {
-		body, _ := io.ReadAll(resp.Body)
-		bodyStr := string(body)
-		// Check for insufficient scope error and provide helpful message
-		if resp.StatusCode == 403 && strings.Contains(bodyStr, "insufficient_scope") {
-			return nil, fmt.Errorf("token lacks 'api' scope - please regenerate your GitLab token with 'api' scope enabled")
-		}
-		return nil, fmt.Errorf("GitLab API error: status %d, body: %s", resp.StatusCode, bodyStr)
+	var mrs []MergeRequest
+	if _, err := c.request("GET", fmt.Sprintf("%s/api/v4/projects/%d/merge_requests?%s", c.baseURL, projectID, query.Encode()), nil, &mrs); err != nil {
+		return nil, err
 	}
-
-	var mr MergeRequest
-	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if len(mrs) == 0 {
+		return nil, nil
 	}
-
-	return &mr, nil
+	return &mrs[0], nil
 }
 
 // GetMergeRequestStatus fetches the status of a merge request to check if it's merged
 func (c *GitLabClient) GetMergeRequestStatus(projectID, mrIID int) (*MergeRequest, error) {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d", c.baseURL, projectID, mrIID)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
 	var mr MergeRequest
-	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if _, err := c.request("GET", url, nil, &mr); err != nil {
+		return nil, err
 	}
 
 	return &mr, nil
@@ -459,26 +314,9 @@ func (c *GitLabClient) GetMergeRequestStatus(projectID, mrIID int) (*MergeReques
 func (c *GitLabClient) GetMergeRequestPipelines(projectID, mrIID int) ([]Pipeline, error) {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/pipelines", c.baseURL, projectID, mrIID)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
 	var pipelines []Pipeline
-	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if _, err := c.request("GET", url, nil, &pipelines); err != nil {
+		return nil, err
 	}
 
 	return pipelines, nil
@@ -488,26 +326,9 @@ func (c *GitLabClient) GetMergeRequestPipelines(projectID, mrIID int) ([]Pipelin
 func (c *GitLabClient) GetPipelinesByCommit(projectID int, sha string) ([]Pipeline, error) {
 	url := fmt.Sprintf("%s/api/v4/projects/%d/pipelines?sha=%s", c.baseURL, projectID, sha)
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("PRIVATE-TOKEN", c.token)
-
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
-	}
-
 	var pipelines []Pipeline
-	if err := json.NewDecoder(resp.Body).Decode(&pipelines); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if _, err := c.request("GET", url, nil, &pipelines); err != nil {
+		return nil, err
 	}
 
 	return pipelines, nil
@@ -515,29 +336,67 @@ func (c *GitLabClient) GetPipelinesByCommit(projectID int, sha string) ([]Pipeli
 
 // GetPipelineJobs fetches jobs for a specific pipeline
 func (c *GitLabClient) GetPipelineJobs(projectID, pipelineID int) ([]PipelineJob, error) {
-	url := fmt.Sprintf("%s/api/v4/projects/%d/pipelines/%d/jobs?per_page=100", c.baseURL, projectID, pipelineID)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	return getAllPages[PipelineJob](c, fmt.Sprintf("%s/api/v4/projects/%d/pipelines/%d/jobs?per_page=100", c.baseURL, projectID, pipelineID))
+}
 
-	req.Header.Set("PRIVATE-TOKEN", c.token)
+// releaseURL returns the API URL of the GitLab Release of a tag
+func (c *GitLabClient) releaseURL(projectID int, tagName string) string {
+	return fmt.Sprintf("%s/api/v4/projects/%d/releases/%s", c.baseURL, projectID, url.PathEscape(tagName))
+}
 
-	resp, err := c.client.Do(req)
+// UpsertRelease creates the GitLab Release of a tag, or updates its name and description if
+// the tag already has one, so retrying a release does not fail. Returns the release web URL.
+func (c *GitLabClient) UpsertRelease(projectID int, release GitLabRelease) (string, error) {
+	exists, err := c.releaseExists(projectID, release.TagName)
 	if err != nil {
-		return nil, fmt.Errorf("network error: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitLab API error: status %d", resp.StatusCode)
+	var result struct {
+		Links struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}
+	if !exists {
+		payload := map[string]interface{}{
+			"name":        release.Name,
+			"tag_name":    release.TagName,
+			"description": release.Description,
+		}
+		if len(release.Links) > 0 {
+			payload["assets"] = map[string]interface{}{"links": release.Links}
+		}
+		_, err = c.request("POST", fmt.Sprintf("%s/api/v4/projects/%d/releases", c.baseURL, projectID), payload, &result)
+		if !errors.Is(err, ErrGitLabConflict) {
+			// A conflict means a retried request created it, update it below
+			if err != nil {
+				return "", err
+			}
+			return result.Links.Self, nil
+		}
 	}
 
-	var jobs []PipelineJob
-	if err := json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	// Asset links are kept as created, they are not part of the update API
+	payload := map[string]interface{}{
+		"name":        release.Name,
+		"description": release.Description,
+	}
+	if _, err := c.request("PUT", c.releaseURL(projectID, release.TagName), payload, &result); err != nil {
+		return "", err
 	}
 
-	return jobs, nil
+	return result.Links.Self, nil
+}
+
+// releaseExists checks whether a tag already has a GitLab Release
+func (c *GitLabClient) releaseExists(projectID int, tagName string) (bool, error) {
+	_, err := c.request("GET", c.releaseURL(projectID, tagName), nil, nil)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrGitLabNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
 }
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestGitLab starts a GitLab stub and returns it with a client of it
+func newTestGitLab(t *testing.T, fixtures *GitLabFixtures) (*GitLabStubServer, *GitLabClient) {
+	t.Helper()
+	stub := NewGitLabStubServer(fixtures)
+	t.Cleanup(stub.Close)
+	return stub, NewGitLabClient(stub.URL()+"/", "token")
+}
+
+// fixtureResponse returns a fixture response with the body encoded as JSON
+func fixtureResponse(status int, headers map[string]string, body any) GitLabFixtureResponse {
+	data, _ := json.Marshal(body)
+	return GitLabFixtureResponse{Status: status, Headers: headers, Body: data}
+}
+
+func TestGitLabClientPagination(t *testing.T) {
+	stub, client := newTestGitLab(t, nil)
+	list := "GET /api/v4/projects?membership=true&per_page=100&order_by=last_activity_at"
+	stub.SetResponses(list, fixtureResponse(200, map[string]string{"X-Next-Page": "2"}, []Project{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}}))
+	stub.SetResponses(list+"&page=2", fixtureResponse(200, map[string]string{"X-Next-Page": ""}, []Project{{ID: 3, Name: "three"}}))
+
+	projects, err := client.GetProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, project := range projects {
+		names = append(names, project.Name)
+	}
+	if strings.Join(names, ",") != "one,two,three" {
+		t.Errorf("projects = %v, want one,two,three", names)
+	}
+}
+
+func TestGitLabClientRateLimit(t *testing.T) {
+	stub, client := newTestGitLab(t, nil)
+	limited := fixtureResponse(429, map[string]string{"Retry-After": "1"}, map[string]string{"message": "429 Too Many Requests"})
+	stub.SetResponses("GET /api/v4/projects/1/merge_requests/5", limited, fixtureResponse(200, nil, MergeRequest{IID: 5, State: "opened"}))
+
+	start := time.Now()
+	mr, err := client.GetMergeRequestStatus(1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mr.IID != 5 {
+		t.Errorf("MR = %+v", mr)
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("retried after %s, want the Retry-After of 1s", waited)
+	}
+
+	// A longer wait than the client accepts fails at once
+	stub.SetResponses("GET /api/v4/projects/1/merge_requests/6", fixtureResponse(429, map[string]string{"Retry-After": "3600"}, map[string]string{"message": "429 Too Many Requests"}))
+	_, err = client.GetMergeRequestStatus(1, 6)
+	var apiErr *GitLabError
+	if !errors.Is(err, ErrGitLabRateLimited) || !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour {
+		t.Errorf("long rate limit error = %v", err)
+	}
+}
+
+func TestGitLabClientCreateMergeRequestConflict(t *testing.T) {
+	_, client := newTestGitLab(t, nil)
+
+	mr, err := client.CreateMergeRequest(1, "release/rpb-1.0.0-test", "test", "release:1.0.0 test v1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A retried create gets a conflict from GitLab and returns the open MR
+	again, err := client.CreateMergeRequest(1, "release/rpb-1.0.0-test", "test", "release:1.0.0 test v1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.IID != mr.IID {
+		t.Errorf("second create returned !%d, want the open !%d", again.IID, mr.IID)
+	}
+	other, err := client.CreateMergeRequest(1, "release/rpb-1.0.0-test", "develop", "release:1.0.0 develop v1", "")
+	if err != nil || other.IID == mr.IID {
+		t.Errorf("MR to another target = %+v, %v", other, err)
+	}
+}
+
+func TestGitLabClientCreateMergeRequestConflictWithoutOpenMR(t *testing.T) {
+	stub, client := newTestGitLab(t, nil)
+	stub.SetResponses("POST /api/v4/projects/1/merge_requests", fixtureResponse(409, nil, map[string]string{"message": "Another open merge request already exists"}))
+	stub.SetResponses("GET /api/v4/projects/1/merge_requests?state=opened&source_branch=feat/a&target_branch=test", fixtureResponse(200, nil, []MergeRequest{}))
+
+	if _, err := client.CreateMergeRequest(1, "feat/a", "test", "Feat A", ""); !errors.Is(err, ErrGitLabConflict) {
+		t.Errorf("error = %v, want a conflict", err)
+	}
+}
+
+func TestGitLabClientPipelineStatus(t *testing.T) {
+	stub, client := newTestGitLab(t, &GitLabFixtures{MergeAfter: 1, PipelineStatuses: []string{"pending", "running", "failed"}})
+
+	mr, err := client.CreateMergeRequest(7, "release/rpb-1.0.0-test", "test", "release:1.0.0 test v1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(mr.WebURL, stub.URL()+"/") {
+		t.Errorf("MR web URL %s does not link back to the stub", mr.WebURL)
+	}
+
+	var states []string
+	var merged *MergeRequest
+	for i := 0; i < 2; i++ {
+		status, err := client.GetMergeRequestStatus(7, mr.IID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		states = append(states, status.State)
+		merged = status
+	}
+	if strings.Join(states, ",") != "opened,merged" || merged.MergeCommitSHA == "" {
+		t.Fatalf("MR states = %v, merge commit %q", states, merged.MergeCommitSHA)
+	}
+
+	var statuses, deploys []string
+	for i := 0; i < 4; i++ {
+		pipelines, err := client.GetPipelinesByCommit(7, merged.MergeCommitSHA)
+		if err != nil || len(pipelines) != 1 {
+			t.Fatalf("pipelines = %v, %v", pipelines, err)
+		}
+		jobs, err := client.GetPipelineJobs(7, pipelines[0].ID)
+		if err != nil || len(jobs) != 2 {
+			t.Fatalf("jobs = %v, %v", jobs, err)
+		}
+		statuses = append(statuses, pipelines[0].Status)
+		deploys = append(deploys, jobs[1].Status)
+	}
+	// The last status repeats once the pipeline is done
+	if want := "pending,running,failed,failed"; strings.Join(statuses, ",") != want || strings.Join(deploys, ",") != want {
+		t.Errorf("pipeline statuses = %v, deploy job = %v, want %s", statuses, deploys, want)
+	}
+
+	pipelines, err := client.GetMergeRequestPipelines(7, mr.IID)
+	if err != nil || len(pipelines) != 1 || pipelines[0].Status != "failed" {
+		t.Errorf("MR pipelines = %v, %v", pipelines, err)
+	}
+}
+
+func TestGitLabRequestsCancel(t *testing.T) {
+	stub, _ := newTestGitLab(t, nil)
+	stub.SetResponses("GET /api/v4/projects/1/merge_requests/5", fixtureResponse(200, nil, MergeRequest{IID: 5}))
+	m := model{creds: &Credentials{GitLabURL: stub.URL(), Token: "token"}, gitLabRequests: make(map[string]gitLabRequests)}
+
+	// A new fetch of a purpose cancels the previous one
+	previous := m.gitLabClient("mrs")
+	current := m.gitLabClient("mrs")
+	if _, err := previous.GetMergeRequestStatus(1, 5); !errors.Is(err, context.Canceled) {
+		t.Errorf("superseded request error = %v, want cancelled", err)
+	}
+	if _, err := current.GetMergeRequestStatus(1, 5); err != nil {
+		t.Errorf("current request: %v", err)
+	}
+
+	// Shared requests run together and are cancelled together when the screen is left
+	first, second := m.sharedGitLabClient("release"), m.sharedGitLabClient("release")
+	if _, err := first.GetMergeRequestStatus(1, 5); err != nil {
+		t.Errorf("shared request: %v", err)
+	}
+	m.cancelGitLabRequests("release")
+	for _, client := range []*GitLabClient{first, second} {
+		if _, err := client.GetMergeRequestStatus(1, 5); !errors.Is(err, context.Canceled) {
+			t.Errorf("request after leaving error = %v, want cancelled", err)
+		}
+	}
+	if _, err := m.sharedGitLabClient("release").GetMergeRequestStatus(1, 5); err != nil {
+		t.Errorf("request after returning: %v", err)
+	}
+}
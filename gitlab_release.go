@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// tagMessage returns the annotation message of the release tag: the release commit title
+func (s *ReleaseState) tagMessage() string {
+	return renderNamingTemplate(s.naming().CommitTitle, s.namingVars(s.ReleaseNumber))
+}
+
+// tagCommand returns the command creating the annotated release tag on HEAD.
+// Retrying the root push re-creates the tag with the same message.
+func (s *ReleaseState) tagCommand() string {
+	return fmt.Sprintf("git tag -f -a %s -m %q", s.TagName, s.tagMessage())
+}
+
+// projectWebURL returns the GitLab web URL of the project, derived from the env MR URL
+func (s *ReleaseState) projectWebURL() string {
+	project, _, ok := strings.Cut(s.CreatedMRURL, "/-/merge_requests/")
+	if !ok {
+		return ""
+	}
+	return project
+}
+
+// gitLabReleaseURL returns the web URL of the GitLab Release of the tag, empty until
+// the release step created it
+func (s *ReleaseState) gitLabReleaseURL() string {
+	project := s.projectWebURL()
+	if !s.GitLabRelease || s.CurrentStep <= ReleaseStepCreateRelease || project == "" || s.TagName == "" {
+		return ""
+	}
+	return project + "/-/releases/" + url.PathEscape(s.TagName)
+}
+
+// createGitLabRelease creates or updates the GitLab Release of the tag with the release
+// notes, linking the env MR and its latest pipeline
+func (e *ReleaseEngine) createGitLabRelease() (string, error) {
+	if e.gitlab == nil {
+		return "", fmt.Errorf("not authenticated to GitLab")
+	}
+	state := e.state
+
+	release := GitLabRelease{
+		Name:        state.TagName,
+		TagName:     state.TagName,
+		Description: state.ReleaseNotes,
+	}
+	if release.Description == "" {
+		release.Description = state.tagMessage()
+	}
+	if state.CreatedMRURL != "" {
+		release.Links = append(release.Links, GitLabReleaseLink{Name: "Environment MR", URL: state.CreatedMRURL})
+	}
+	if state.CreatedMRIID != 0 {
+		// The pipeline link is optional, the release is created without it
+		if pipelines, err := e.gitlab.GetMergeRequestPipelines(state.ProjectID, state.CreatedMRIID); err == nil && len(pipelines) > 0 {
+			release.Links = append(release.Links, GitLabReleaseLink{Name: "Pipeline", URL: pipelines[0].WebURL})
+		}
+	}
+
+	webURL, err := e.gitlab.UpsertRelease(state.ProjectID, release)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitLab release: %w", err)
+	}
+	return fmt.Sprintf("GitLab release %s: %s\n", state.TagName, webURL), nil
+}
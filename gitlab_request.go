@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitLab request retry policy
+const (
+	gitLabMaxAttempts  = 4                      // Attempts per request, including the first one
+	gitLabBackoffBase  = 500 * time.Millisecond // Wait before the first retry, doubled for each next one
+	gitLabMaxRetryWait = 30 * time.Second       // Longest wait before a retry, longer rate limits fail at once
+)
+
+// Kinds of GitLab API errors, matched with errors.Is
+var (
+	ErrGitLabAuth        = errors.New("GitLab authentication failed")
+	ErrGitLabNotFound    = errors.New("not found in GitLab")
+	ErrGitLabConflict    = errors.New("GitLab conflict")
+	ErrGitLabRateLimited = errors.New("GitLab rate limit exceeded")
+)
+
+// GitLabError is a GitLab API request that failed with an error status
+type GitLabError struct {
+	Kind       error // One of the ErrGitLab* kinds, nil for other statuses
+	Method     string
+	Path       string // API path without the /api/v4 prefix and query
+	Status     int
+	Message    string        // Error message of the response body
+	RetryAfter time.Duration // Wait requested by a rate limited response
+}
+
+func (e *GitLabError) Error() string {
+	switch e.Kind {
+	case ErrGitLabAuth:
+		if strings.Contains(e.Message, "insufficient_scope") {
+			return "token lacks 'api' scope - please regenerate your GitLab token with 'api' scope enabled"
+		}
+		if e.Status == 401 {
+			return "invalid token: authentication failed"
+		}
+		return fmt.Sprintf("access denied to %s: %s", e.Path, e.Message)
+	case ErrGitLabNotFound:
+		return fmt.Sprintf("%s %s", e.Path, e.Kind)
+	case ErrGitLabConflict:
+		return fmt.Sprintf("%s: %s", e.Kind, e.Message)
+	case ErrGitLabRateLimited:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("%s, retry in %s", e.Kind, e.RetryAfter.Round(time.Second))
+		}
+		return e.Kind.Error()
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("GitLab API error: status %d, %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("GitLab API error: status %d", e.Status)
+}
+
+func (e *GitLabError) Unwrap() error {
+	return e.Kind
+}
+
+// gitLabErrorLabel returns a short description of a failed GitLab check for status lines
+func gitLabErrorLabel(err error) string {
+	switch {
+	case errors.Is(err, ErrGitLabAuth):
+		return "token rejected"
+	case errors.Is(err, ErrGitLabNotFound):
+		return "not found"
+	case errors.Is(err, ErrGitLabRateLimited):
+		return "rate limited"
+	default:
+		return "check failed"
+	}
+}
+
+// gitLabErrorText returns the message of an error for the error modal, with a hint on what
+// to do about the GitLab errors the user can fix
+func gitLabErrorText(err error) string {
+	switch {
+	case errors.Is(err, ErrGitLabAuth):
+		return err.Error() + "\n\nCheck the token scopes or run /logout to enter a new token."
+	case errors.Is(err, ErrGitLabRateLimited):
+		return err.Error() + "\n\nGitLab limits the request rate of your token, wait a bit and retry."
+	default:
+		return err.Error()
+	}
+}
+
+// WithContext returns a copy of the client whose requests are cancelled with ctx
+func (c *GitLabClient) WithContext(ctx context.Context) *GitLabClient {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+// context returns the context of the client requests
+func (c *GitLabClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// request sends a GitLab API request with payload encoded as JSON (if not nil) and decodes
+// a successful response into out (if not nil). Network errors, 5xx and 429 responses are
+// retried with exponential backoff, waiting as long as Retry-After or RateLimit-Reset ask.
+// Error statuses are returned as *GitLabError.
+func (c *GitLabClient) request(method, reqURL string, payload, out any) (http.Header, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to marshal payload: %w", err)
+		}
+	}
+
+	ctx := c.context()
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		var wait time.Duration
+		resp, err := c.client.Do(req)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt == gitLabMaxAttempts {
+				return nil, fmt.Errorf("network error: %w", err)
+			}
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			defer resp.Body.Close()
+			if out != nil {
+				if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+					return nil, fmt.Errorf("failed to parse response: %w", err)
+				}
+			}
+			return resp.Header, nil
+		default:
+			apiErr := newGitLabError(req, resp)
+			resp.Body.Close()
+			retryable := resp.StatusCode == 429 || resp.StatusCode >= 500
+			if !retryable || attempt == gitLabMaxAttempts || apiErr.RetryAfter > gitLabMaxRetryWait {
+				return nil, apiErr
+			}
+			wait = apiErr.RetryAfter
+		}
+
+		if wait == 0 {
+			wait = gitLabBackoffBase << (attempt - 1)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// newGitLabError reads the error status, message and rate limit wait of a failed response
+func newGitLabError(req *http.Request, resp *http.Response) *GitLabError {
+	apiErr := &GitLabError{
+		Method: req.Method,
+		Path:   strings.TrimPrefix(req.URL.Path, "/api/v4"),
+		Status: resp.StatusCode,
+	}
+	switch resp.StatusCode {
+	case 401, 403:
+		apiErr.Kind = ErrGitLabAuth
+	case 404:
+		apiErr.Kind = ErrGitLabNotFound
+	case 409:
+		apiErr.Kind = ErrGitLabConflict
+	case 429:
+		apiErr.Kind = ErrGitLabRateLimited
+		apiErr.RetryAfter = rateLimitWait(resp.Header, time.Now())
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var body struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		apiErr.Message = strings.TrimSpace(string(data))
+		return apiErr
+	}
+	switch message := body.Message.(type) {
+	case string:
+		apiErr.Message = message
+	case nil:
+	case []any:
+		var messages []string
+		for _, item := range message {
+			messages = append(messages, fmt.Sprint(item))
+		}
+		apiErr.Message = strings.Join(messages, "; ")
+	default:
+		// Validation errors come as a map of messages by field
+		encoded, _ := json.Marshal(message)
+		apiErr.Message = string(encoded)
+	}
+	if body.Error != "" {
+		apiErr.Message = strings.TrimSpace(body.Error + " " + apiErr.Message)
+	}
+	return apiErr
+}
+
+// rateLimitWait returns the wait asked by a rate limited response: Retry-After in seconds
+// or as an HTTP date, else the RateLimit-Reset Unix time
+func rateLimitWait(header http.Header, now time.Time) time.Duration {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(max(seconds, 0)) * time.Second
+		}
+		if at, err := http.ParseTime(retryAfter); err == nil {
+			return max(at.Sub(now), 0)
+		}
+	}
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		return max(time.Unix(reset, 0).Sub(now), 0)
+	}
+	return 0
+}
+
+// getAllPages fetches every page of a GitLab list endpoint by following the X-Next-Page header
+func getAllPages[T any](c *GitLabClient, listURL string) ([]T, error) {
+	u, err := url.Parse(listURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var items []T
+	for page, pages := "1", 0; page != "" && pages < gitLabMaxPages; pages++ {
+		query := u.Query()
+		query.Set("page", page)
+		u.RawQuery = query.Encode()
+
+		var pageItems []T
+		header, err := c.request("GET", u.String(), nil, &pageItems)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		page = header.Get("X-Next-Page")
+	}
+	return items, nil
+}
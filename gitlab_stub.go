@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gitLabStubBaseURL is replaced with the stub URL in served fixture bodies, so fixtures can
+// link back to the stub wherever it runs
+const gitLabStubBaseURL = "{{base_url}}"
+
+// gitLabStubHeaders are the response headers kept in fixtures
+var gitLabStubHeaders = []string{"X-Next-Page", "Retry-After", "RateLimit-Reset"}
+
+// GitLabFixtures are the canned responses of the GitLab stub
+type GitLabFixtures struct {
+	// Responses by request key "METHOD /api/v4/path?query", served in turn, the last one
+	// repeating. A request gets the next response of its key only.
+	Responses map[string][]GitLabFixtureResponse `json:"responses"`
+
+	MergeAfter       int      `json:"merge_after,omitempty"`       // Status checks before an MR created on the stub is merged (default 2)
+	PipelineStatuses []string `json:"pipeline_statuses,omitempty"` // Statuses its pipeline goes through, one per check (default running, running, success)
+}
+
+// GitLabFixtureResponse is a canned GitLab API response
+type GitLabFixtureResponse struct {
+	Status  int               `json:"status,omitempty"` // 200 if empty
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// LoadGitLabFixtures reads stub fixtures from a JSON file
+func LoadGitLabFixtures(path string) (*GitLabFixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures GitLabFixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("invalid fixtures %s: %w", path, err)
+	}
+
+	// Hand-written keys may have their query in any order
+	responses := make(map[string][]GitLabFixtureResponse, len(fixtures.Responses))
+	for key, list := range fixtures.Responses {
+		responses[normalizeGitLabStubKey(key)] = list
+	}
+	fixtures.Responses = responses
+	return &fixtures, nil
+}
+
+// SaveGitLabFixtures writes stub fixtures to a JSON file
+func SaveGitLabFixtures(path string, fixtures *GitLabFixtures) error {
+	data, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Set replaces the responses of a request key with the given bodies encoded as JSON
+func (f *GitLabFixtures) Set(key string, bodies ...any) {
+	list := make([]GitLabFixtureResponse, 0, len(bodies))
+	for _, body := range bodies {
+		data, _ := json.Marshal(body)
+		list = append(list, GitLabFixtureResponse{Body: data})
+	}
+	if f.Responses == nil {
+		f.Responses = make(map[string][]GitLabFixtureResponse)
+	}
+	f.Responses[normalizeGitLabStubKey(key)] = list
+}
+
+// gitLabStubKey returns the fixture key of a request: method, path and sorted query
+// without the default page=1
+func gitLabStubKey(method string, u *url.URL) string {
+	query := u.Query()
+	if query.Get("page") == "1" {
+		query.Del("page")
+	}
+	key := method + " " + u.Path
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	return key
+}
+
+// normalizeGitLabStubKey rewrites a fixture key the way gitLabStubKey builds it
+func normalizeGitLabStubKey(key string) string {
+	method, target, ok := strings.Cut(key, " ")
+	if !ok {
+		return key
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return key
+	}
+	return gitLabStubKey(method, u)
+}
+
+// GitLabStub is a local HTTP server answering the GitLab API endpoints relix uses from
+// fixtures, so the TUI and GitLabClient can run without a real GitLab. MRs created on the
+// stub get merged after a few status checks and run a pipeline through the configured
+// statuses. With Upstream set it proxies to a real GitLab instead, recording the responses.
+type GitLabStub struct {
+	Fixtures *GitLabFixtures
+	Upstream string // GitLab URL to proxy to and record from, replay only if empty
+	Path     string // File recorded fixtures are saved to
+
+	mu      sync.Mutex
+	served  map[string]int    // Responses served per fixture key
+	created map[string]string // Status fixture keys of MRs created on the stub by source and target branch
+	nextIID int               // IID of the next MR created on the stub
+}
+
+// newGitLabStub creates a stub serving the given fixtures without starting a server
+func newGitLabStub(fixtures *GitLabFixtures) *GitLabStub {
+	if fixtures == nil {
+		fixtures = &GitLabFixtures{}
+	}
+	if fixtures.Responses == nil {
+		fixtures.Responses = make(map[string][]GitLabFixtureResponse)
+	}
+	return &GitLabStub{Fixtures: fixtures, served: make(map[string]int), created: make(map[string]string), nextIID: 1000}
+}
+
+// ServeHTTP answers a GitLab API request from the fixtures, or from the upstream GitLab
+// when recording
+func (s *GitLabStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := gitLabStubKey(r.Method, r.URL)
+	if s.Upstream != "" {
+		s.record(w, r, key)
+		return
+	}
+
+	s.mu.Lock()
+	response, ok := s.next(key)
+	if !ok && r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/merge_requests") {
+		response, ok = s.createMergeRequest(r), true
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeStubError(w, http.StatusNotFound, "404 Not Found: no fixture for "+key)
+		return
+	}
+	baseURL := "http://" + r.Host
+	s.write(w, response, bytes.ReplaceAll(response.Body, []byte(gitLabStubBaseURL), []byte(baseURL)))
+}
+
+// next returns the next response of a fixture key, repeating the last one
+func (s *GitLabStub) next(key string) (GitLabFixtureResponse, bool) {
+	list := s.Fixtures.Responses[key]
+	if len(list) == 0 {
+		return GitLabFixtureResponse{}, false
+	}
+	i := min(s.served[key], len(list)-1)
+	s.served[key]++
+	return list[i], true
+}
+
+// write writes a fixture response with the given body
+func (s *GitLabStub) write(w http.ResponseWriter, response GitLabFixtureResponse, body []byte) {
+	for name, value := range response.Headers {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// createMergeRequest answers POST /api/v4/projects/{id}/merge_requests without a fixture:
+// creates the MR and the fixtures of its status checks, merge and pipeline
+func (s *GitLabStub) createMergeRequest(r *http.Request) GitLabFixtureResponse {
+	var payload struct {
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.SourceBranch == "" || payload.TargetBranch == "" {
+		return stubErrorResponse(http.StatusBadRequest, "source_branch and target_branch are required")
+	}
+	projectPath := strings.TrimSuffix(r.URL.Path, "/merge_requests")
+	var projectID int
+	fmt.Sscanf(strings.TrimPrefix(projectPath, "/api/v4/projects/"), "%d", &projectID)
+
+	mergeAfter := s.Fixtures.MergeAfter
+	if mergeAfter <= 0 {
+		mergeAfter = 2
+	}
+
+	// Same answer as GitLab for a second open MR of the branch: until it is merged
+	branches := projectPath + " " + payload.SourceBranch + " " + payload.TargetBranch
+	if statusKey, ok := s.created[branches]; ok && s.served[statusKey] <= mergeAfter {
+		var open MergeRequest
+		json.Unmarshal(s.Fixtures.Responses[statusKey][0].Body, &open)
+		return stubErrorResponse(http.StatusConflict, fmt.Sprintf("Another open merge request already exists for this source branch: !%d", open.IID))
+	}
+
+	iid := s.nextIID
+	s.nextIID++
+	now := time.Now()
+	mr := MergeRequest{
+		ID:           iid,
+		IID:          iid,
+		ProjectID:    projectID,
+		Title:        payload.Title,
+		Description:  payload.Description,
+		State:        "opened",
+		SourceBranch: payload.SourceBranch,
+		TargetBranch: payload.TargetBranch,
+		CreatedAt:    now,
+		Author:       GitLabUser{ID: 1, Username: "dev", Name: "Relix Developer"},
+		WebURL:       fmt.Sprintf("%s/stub/project-%d/-/merge_requests/%d", gitLabStubBaseURL, projectID, iid),
+		SHA:          fmt.Sprintf("%040x", iid),
+	}
+	s.Fixtures.Set(fmt.Sprintf("GET %s/merge_requests?state=opened&source_branch=%s&target_branch=%s",
+		projectPath, url.QueryEscape(payload.SourceBranch), url.QueryEscape(payload.TargetBranch)), []MergeRequest{mr})
+
+	// Opened for MergeAfter checks, then merged
+	merged := mr
+	merged.State = "merged"
+	merged.MergeCommitSHA = fmt.Sprintf("%040x", iid*10)
+	var states []any
+	for i := 0; i < mergeAfter; i++ {
+		states = append(states, mr)
+	}
+	statusKey := normalizeGitLabStubKey(fmt.Sprintf("GET %s/merge_requests/%d", projectPath, iid))
+	s.Fixtures.Set(statusKey, append(states, merged)...)
+	s.created[branches] = statusKey
+
+	// Pipeline of the merge commit and its jobs, one status per check
+	statuses := s.Fixtures.PipelineStatuses
+	if len(statuses) == 0 {
+		statuses = []string{"running", "running", "success"}
+	}
+	pipelineID := iid * 10
+	pipelineURL := fmt.Sprintf("%s/stub/project-%d/-/pipelines/%d", gitLabStubBaseURL, projectID, pipelineID)
+	var pipelines, jobs []any
+	for _, status := range statuses {
+		pipelines = append(pipelines, []Pipeline{{ID: pipelineID, Status: status, Ref: mr.TargetBranch, SHA: merged.MergeCommitSHA, WebURL: pipelineURL}})
+		jobs = append(jobs, stubPipelineJobs(pipelineURL, status))
+	}
+	s.Fixtures.Set(fmt.Sprintf("GET %s/pipelines?sha=%s", projectPath, merged.MergeCommitSHA), pipelines...)
+	s.Fixtures.Set(fmt.Sprintf("GET %s/pipelines/%d/jobs?per_page=100", projectPath, pipelineID), jobs...)
+	s.Fixtures.Set(fmt.Sprintf("GET %s/merge_requests/%d/pipelines", projectPath, iid), pipelines[len(pipelines)-1])
+
+	data, _ := json.Marshal(mr)
+	return GitLabFixtureResponse{Status: http.StatusCreated, Body: data}
+}
+
+// stubPipelineJobs returns the build and deploy jobs of a stub pipeline with the given status
+func stubPipelineJobs(pipelineURL, status string) []PipelineJob {
+	build, deploy := "success", status
+	switch status {
+	case "created", "pending":
+		build = status
+	}
+	return []PipelineJob{
+		{ID: 1, Name: "build", Stage: "build", Status: build, WebURL: pipelineURL + "/jobs/1"},
+		{ID: 2, Name: "deploy", Stage: "deploy", Status: deploy, WebURL: pipelineURL + "/jobs/2"},
+	}
+}
+
+// record proxies a request to the upstream GitLab and appends the response to the fixtures
+// of its key unless it repeats the last one, saving them to Path
+func (s *GitLabStub) record(w http.ResponseWriter, r *http.Request, key string) {
+	body, _ := io.ReadAll(r.Body)
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, strings.TrimSuffix(s.Upstream, "/")+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		writeStubError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	for _, name := range []string{"PRIVATE-TOKEN", "Content-Type"} {
+		if value := r.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		writeStubError(w, http.StatusBadGateway, "upstream GitLab: "+err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+
+	response := GitLabFixtureResponse{Status: resp.StatusCode, Body: data}
+	if !json.Valid(data) {
+		response.Body, _ = json.Marshal(string(data))
+	}
+	for _, name := range gitLabStubHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if response.Headers == nil {
+				response.Headers = make(map[string]string)
+			}
+			response.Headers[name] = value
+		}
+	}
+
+	s.mu.Lock()
+	list := s.Fixtures.Responses[key]
+	if len(list) == 0 || !bytes.Equal(list[len(list)-1].Body, response.Body) || list[len(list)-1].Status != response.Status {
+		s.Fixtures.Responses[key] = append(list, response)
+		if s.Path != "" {
+			if err := SaveGitLabFixtures(s.Path, s.Fixtures); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to save fixtures: %v\n", err)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	s.write(w, response, data)
+}
+
+// stubErrorResponse returns a GitLab error response with the given message
+func stubErrorResponse(status int, message string) GitLabFixtureResponse {
+	data, _ := json.Marshal(map[string]string{"message": message})
+	return GitLabFixtureResponse{Status: status, Body: data}
+}
+
+// writeStubError writes a GitLab error response with the given message
+func writeStubError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
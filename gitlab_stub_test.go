@@ -0,0 +1,33 @@
+package main
+
+import "net/http/httptest"
+
+// GitLabStubServer runs a GitLabStub on a local HTTP server, so GitLabClient can be tested
+// against it
+type GitLabStubServer struct {
+	*GitLabStub
+	Server *httptest.Server
+}
+
+// NewGitLabStubServer starts a stub serving the given fixtures
+func NewGitLabStubServer(fixtures *GitLabFixtures) *GitLabStubServer {
+	stub := newGitLabStub(fixtures)
+	return &GitLabStubServer{GitLabStub: stub, Server: httptest.NewServer(stub)}
+}
+
+// URL returns the base URL of the stub, usable as the GitLab URL of relix credentials
+func (s *GitLabStubServer) URL() string {
+	return s.Server.URL
+}
+
+// Close shuts the stub down
+func (s *GitLabStubServer) Close() {
+	s.Server.Close()
+}
+
+// SetResponses replaces the responses of a request key
+func (s *GitLabStubServer) SetResponses(key string, responses ...GitLabFixtureResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Fixtures.Responses[normalizeGitLabStubKey(key)] = responses
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Exit codes of the headless release command
+const (
+	exitReleaseOK       = 0
+	exitReleaseError    = 1
+	exitReleaseConflict = 2
+)
+
+// mrIIDsFlag collects repeatable --mr values
+type mrIIDsFlag []int
+
+func (f *mrIIDsFlag) String() string {
+	parts := make([]string, len(*f))
+	for i, iid := range *f {
+		parts[i] = strconv.Itoa(iid)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f *mrIIDsFlag) Set(value string) error {
+	iid, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(value), "!"))
+	if err != nil || iid <= 0 {
+		return fmt.Errorf("invalid MR IID: %s", value)
+	}
+	*f = append(*f, iid)
+	return nil
+}
+
+// headlessReleaseOptions holds parsed arguments of the release subcommand
+type headlessReleaseOptions struct {
+	projectDir   string
+	projectID    int
+	env          string
+	version      string
+	mrIIDs       mrIIDsFlag
+	sourceBranch string
+	rootMerge    bool
+	envMerge     string
+	moveTag      bool
+}
+
+// parseReleaseFlags parses arguments of the release subcommand
+func parseReleaseFlags(args []string) (*headlessReleaseOptions, error) {
+	opts := &headlessReleaseOptions{}
+	fs := flag.NewFlagSet("release", flag.ContinueOnError)
+
+	fs.StringVar(&opts.projectDir, "d", "", "Project root directory path")
+	fs.StringVar(&opts.projectDir, "project-directory", "", "Project root directory path")
+	fs.IntVar(&opts.projectID, "project-id", 0, "GitLab project ID")
+	fs.StringVar(&opts.env, "env", "", "Target environment name or branch")
+	fs.StringVar(&opts.version, "version", "", "Release version")
+	fs.Var(&opts.mrIIDs, "mr", "Merge request IID (repeatable, merged in the given order)")
+	fs.StringVar(&opts.sourceBranch, "source-branch", "", "Source branch accumulating MRs")
+	fs.BoolVar(&opts.rootMerge, "root-merge", false, "Merge release to root and root to develop")
+	fs.StringVar(&opts.envMerge, "env-merge", "", "Env merge mode: squash, regular or cherry-pick (default: environment setting or squash)")
+	fs.BoolVar(&opts.moveTag, "move-tag", false, "Move the release tag if it exists on origin at another commit")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: relix release --env <env> --version <version> --mr <iid> [--mr <iid> ...] [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Runs the full release without the TUI and streams git output to stdout.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fmt.Fprintf(os.Stderr, "  -d, --project-directory <path>  Project root directory path\n")
+		fmt.Fprintf(os.Stderr, "  --project-id <id>               GitLab project ID (default: selected project)\n")
+		fmt.Fprintf(os.Stderr, "  --env <name>                    Target environment name or branch\n")
+		fmt.Fprintf(os.Stderr, "  --version <version>             Release version (X.Y, X.Y.Z or X.Y.Z.W)\n")
+		fmt.Fprintf(os.Stderr, "  --mr <iid>                      Merge request IID, repeatable, at least one\n")
+		fmt.Fprintf(os.Stderr, "  --source-branch <branch>        Source branch (default: source branch naming template)\n")
+		fmt.Fprintf(os.Stderr, "  --root-merge                    Merge release to root and root to develop\n")
+		fmt.Fprintf(os.Stderr, "  --env-merge <mode>              squash, regular or cherry-pick (default: environment setting or squash)\n")
+		fmt.Fprintf(os.Stderr, "  --move-tag                      Move the release tag if it exists on origin at another commit\n")
+		fmt.Fprintf(os.Stderr, "\nExit codes: 0 success, 1 error, 2 merge conflict\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// A missing required flag is a usage error: print the usage as fs.Parse does for bad flags
+	required := func(name string) error {
+		err := fmt.Errorf("%s is required", name)
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+		return err
+	}
+	if opts.env == "" {
+		return nil, required("--env")
+	}
+	if opts.version == "" {
+		return nil, required("--version")
+	}
+	if len(opts.mrIIDs) == 0 {
+		return nil, required("--mr")
+	}
+	if !validateVersion(opts.version) {
+		return nil, fmt.Errorf("invalid version format %q. Use: X.Y, X.Y.Z, or X.Y.Z.W", opts.version)
+	}
+	if opts.envMerge != "" && !slices.Contains(envMergeModes, opts.envMerge) {
+		return nil, fmt.Errorf("--env-merge must be squash, regular or cherry-pick")
+	}
+	if opts.sourceBranch != "" && !strings.Contains(opts.sourceBranch, opts.version) {
+		return nil, fmt.Errorf("source branch name must contain version: %s", opts.version)
+	}
+
+	return opts, nil
+}
+
+// findEnvironment looks up a configured environment by display name or branch name
+func findEnvironment(configs []EnvConfig, name string) (*Environment, error) {
+	if len(configs) == 0 {
+		configs = defaultEnvironments()
+	}
+	envs := envsFromConfig(configs)
+	for i := range envs {
+		if strings.EqualFold(envs[i].Name, name) || envs[i].BranchName == name {
+			return &envs[i], nil
+		}
+	}
+	names := make([]string, len(envs))
+	for i, env := range envs {
+		names[i] = strings.ToLower(env.Name)
+	}
+	return nil, fmt.Errorf("unknown environment %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// loadHeadlessCredentials returns GitLab credentials from RELIX_GITLAB_URL/RELIX_GITLAB_TOKEN
+// environment variables, falling back to the system keyring
+func loadHeadlessCredentials() (*Credentials, error) {
+	url := os.Getenv("RELIX_GITLAB_URL")
+	token := os.Getenv("RELIX_GITLAB_TOKEN")
+	if url != "" && token != "" {
+		return &Credentials{GitLabURL: strings.TrimRight(url, "/"), Token: token}, nil
+	}
+	creds, err := LoadCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("no GitLab credentials: set RELIX_GITLAB_URL and RELIX_GITLAB_TOKEN or log in via relix first")
+	}
+	return creds, nil
+}
+
+// runReleaseCommand runs the headless release subcommand and returns the process exit code
+func runReleaseCommand(args []string) int {
+	opts, err := parseReleaseFlags(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return exitReleaseOK
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitReleaseError
+	}
+
+	if opts.projectDir != "" {
+		absPath, err := resolveProjectDirectory(opts.projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitReleaseError
+		}
+		projectDirectory = absPath
+	}
+
+	// Ctrl+C cancels the GitLab requests in flight, suspending the release at its current step
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	state, client, err := prepareHeadlessRelease(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitReleaseError
+	}
+
+	lock, err := AcquireReleaseLock(state)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitReleaseError
+	}
+	defer lock.Release()
+
+	// The worktree is reset only under the lock, so a running release's worktree is left alone
+	if config, err := LoadConfig(); err == nil && config.ReleaseInWorktree {
+		if err := UseReleaseWorktree(state); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to prepare release worktree: %v\n", err)
+			return exitReleaseError
+		}
+	}
+
+	return executeHeadlessRelease(ctx, state, client, opts.moveTag, os.Stdout)
+}
+
+// prepareHeadlessRelease validates the environment and builds the initial release state
+// the same way startRelease does for the TUI
+func prepareHeadlessRelease(ctx context.Context, opts *headlessReleaseOptions) (*ReleaseState, *GitLabClient, error) {
+	workDir, err := FindProjectRoot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find project root: %w", err)
+	}
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if !config.ReleaseInWorktree {
+		hasChanges, err := HasUncommittedChanges(workDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasChanges {
+			return nil, nil, fmt.Errorf("cannot start release: there are uncommitted changes in the working directory")
+		}
+	}
+
+	projectID := opts.projectID
+	if projectID == 0 {
+		projectID = config.SelectedProjectID
+	}
+	if projectID == 0 {
+		return nil, nil, fmt.Errorf("no project selected: pass --project-id or select a project in relix")
+	}
+
+	// Release settings of the project: global, .relix.json of the checkout, project overrides
+	projectConfig, err := LoadEffectiveConfig(projectID, workDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load project config: %w", err)
+	}
+
+	env, err := findEnvironment(projectConfig.Environments, opts.env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := LoadReleaseState(projectID, env.BranchName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read release state: %w", err)
+	}
+	if existing != nil {
+		return nil, nil, fmt.Errorf("release %s to %s is already in progress; resume or abort it in relix first",
+			existing.Version, existing.Environment.Name)
+	}
+
+	creds, err := loadHeadlessCredentials()
+	if err != nil {
+		return nil, nil, err
+	}
+	client := NewGitLabClient(creds.GitLabURL, creds.Token).WithContext(ctx)
+
+	var branches, mrURLs, mrCommitSHAs, issueTexts []string
+	var mrs []*MergeRequestDetails
+	for _, iid := range opts.mrIIDs {
+		mr, err := client.GetMergeRequestByIID(projectID, iid)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch MR !%d: %w", iid, err)
+		}
+		if mr.State != "opened" {
+			return nil, nil, fmt.Errorf("MR !%d is %s", iid, mr.State)
+		}
+		branches = append(branches, mr.SourceBranch)
+		mrURLs = append(mrURLs, mr.WebURL)
+		mrCommitSHAs = append(mrCommitSHAs, mr.SHA)
+		issueTexts = append(issueTexts, mr.SourceBranch, mr.Title)
+		mrs = append(mrs, mr)
+	}
+
+	// Pre-flight checks blocking the release by the environment policy
+	envConfig := envConfigByBranch(projectConfig.Environments, env.BranchName)
+	if envConfig != nil && len(envConfig.preflightBlockingChecks()) > 0 {
+		if problem := validatePreflightBlocking(envConfig.PreflightBlocking); problem != "" {
+			return nil, nil, fmt.Errorf("invalid preflight_blocking of %s: %s", env.Name, problem)
+		}
+		if blockers := preflightBlockers(runPreflight(client, projectID, mrs), envConfig); len(blockers) > 0 {
+			return nil, nil, fmt.Errorf("pre-flight checks block the release to %s:\n  %s", env.Name, strings.Join(blockers, "\n  "))
+		}
+	}
+
+	rules := trackerRulesOf(projectConfig)
+	issues := fetchTrackerIssues(findTrackerIssues(rules, issueTexts...), creds)
+
+	envMergeMode := opts.envMerge
+	if envMergeMode == "" {
+		envMergeMode = "squash"
+		if envConfig != nil && envConfig.EnvMergeMode != "" {
+			envMergeMode = envMergeModes[envMergeModeIndex(envConfig.EnvMergeMode)]
+		}
+	}
+
+	baseBranch := projectConfig.BaseBranch
+	if baseBranch == "" {
+		baseBranch = "root"
+	}
+
+	naming := projectConfig.Naming.withDefaults()
+	if err := validateNamingTemplates(naming); err != "" {
+		return nil, nil, fmt.Errorf("invalid naming templates: %s", err)
+	}
+	startedAt := time.Now()
+	sourceBranch := opts.sourceBranch
+	if sourceBranch == "" {
+		sourceBranch = renderNamingTemplate(naming.SourceBranch, NamingVars{Version: opts.version, Env: env.Name, EnvBranch: env.BranchName, Date: startedAt})
+	}
+
+	notes := BuildReleaseNoteEntries(mrs, rules, issues)
+	state := &ReleaseState{
+		SelectedMRIIDs:       append([]int{}, opts.mrIIDs...),
+		MRBranches:           branches,
+		MRURLs:               mrURLs,
+		MRCommitSHAs:         mrCommitSHAs,
+		Environment:          *env,
+		Version:              opts.version,
+		BaseBranch:           baseBranch,
+		SourceBranch:         sourceBranch,
+		SourceBranchIsRemote: RemoteBranchExists(workDir, sourceBranch),
+		RootMerge:            opts.rootMerge,
+		EnvMergeMode:         envMergeMode,
+		ProjectID:            projectID,
+		Naming:               naming,
+		StartedAt:            startedAt,
+		ReleaseNotes:         formatReleaseNotes(notes),
+		ReleaseNoteEntries:   notes,
+		GitLabRelease:        projectConfig.GitLabRelease,
+		CurrentStep:          ReleaseStepGitFetch,
+		LastSuccessStep:      ReleaseStepIdle,
+		MergedBranches:       []string{},
+		WorkDir:              workDir,
+	}
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+	if projectID == config.SelectedProjectID {
+		state.ProjectName = config.SelectedProjectShortName
+		state.ProjectPath = config.SelectedProjectPath
+	}
+
+	return state, client, nil
+}
+
+// executeHeadlessRelease drives the release state machine to completion without waiting
+// for user actions. State is saved after every step, so a failed release can be retried
+// or aborted from the TUI. A release tag existing on origin at another commit is only
+// moved with moveTag. Cancelling ctx suspends the release before its next step.
+func executeHeadlessRelease(ctx context.Context, state *ReleaseState, client *GitLabClient, moveTag bool, out io.Writer) int {
+	var log strings.Builder
+	executor := NewGitExecutor(state.WorkDir, nil)
+	executor.SetOutput(io.MultiWriter(out, &log))
+	defer executor.Close()
+
+	terminalOutput := func() []string {
+		return strings.Split(strings.ReplaceAll(log.String(), "\r\n", "\n"), "\n")
+	}
+
+	engine := NewReleaseEngine(state, executor, client)
+	engine.Subscribe(func(ev ReleaseEvent) {
+		switch ev := ev.(type) {
+		case ReleaseStepStartedEvent:
+			fmt.Fprintf(out, "\n==> [%d/%d] %s\n", state.CompletedSubSteps, state.TotalSubSteps, releaseStepTitle(state, ev.Step))
+		case ReleaseMRCreatedEvent:
+			fmt.Fprintf(out, "\nMerge request created: %s\n", ev.URL)
+		case ReleaseStepFinishedEvent:
+			// Save terminal output for resume in the TUI
+			state.TerminalOutput = terminalOutput()
+			SaveReleaseState(state)
+		}
+	})
+
+	SaveReleaseState(state)
+
+	err := engine.RunContext(ctx)
+	if move := state.TagMove; err != nil && moveTag && move != nil && state.LastError != nil && state.LastError.Code == "TAG_COLLISION" {
+		fmt.Fprintf(out, "\nMoving tag %s on origin from %s to %s (--move-tag)\n", move.Tag, move.OldSHA, move.NewSHA)
+		engine.ConfirmTagMove()
+		err = engine.RunContext(ctx)
+	}
+	if err != nil {
+		fmt.Fprintf(out, "\nERROR: %v\n", err)
+		if state.LastError != nil && state.LastError.Code == "COMMIT_FAILED" {
+			fmt.Fprintf(out, "Switched to %s for fixes\n", engine.RootBranch())
+		}
+		state.TerminalOutput = terminalOutput()
+		state.ErrorOutput = GetLastNLines(strings.Join(state.TerminalOutput, "\n"), 5000)
+		SaveReleaseState(state)
+
+		if state.InWorktree() {
+			fmt.Fprintf(os.Stderr, "Release worktree: %s\n", state.WorkDir)
+		}
+		if DetectMergeConflict(state.WorkDir) {
+			fmt.Fprintf(os.Stderr, "Merge conflict: resolve it and run relix to retry or abort the release\n")
+			return exitReleaseConflict
+		}
+		if state.LastError != nil && state.LastError.Code == "TAG_COLLISION" {
+			fmt.Fprintf(os.Stderr, "Tag collision: run relix to move the tag or abort the release\n")
+			return exitReleaseError
+		}
+		fmt.Fprintf(os.Stderr, "Release failed: run relix to retry or abort the release\n")
+		return exitReleaseError
+	}
+
+	SaveReleaseHistory(state, "completed", terminalOutput())
+	ClearReleaseState(state)
+
+	fmt.Fprintf(out, "\nRelease %s to %s completed", state.Version, state.Environment.Name)
+	if state.TagName != "" {
+		fmt.Fprintf(out, " (tag %s)", state.TagName)
+	}
+	fmt.Fprintln(out)
+	return exitReleaseOK
+}
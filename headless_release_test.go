@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestParseReleaseFlagsRequired(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--version", "1.0.0", "--mr", "1"}, "--env is required"},
+		{[]string{"--env", "stage", "--mr", "1"}, "--version is required"},
+		{[]string{"--env", "stage", "--version", "1.0.0"}, "--mr is required"},
+	}
+	for _, tt := range tests {
+		_, err := parseReleaseFlags(tt.args)
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("parseReleaseFlags(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+
+	opts, err := parseReleaseFlags([]string{"--env", "stage", "--version", "1.0.0", "--mr", "1", "--mr", "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts.mrIIDs) != 2 || opts.mrIIDs[0] != 1 || opts.mrIIDs[1] != 2 {
+		t.Errorf("mrIIDs = %v, want [1 2]", opts.mrIIDs)
+	}
+}
+
+func TestRunReleaseCommandWithoutMR(t *testing.T) {
+	if code := runReleaseCommand([]string{"--env", "stage", "--version", "1.0.0"}); code != exitReleaseError {
+		t.Errorf("runReleaseCommand without --mr = %d, want %d", code, exitReleaseError)
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -11,7 +13,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-var historyDetailTabs = []string{"MRs", "Meta", "Logs"}
+var historyDetailTabs = []string{"MRs", "Meta", "Logs", "Notes"}
 
 // remapTerminalColors replaces ANSI escape sequences from the theme that was
 // active at save time with sequences from the current theme. Plain-text lines
@@ -85,6 +87,16 @@ func (m *model) initHistoryDetailScreen() {
 		m.historyLogsViewport.SetContent(strings.Join(remapped, "\n"))
 	}
 
+	// Initialize release notes viewport
+	if m.historyDetailTab == 3 { // Notes tab
+		notesHeight := contentHeight - 8 // tabs + padding + export status
+		if notesHeight < 1 {
+			notesHeight = 1
+		}
+		m.historyNotesViewport = viewport.New(m.width-8, notesHeight)
+		m.historyNotesViewport.SetContent(renderReleaseNotes(m.historySelected.ReleaseNotes, m.width-8))
+	}
+
 	// Initialize MRs viewport for MRs tab
 	if m.historyDetailTab == 0 {
 		sidebarW := sidebarWidth(m.width)
@@ -122,6 +134,7 @@ func (m model) updateHistoryDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+q", "esc":
 		// Go back to history list
+		m.cancelGitLabRequests("history")
 		m.screen = screenHistoryList
 		m.historySelected = nil
 		m.historyMRDetailsMap = make(map[int]*MergeRequestDetails)
@@ -137,9 +150,18 @@ func (m model) updateHistoryDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "o":
 		// Show options modal based on current tab
 		if m.historySelected != nil {
-			return m.handleOpenAction(buildHistoryOpenOptions(m.historySelected, m.historyMRIndex, m.historyDetailTab))
+			return m.handleOpenAction(buildHistoryOpenOptions(m.historySelected, m.historyMRIndex, m.historyDetailTab, m.trackerRules))
 		}
 		return m, nil
+	case "c":
+		// Export release notes to CHANGELOG.md (if on Notes tab)
+		if m.historyDetailTab == 3 && m.historySelected != nil {
+			return m.exportHistoryChangelog()
+		}
+		return m, nil
+	case "p":
+		// Promote the release to the next environment
+		return m.promoteRelease()
 	case "r":
 		// Reload MRs (if on MRs tab)
 		if m.historyDetailTab == 0 && m.historySelected != nil {
@@ -156,6 +178,10 @@ func (m model) updateHistoryDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.historyLogsViewport, cmd = m.historyLogsViewport.Update(msg)
 		return m, cmd
+	case 3: // Notes tab - scroll viewport
+		var cmd tea.Cmd
+		m.historyNotesViewport, cmd = m.historyNotesViewport.Update(msg)
+		return m, cmd
 	}
 
 	return m, nil
@@ -204,12 +230,15 @@ func (m *model) fetchAllHistoryMRs() tea.Cmd {
 		return nil
 	}
 
-	return func() tea.Msg {
-		if m.creds == nil || m.selectedProject == nil {
+	if m.creds == nil || m.selectedProject == nil {
+		return func() tea.Msg {
 			return fetchAllHistoryMRsMsg{err: fmt.Errorf("no credentials or project")}
 		}
+	}
 
-		client := NewGitLabClient(m.creds.GitLabURL, m.creds.Token)
+	// A reload cancels the previous fetch, leaving the entry cancels both
+	client := m.gitLabClient("history")
+	return func() tea.Msg {
 		mrDetailsMap := make(map[int]*MergeRequestDetails)
 
 		// Fetch all MRs - prefer using saved IIDs if available, fallback to branch search
@@ -281,6 +310,8 @@ func (m model) viewHistoryDetail() string {
 		content = m.viewHistoryMetaTab(contentHeight - 6)
 	case 2:
 		content = m.viewHistoryLogsTab(contentHeight - 6)
+	case 3:
+		content = m.viewHistoryNotesTab()
 	}
 
 	// Build title
@@ -291,13 +322,9 @@ func (m model) viewHistoryDetail() string {
 		statusStyle = historyStatusAbortedStyle
 	}
 
-	// Extract version number from tag (format: "{env}-{version}-v{number}")
 	vNumber := ""
-	if entry.Tag != "" {
-		parts := strings.Split(entry.Tag, "-v")
-		if len(parts) > 1 {
-			vNumber = " v" + parts[len(parts)-1]
-		}
+	if number := historyReleaseNumber(entry); number != "" {
+		vNumber = " v" + number
 	}
 
 	// Prefix style (matching history list title)
@@ -308,8 +335,13 @@ func (m model) viewHistoryDetail() string {
 		PaddingLeft(1).
 		PaddingRight(1)
 
+	prefix := "Release"
+	if entry.Rollback != nil {
+		prefix = "Rollback"
+	}
+
 	fgStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
-	titleText := prefixStyle.Render("Release") + " " +
+	titleText := prefixStyle.Render(prefix) + " " +
 		fgStyle.Render(entry.Version+vNumber+" to ") +
 		envStyle.Render(entry.Environment) + fgStyle.Render(" was ") +
 		statusStyle.Render(entry.Status) + fgStyle.Render(" at "+
@@ -329,7 +361,13 @@ func (m model) viewHistoryDetail() string {
 		Render(titleWithBorder + "\n\n" + tabs + "\n\n" + content)
 
 	// Help footer with empty line after
-	helpText := "H/L: switch tab • j/k: nav • d/u: scroll • o: open • r: reload • C+q: back"
+	helpText := "H/L: switch tab • j/k: nav • d/u: scroll • o: open • r: reload • p: promote • C+q: back"
+	if m.historyDetailTab == 3 {
+		helpText = "H/L: switch tab • j/k: scroll • c: export to CHANGELOG.md • o: open • p: promote • C+q: back"
+	}
+	if m.promotionChecking {
+		helpText = m.spinner.View() + " Checking source branch of the release..."
+	}
 	help := helpStyle.Width(m.width).Align(lipgloss.Center).Render(helpText)
 
 	return lipgloss.JoinVertical(lipgloss.Left, main, help, "")
@@ -399,16 +437,15 @@ func (m model) viewHistoryMetaTab(height int) string {
 	entry := m.historySelected
 	var sb strings.Builder
 
-	// Extract version number from tag (e.g., "5.0-v33" -> "33")
-	number := ""
-	if parts := strings.Split(entry.Tag, "-v"); len(parts) > 1 {
-		number = parts[len(parts)-1]
-	}
+	number := historyReleaseNumber(entry)
 
-	// Reconstruct full tag: {env}-{tag} (e.g., "test-5.0-v33")
-	fullTag := entry.Tag
-	if entry.Environment != "" && entry.Tag != "" {
-		fullTag = strings.ToLower(entry.Environment) + "-" + entry.Tag
+	// Full tag name; reconstructed as {env}-{tag} (e.g., "test-5.0-v33") for entries saved without it
+	fullTag := entry.TagName
+	if fullTag == "" {
+		fullTag = entry.Tag
+		if entry.Environment != "" && entry.Tag != "" {
+			fullTag = strings.ToLower(entry.Environment) + "-" + entry.Tag
+		}
 	}
 
 	rows := []struct {
@@ -427,6 +464,47 @@ func (m model) viewHistoryMetaTab(height int) string {
 		{"MRs count", fmt.Sprintf("%d", entry.MRCount)},
 	}
 
+	if entry.SourceBranchSHA != "" {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Source commit", entry.SourceBranchSHA})
+	}
+
+	if entry.PromotedFrom != "" {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Promoted from", entry.PromotedFrom})
+	}
+
+	if entry.EnvReleaseSHA != "" {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Env commit", entry.EnvReleaseSHA})
+	}
+
+	if entry.Rollback != nil {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Restored release", entry.Rollback.Restored})
+		if entry.Rollback.Reverted != "" {
+			rows = append(rows, struct {
+				label string
+				value string
+			}{"Rolled back release", entry.Rollback.Reverted})
+		}
+	}
+
+	if entry.RolledBackBy != "" {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Rolled back by", entry.RolledBackBy})
+	}
+
 	if entry.CreatedMRURL != "" {
 		rows = append(rows, struct {
 			label string
@@ -434,6 +512,27 @@ func (m model) viewHistoryMetaTab(height int) string {
 		}{"MR URL", entry.CreatedMRURL})
 	}
 
+	if entry.TagMove != nil {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Tag collision", tagMoveSummary(entry.TagMove)})
+	}
+
+	if entry.GitLabReleaseURL != "" {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Release URL", entry.GitLabReleaseURL})
+	}
+
+	for _, skipped := range entry.SkippedMRs {
+		rows = append(rows, struct {
+			label string
+			value string
+		}{"Skipped MR", fmt.Sprintf("%s (%s)", skipped.Branch, skipped.Reason)})
+	}
+
 	for _, row := range rows {
 		label := historyMetaLabelStyle.Width(20).Render(row.label)
 
@@ -470,6 +569,76 @@ func (m model) viewHistoryLogsTab(height int) string {
 	return m.historyLogsViewport.View()
 }
 
+// viewHistoryNotesTab renders the Notes tab
+func (m model) viewHistoryNotesTab() string {
+	if m.historySelected == nil {
+		return ""
+	}
+
+	if m.historySelected.ReleaseNotes == "" {
+		return lipgloss.NewStyle().Foreground(currentTheme.Notion).Render("No release notes available")
+	}
+
+	status := m.historyNotesStatus
+	if status == "" {
+		status = "Press c to add these notes to CHANGELOG.md in the project root"
+	}
+	return helpStyle.Render(status) + "\n\n" + m.historyNotesViewport.View()
+}
+
+// exportHistoryChangelog adds the release notes of the selected history entry to CHANGELOG.md
+// in the project root, replacing the section of the release if it was exported before
+func (m model) exportHistoryChangelog() (tea.Model, tea.Cmd) {
+	entry := m.historySelected
+	if entry.ReleaseNotes == "" {
+		return m, nil
+	}
+	if entry.Status != "completed" {
+		m.showErrorModal = true
+		m.errorModalMsg = "Only completed releases can be exported to the changelog"
+		return m, nil
+	}
+
+	workDir, err := FindProjectRoot()
+	if err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Failed to find project root: %v", err)
+		return m, nil
+	}
+	path := filepath.Join(workDir, "CHANGELOG.md")
+	if err := WriteChangelogSection(path, changelogHeading(entry), entry.ReleaseNotes); err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Failed to write %s: %v", path, err)
+		return m, nil
+	}
+	m.historyNotesStatus = "Exported to " + path
+	return m, nil
+}
+
+// renderReleaseNotes renders release notes markdown for the terminal
+func renderReleaseNotes(notes string, width int) string {
+	style := styles.DarkStyleConfig
+	style.Document.StylePrimitive.Color = stringPtr(string(currentTheme.Foreground))
+	style.Strong.Color = stringPtr(string(currentTheme.Warning))
+	style.H3.Prefix = ""
+	style.H3.Color = stringPtr(string(currentTheme.Accent))
+	style.Code.BackgroundColor = stringPtr(string(currentTheme.Muted))
+	style.Code.Color = stringPtr(string(currentTheme.MutedForeground))
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return notes
+	}
+	rendered, err := renderer.Render(notes)
+	if err != nil {
+		return notes
+	}
+	return strings.Trim(rendered, "\n")
+}
+
 // updateHistoryMRViewport updates the history MR viewport with the fetched MR details
 func (m *model) updateHistoryMRViewport() {
 	if m.historyMRViewport.Width == 0 {
@@ -535,30 +704,13 @@ func (m model) renderHistoryMRMarkdown() string {
 	// Clean up author name (replace multiple spaces with single space)
 	authorName := strings.Join(strings.Fields(details.Author.Name), " ")
 
-	// Build info table row
-	discussionInfo := fmt.Sprintf(
-		"%d/%d",
-		details.DiscussionsResolved,
-		details.DiscussionsTotal)
-	if details.DiscussionsTotal == 0 {
-		discussionInfo = "0/0"
-	}
-
-	changesCount := details.ChangesCount
-	if changesCount == "" {
-		changesCount = "0"
-	}
-
 	// Build markdown content
 	markdown := fmt.Sprintf(`# %s
 
 ### %s (@%s)
 **%s** -> %s (at %s)
 
- | Overview | Commits | Changes |
- |:--------:|:-------:|:-------:|
- | %s | %d | %s |
-
+%s
  %s
  `,
 		details.Title,
@@ -567,9 +719,7 @@ func (m model) renderHistoryMRMarkdown() string {
 		details.SourceBranch,
 		details.TargetBranch,
 		details.CreatedAt.Format("02.01.2006 15:04"),
-		discussionInfo,
-		details.CommitsCount,
-		changesCount,
+		mrInfoMarkdown(details),
 		details.Description,
 	)
 
@@ -586,3 +736,15 @@ func (m model) renderHistoryMRMarkdown() string {
 
 	return strings.Trim(rendered, "\n")
 }
+
+// historyReleaseNumber returns the ordinal number of a release. Entries saved without it
+// get it from the default tag format (e.g., "5.0-v33" -> "33").
+func historyReleaseNumber(entry *ReleaseHistoryEntry) string {
+	if entry.Number > 0 {
+		return strconv.Itoa(entry.Number)
+	}
+	if parts := strings.Split(entry.Tag, "-v"); len(parts) > 1 {
+		return parts[len(parts)-1]
+	}
+	return ""
+}
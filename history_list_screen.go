@@ -187,10 +187,13 @@ func (m model) updateHistoryList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	}
+	if m.showRollbackConfirm {
+		return m.updateRollbackConfirm(msg)
+	}
 
 	switch msg.String() {
 	case "ctrl+q":
-		m.screen = screenHome
+		m.showHome()
 		m.historySelectMode = false
 		m.historySelectedIDs = nil
 		return m, nil
@@ -205,7 +208,7 @@ func (m model) updateHistoryList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.historySelectedIDs = nil
 			return m, nil
 		}
-		m.screen = screenHome
+		m.showHome()
 		return m, nil
 	case "v":
 		if m.historyList.FilterState() == list.Filtering {
@@ -242,6 +245,11 @@ func (m model) updateHistoryList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.historyDeleteConfirmIndex = 1 // Cancel focused by default
 			return m, nil
 		}
+	case "b":
+		if m.historyList.FilterState() == list.Filtering || m.historySelectMode {
+			break
+		}
+		return m.startRollback()
 	case "enter":
 		// If filtering, let the list handle it
 		if m.historyList.FilterState() == list.Filtering {
@@ -387,7 +395,7 @@ func (m model) viewHistoryList() string {
 	if m.historySelectMode {
 		helpText = "v: exit select • space: toggle • d: delete • esc: cancel"
 	} else {
-		helpText = "j/k: nav • enter: view • /: search • v: select • C+q: back • C+c: quit"
+		helpText = "j/k: nav • enter: view • b: roll back • /: search • v: select • C+q: back • C+c: quit"
 	}
 	help := helpStyle.Width(m.width).Align(lipgloss.Center).Render(helpText)
 
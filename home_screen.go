@@ -17,12 +17,6 @@ const relixASCII = `
 ██║  ██║███████╗███████╗██║██╔╝ ██╗
 ╚═╝  ╚═╝╚══════╝╚══════╝╚═╝╚═╝  ╚═╝`
 
-// hasInProgressRelease checks if there is an uncompleted release
-func hasInProgressRelease() bool {
-	state, err := LoadReleaseState()
-	return err == nil && state != nil
-}
-
 // updateHome handles key events on the home screen
 func (m model) updateHome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -45,6 +39,13 @@ func (m model) updateHome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(m.spinner.Tick, m.fetchMRs())
 		}
 		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// Resume a pending release
+		index := int(msg.String()[0] - '1')
+		if index < len(m.pendingReleases) {
+			return m.openPendingRelease(m.pendingReleases[index].State)
+		}
+		return m, nil
 	case "h":
 		// Go to releases history
 		m.screen = screenHistoryList
@@ -55,30 +56,8 @@ func (m model) updateHome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Open settings screen
 		m.settingsPreviousScreen = m.screen
 		m.settingsTab = 0
-		m.settingsFocusIndex = 0
-		if config, err := LoadConfig(); err == nil {
-			m.settingsExcludePatterns.SetValue(config.ExcludePatterns)
-			pipelineRegex := config.PipelineJobsRegex
-			if pipelineRegex == "" {
-				pipelineRegex = defaultPipelineJobsRegex
-			}
-			m.settingsPipelineRegex.SetValue(pipelineRegex)
-			// Load base branch
-			baseBranch := config.BaseBranch
-			if baseBranch == "" {
-				baseBranch = "root"
-			}
-			m.settingsBaseBranch.SetValue(baseBranch)
-			// Load environment settings
-			envs := config.Environments
-			if len(envs) == 0 {
-				envs = defaultEnvironments()
-			}
-			for i := 0; i < 4 && i < len(envs); i++ {
-				m.settingsEnvNames[i].SetValue(strings.ToUpper(envs[i].Name))
-				m.settingsEnvBranches[i].SetValue(envs[i].BranchName)
-			}
-		}
+		m.settingsFocusIndex = settingsBaseBranchIndex
+		(&m).loadSettingsFromConfig()
 		(&m).updateTextareaTheme()
 		m.screen = screenSettings
 		(&m).initSettingsViewport()
@@ -101,17 +80,11 @@ func (m model) viewHome() string {
 	sb.WriteString(title)
 	sb.WriteString("\n\n")
 
-	// Menu items
-	releaseLabel := "Release"
-	if hasInProgressRelease() {
-		releaseLabel = "Continue release"
-	}
-
 	menuItems := []struct {
 		key   string
 		label string
 	}{
-		{"r", releaseLabel},
+		{"r", "Release"},
 		{"h", "Releases history"},
 		{"s", "Settings"},
 	}
@@ -150,6 +123,15 @@ func (m model) viewHome() string {
 		)
 	sb.WriteString(menuBlock)
 
+	// Pending releases to resume, centered within title width
+	if pending := renderPendingReleases(m.pendingReleases); pending != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().
+			Width(titleWidth).
+			Align(lipgloss.Center).
+			Render(lipgloss.NewStyle().Align(lipgloss.Left).Render(pending)))
+	}
+
 	// Version centered within title width
 	sb.WriteString("\n\n")
 	version := lipgloss.NewStyle().
@@ -16,6 +16,14 @@ const AppVersion = "0.1.0"
 var projectDirectory string
 
 func main() {
+	// Headless subcommands run without the TUI
+	if len(os.Args) > 1 && os.Args[1] == "release" {
+		os.Exit(runReleaseCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "dev-server" {
+		os.Exit(runDevServerCommand(os.Args[2:]))
+	}
+
 	// Define command-line flags
 	var showHelp bool
 	var showVersion bool
@@ -31,7 +39,9 @@ func main() {
 	// Custom usage message
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Relix - GitLab Release Manager\n\n")
-		fmt.Fprintf(os.Stderr, "Usage: relix [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: relix [options]\n")
+		fmt.Fprintf(os.Stderr, "       relix release [release options]\n")
+		fmt.Fprintf(os.Stderr, "       relix dev-server [dev-server options]\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		fmt.Fprintf(os.Stderr, "  -d, --project-directory <path>  Project root directory path\n")
 		fmt.Fprintf(os.Stderr, "  -h, --help                      Show this help message\n")
@@ -39,6 +49,9 @@ func main() {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  relix                           Run in current directory\n")
 		fmt.Fprintf(os.Stderr, "  relix -d /path/to/project       Run with specified project directory\n")
+		fmt.Fprintf(os.Stderr, "  relix release --env stage --version 4.2.0 --mr 123 --mr 456\n")
+		fmt.Fprintf(os.Stderr, "                                  Run a release without the TUI (see relix release -h)\n")
+		fmt.Fprintf(os.Stderr, "  relix dev-server                Serve a fake GitLab for working offline (see relix dev-server -h)\n")
 	}
 
 	flag.Parse()
@@ -57,29 +70,11 @@ func main() {
 
 	// Validate and set project directory
 	if projectDir != "" {
-		// Convert to absolute path
-		absPath, err := filepath.Abs(projectDir)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: invalid project directory path: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Check if directory exists
-		info, err := os.Stat(absPath)
+		absPath, err := resolveProjectDirectory(projectDir)
 		if err != nil {
-			if os.IsNotExist(err) {
-				fmt.Fprintf(os.Stderr, "Error: project directory does not exist: %s\n", absPath)
-			} else {
-				fmt.Fprintf(os.Stderr, "Error: cannot access project directory: %v\n", err)
-			}
-			os.Exit(1)
-		}
-
-		if !info.IsDir() {
-			fmt.Fprintf(os.Stderr, "Error: specified path is not a directory: %s\n", absPath)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-
 		projectDirectory = absPath
 	}
 
@@ -98,3 +93,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// resolveProjectDirectory converts the -d flag value to an absolute path and checks it is a directory
+func resolveProjectDirectory(projectDir string) (string, error) {
+	// Convert to absolute path
+	absPath, err := filepath.Abs(projectDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid project directory path: %v", err)
+	}
+
+	// Check if directory exists
+	info, err := os.Stat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("project directory does not exist: %s", absPath)
+		}
+		return "", fmt.Errorf("cannot access project directory: %v", err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("specified path is not a directory: %s", absPath)
+	}
+
+	return absPath, nil
+}
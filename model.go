@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -40,6 +42,12 @@ type model struct {
 	loadingMRs   bool // Loading modal for MRs
 	mrsLoaded    bool // True after first MR load completes
 	mrsLoadError bool // True if last MR load failed
+	trackerRules []TrackerRule // Issue tracker rules of the selected project
+	trackerIssues map[string]*TrackerIssueResult // Fetched linked issue details by issue key
+
+	mrDetailsLoading map[*MergeRequestDetails]bool // Lazily listed MRs whose details are being fetched
+
+	gitLabRequests map[string]gitLabRequests // In-flight GitLab requests by purpose, see gitLabClient
 
 	// Environment selection screen
 	environments   []Environment
@@ -59,8 +67,8 @@ type model struct {
 	sourceBranchCheckedName   string    // Branch name that was last checked
 
 	// Env merge screen
-	envMergeOptionIndex  int  // 0 = squash (default), 1 = regular
-	envMergeSelection    int  // confirmed choice: 0 = squash, 1 = regular
+	envMergeOptionIndex  int  // Index in envMergeModes: 0 = squash (default), 1 = regular, 2 = cherry-pick
+	envMergeSelection    int  // confirmed choice, index in envMergeModes
 	envMergeCommitCount  int  // calculated commit count for regular merge display
 	envMergeCountLoading bool // true while calculating commit count
 
@@ -69,7 +77,16 @@ type model struct {
 	rootMergeSelection   bool // true = merge, false = skip
 
 	// Confirmation screen
-	confirmViewport viewport.Model
+	confirmViewport   viewport.Model
+	confirmSimulating bool               // Release simulation is running
+	confirmSimulation *ReleaseSimulation // Result of the last simulation
+
+	confirmPreparingWorktree bool // Release worktree is being prepared before the release starts
+
+	// Pre-flight screen
+	preflightRunning bool          // Pre-flight checks of the selected MRs are running
+	preflightResults []PreflightMR // Results of the last pre-flight run
+	preflightRun     int           // Number of the latest pre-flight run, older results are dropped
 
 	// Command menu
 	showCommandMenu  bool
@@ -93,12 +110,22 @@ type model struct {
 	settingsViewport        viewport.Model
 	settingsTab             int // Current tab index (0 = Release, 1 = Theme)
 	settingsBaseBranch      textinput.Model
-	settingsEnvNames        [4]textinput.Model
-	settingsEnvBranches     [4]textinput.Model
+	settingsEnvs            []settingsEnvRow // Environment rows, any count
 	settingsExcludePatterns textarea.Model
 	settingsPipelineRegex   textinput.Model
+	settingsNaming          []textinput.Model // Naming template inputs, see settings_naming.go
+	settingsReleaseWorktree bool   // Run releases in a dedicated worktree
+	settingsLazyMRDetails   bool   // Fetch MR details only when the MR is focused
+	settingsGitLabRelease   bool   // Create a GitLab Release after the root push
 	settingsError           string // Validation error message
-	settingsFocusIndex      int    // 0=base branch, 1-8=env fields, 9=textarea, 10=pipeline regex, 11=save button
+	settingsFocusIndex      int    // Focused field, see the Release tab layout in settings_environments.go
+
+	// Release settings layers (within settings modal)
+	settingsConfig      *AppConfig                         // Global config the layers were loaded from
+	settingsLayers      [configLayerCount]*ReleaseSettings // Edited release settings of each layer
+	settingsLayer       ConfigLayer                        // Layer shown on the Release tab
+	settingsProjectID   int                                // Project of the Project layer, 0 if none selected
+	settingsProjectRoot string                             // Project root holding the Repository layer .relix.json
 
 	// Theme settings (within settings modal)
 	settingsThemes     []ThemeConfig // Themes loaded from config for display
@@ -114,12 +141,36 @@ type model struct {
 	releaseButtons                   []ReleaseButton
 	releaseRunning                   bool
 	releaseExecutor                  *GitExecutor
+	releaseLock                      *ReleaseLock // Lock of the release driven by this process
+	pendingReleases                  []pendingRelease // In-progress releases listed on the home screen, loaded by showHome
 	showAbortConfirm                 bool
 	abortConfirmIndex                int  // 0 = Yes, 1 = Cancel
 	showDeleteRemoteConfirm          bool // Second confirmation for deleting remote branch
 	deleteRemoteConfirmIndex         int  // 0 = Yes, 1 = No
+	showTagMoveConfirm               bool // Confirmation of moving a release tag that exists on origin
+	tagMoveConfirmIndex              int  // 0 = Move, 1 = Cancel
 	releaseNeedEmptyLineAfterCommand bool // Flag to add empty line after command output if needed
 
+	// Conflict resolution screen
+	conflictFiles            []string        // Unmerged paths when the screen was opened
+	conflictUnmerged         map[string]bool // Paths that are still unmerged
+	conflictIndex            int
+	conflictViewport         viewport.Model
+	showConflictDropConfirm  bool
+	conflictDropConfirmIndex int // 0 = Drop, 1 = Cancel
+
+	// Merge queue modal (skip/reorder pending MRs of a suspended release)
+	showMRQueueModal   bool
+	mrQueueIndex       int
+	mrQueueReasonMode  bool // Entering the skip reason for the selected MR
+	mrQueueReasonInput textinput.Model
+
+	// MR drift prompt (pending MR branches moved on origin since selection, asked on retry)
+	mrDriftChecking   bool
+	showMRDriftPrompt bool
+	mrDrifts          []MRDrift // Drifts still to decide, the first one is shown
+	mrDriftOption     int       // 0 = Keep pinned, 1 = Take new head, 2 = Drop MR
+
 	// Pipeline observer
 	pipelineObserving    bool
 	pipelineStatus       *PipelineStatus
@@ -129,18 +180,25 @@ type model struct {
 	historyList                list.Model
 	historyEntries             []HistoryIndexEntry
 	historySelected            *ReleaseHistoryEntry
-	historyDetailTab           int // 0=MRs, 1=Meta, 2=Logs
+	historyDetailTab           int // 0=MRs, 1=Meta, 2=Logs, 3=Notes
 	historyLogsViewport        viewport.Model
+	historyNotesViewport       viewport.Model
+	historyNotesStatus         string // Result of the last CHANGELOG.md export
 	historyMRViewport          viewport.Model
 	historyMRIndex             int                              // Selected MR in detail MRs tab
 	historyMRDetailsMap        map[int]*MergeRequestDetails     // All fetched MR details by index
 	loadingHistory             bool
 	loadingHistoryMRs          bool                             // Loading state for all MRs fetch
 	historyMRsLoadError        bool                             // True if MRs failed to load
+	promotionChecking          bool                             // Verifying the source branch of the release to promote
+	promotion                  *ReleaseHistoryEntry             // Release being promoted to the next environment by the wizard
 	historySelectMode          bool                             // Whether select mode is active
 	historySelectedIDs         map[string]bool                  // Selected history entry IDs for deletion
 	showHistoryDeleteConfirm   bool                             // Show delete confirmation modal
 	historyDeleteConfirmIndex  int                              // 0=Delete, 1=Cancel
+	showRollbackConfirm        bool                             // Show rollback confirmation modal
+	rollbackConfirmIndex       int                              // 0=Roll back, 1=Cancel
+	rollbackPlan               *ReleaseState                    // Rollback awaiting confirmation
 
 	// Open options modal (for "open" actions)
 	showOpenOptionsModal bool
@@ -201,29 +259,6 @@ func NewModel() model {
 	pipelineRegexInput.TextStyle = lipgloss.NewStyle().Foreground(currentTheme.Foreground)
 	pipelineRegexInput.Cursor.Style = lipgloss.NewStyle().Foreground(currentTheme.Accent)
 
-	// Initialize settings environment name and branch inputs
-	var envNames [4]textinput.Model
-	var envBranches [4]textinput.Model
-	for i := 0; i < 4; i++ {
-		nameInput := textinput.New()
-		nameInput.CharLimit = 20
-		nameInput.Width = 10
-		nameInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(currentTheme.Notion)
-		nameInput.PromptStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
-		nameInput.TextStyle = lipgloss.NewStyle().Foreground(currentTheme.Foreground)
-		nameInput.Cursor.Style = lipgloss.NewStyle().Foreground(currentTheme.Accent)
-		envNames[i] = nameInput
-
-		branchInput := textinput.New()
-		branchInput.CharLimit = 50
-		branchInput.Width = 20
-		branchInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(currentTheme.Notion)
-		branchInput.PromptStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
-		branchInput.TextStyle = lipgloss.NewStyle().Foreground(currentTheme.Foreground)
-		branchInput.Cursor.Style = lipgloss.NewStyle().Foreground(currentTheme.Accent)
-		envBranches[i] = branchInput
-	}
-
 	return model{
 		screen:                  screenLoading,
 		inputs:                  initAuthInputs(),
@@ -231,11 +266,13 @@ func NewModel() model {
 		spinner:                 s,
 		loading:                 true, // Initial loading state
 		settingsBaseBranch:      baseBranchInput,
-		settingsEnvNames:        envNames,
-		settingsEnvBranches:     envBranches,
 		settingsExcludePatterns: ta,
 		settingsPipelineRegex:   pipelineRegexInput,
 		environments:            getEnvironments(),
+		trackerRules:            getTrackerRules(),
+		trackerIssues:           make(map[string]*TrackerIssueResult),
+		mrDetailsLoading:        make(map[*MergeRequestDetails]bool),
+		gitLabRequests:          make(map[string]gitLabRequests),
 		selectedMRs:             make(map[int]bool),
 		historyMRDetailsMap:     make(map[int]*MergeRequestDetails),
 		envMergeOptionIndex:     0, // Default to squash
@@ -261,6 +298,7 @@ func (m *model) closeAllModals() {
 	m.showErrorModal = false
 	m.errorModalMsg = ""
 	m.showHistoryDeleteConfirm = false
+	m.showRollbackConfirm = false
 	m.closeOpenOptionsModal()
 }
 
@@ -271,6 +309,39 @@ func (m *model) closeOpenOptionsModal() {
 	m.openOptionsIndex = 0
 }
 
+// gitLabRequests is the context the GitLab requests of a purpose are cancelled with
+type gitLabRequests struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// gitLabClient returns a GitLab client for requests of the given purpose, cancelling the
+// requests previously started for it
+func (m *model) gitLabClient(purpose string) *GitLabClient {
+	m.cancelGitLabRequests(purpose)
+	return m.sharedGitLabClient(purpose)
+}
+
+// sharedGitLabClient returns a GitLab client for requests of the given purpose running
+// alongside the ones in flight, e.g. details of several MRs. All of them are cancelled
+// together by cancelGitLabRequests when the user leaves the screen.
+func (m *model) sharedGitLabClient(purpose string) *GitLabClient {
+	requests, ok := m.gitLabRequests[purpose]
+	if !ok {
+		requests.ctx, requests.cancel = context.WithCancel(context.Background())
+		m.gitLabRequests[purpose] = requests
+	}
+	return NewGitLabClient(m.creds.GitLabURL, m.creds.Token).WithContext(requests.ctx)
+}
+
+// cancelGitLabRequests cancels the in-flight GitLab requests of the given purpose
+func (m *model) cancelGitLabRequests(purpose string) {
+	if requests, ok := m.gitLabRequests[purpose]; ok {
+		requests.cancel()
+		delete(m.gitLabRequests, purpose)
+	}
+}
+
 // Update handles all messages
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -333,6 +404,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateEnvMerge(msg)
 		case screenRootMerge:
 			return m.updateRootMerge(msg)
+		case screenPreflight:
+			return m.updatePreflight(msg)
 		case screenConfirm:
 			return m.updateConfirm(msg)
 		case screenRelease:
@@ -343,6 +416,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateHistoryDetail(msg)
 		case screenSettings:
 			return m.updateSettings(msg)
+		case screenConflict:
+			return m.updateConflict(msg)
 		}
 
 	case tea.WindowSizeMsg:
@@ -370,29 +445,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.screen == screenSettings {
 			m.updateSettingsSize()
 		}
+		if m.screen == screenConflict {
+			m.initConflictScreen()
+		}
 
 	case checkCredsMsg:
 		m.loading = false
 		if msg.creds != nil {
 			m.creds = msg.creds
 
-			// Check for existing release state first
-			if releaseState, err := LoadReleaseState(); err == nil && releaseState != nil {
-				// Resume existing release
-				m.initListScreen()
-				m.updateListSize()
-				// Load project info for release
-				if config, err := LoadConfig(); err == nil && config.SelectedProjectID != 0 {
-					m.selectedProject = &Project{
-						ID:                config.SelectedProjectID,
-						Name:              config.SelectedProjectShortName,
-						PathWithNamespace: config.SelectedProjectPath,
-						NameWithNamespace: config.SelectedProjectName,
-					}
-				}
-				return m, m.resumeRelease(releaseState)
-			}
-
 			// Load saved project from config
 			if config, err := LoadConfig(); err == nil && config.SelectedProjectID != 0 {
 				m.selectedProject = &Project{
@@ -403,7 +464,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			m.screen = screenHome
+			m.showHome()
+
+			// Resume the only pending release right away, several are listed on the home screen
+			if len(m.pendingReleases) == 1 && m.pendingReleases[0].Holder == 0 {
+				return m.openPendingRelease(m.pendingReleases[0].State)
+			}
 		}
 		// No credentials - show auth screen
 		if msg.creds == nil {
@@ -411,7 +477,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case spinner.TickMsg:
-		if m.loading || m.loadingProjects || m.loadingMRs || m.loadingHistory || m.loadingHistoryMRs || m.releaseRunning || m.sourceBranchRemoteStatus == "checking" || m.envMergeCountLoading || (m.pipelineObserving && m.pipelineStatus != nil && m.pipelineStatus.Stage != PipelineStageCompleted && m.pipelineStatus.Stage != PipelineStageFailed) {
+		if m.loading || m.loadingProjects || m.loadingMRs || m.loadingHistory || m.loadingHistoryMRs || m.promotionChecking || m.preflightRunning || m.mrDriftChecking || m.releaseRunning || m.sourceBranchRemoteStatus == "checking" || m.envMergeCountLoading || m.confirmSimulating || m.confirmPreparingWorktree || (m.pipelineObserving && m.pipelineStatus != nil && m.pipelineStatus.Stage != PipelineStageCompleted && m.pipelineStatus.Stage != PipelineStageFailed) {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
@@ -442,16 +508,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			m.screen = screenHome
+			m.showHome()
 		}
 
 	case fetchProjectsMsg:
 		m.loadingProjects = false
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
 		m.projectsLoaded = true
 		if msg.err != nil {
 			m.closeAllModals()
 			m.showErrorModal = true
-			m.errorModalMsg = "Failed to fetch projects: " + msg.err.Error()
+			m.errorModalMsg = "Failed to fetch projects: " + gitLabErrorText(msg.err)
 		} else {
 			m.projects = msg.projects
 			m.projectSelectorIndex = 0
@@ -459,13 +528,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case fetchMRsMsg:
+		if errors.Is(msg.err, context.Canceled) {
+			// Superseded by a newer fetch
+			return m, nil
+		}
 		m.loadingMRs = false
 		m.mrsLoaded = true
 		if msg.err != nil {
 			m.mrsLoadError = true
 			m.closeAllModals()
 			m.showErrorModal = true
-			m.errorModalMsg = msg.err.Error()
+			m.errorModalMsg = gitLabErrorText(msg.err)
 			// Update viewport to show hint even on error
 			if m.ready {
 				m.viewport.SetContent(m.renderMarkdown())
@@ -494,12 +567,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.ready {
 				m.viewport.SetContent(m.renderMarkdown())
 			}
+			return m, tea.Batch(m.loadTrackerIssues(m.highlightedMRIssues()), m.loadHighlightedMRDetails())
+		}
+
+	case mrDetailsMsg:
+		m.applyMRDetails(msg)
+		if m.ready {
+			m.viewport.SetContent(m.renderMarkdown())
+		}
+
+	case trackerIssuesMsg:
+		for key, result := range msg.issues {
+			m.trackerIssues[key] = result
+		}
+		if m.ready {
+			m.viewport.SetContent(m.renderMarkdown())
+		}
+		if m.screen == screenConfirm {
+			m.confirmViewport.SetContent(m.renderConfirmMarkdown(m.confirmViewport.Width))
 		}
 
 	case existingReleaseMsg:
 		if msg.state != nil {
 			// Found existing release - resume it
-			return m, m.resumeRelease(msg.state)
+			return m.openPendingRelease(msg.state)
 		}
 
 	case releaseOutputMsg:
@@ -508,16 +599,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case releaseCommandStartMsg:
 		// Flush current virtual terminal screen to buffer before starting new command
-		if m.releaseCurrentScreen != "" {
-			lines := strings.Split(m.releaseCurrentScreen, "\n")
-			for _, line := range lines {
-				m.releaseOutputBuffer = append(m.releaseOutputBuffer, line)
-			}
-			if len(m.releaseOutputBuffer) > maxOutputLines {
-				m.releaseOutputBuffer = m.releaseOutputBuffer[len(m.releaseOutputBuffer)-maxOutputLines:]
-			}
-			m.releaseCurrentScreen = ""
-		}
+		m.flushReleaseScreen()
 		// Smart empty line before command: only add if last line isn't empty
 		if len(m.releaseOutputBuffer) > 0 {
 			lastLine := m.releaseOutputBuffer[len(m.releaseOutputBuffer)-1]
@@ -560,12 +642,49 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.program = msg.program
 		return m, nil
 
+	case releaseStepStartedMsg:
+		return m.handleReleaseStepStarted(msg)
+
 	case releaseSubStepDoneMsg:
 		if m.releaseState != nil {
 			m.releaseState.CompletedSubSteps++
 		}
 		return m, nil
 
+	case conflictEditorDoneMsg:
+		return m.handleConflictEditorDone(msg)
+
+	case releaseWorktreeMsg:
+		m.confirmPreparingWorktree = false
+		if msg.err != nil {
+			m.unlockRelease()
+			m.closeAllModals()
+			m.showErrorModal = true
+			m.errorModalMsg = "Failed to prepare release worktree: " + msg.err.Error()
+			return m, nil
+		}
+		// Rollbacks are started from the history list
+		if m.screen != screenConfirm && msg.state.Rollback == nil {
+			m.unlockRelease()
+			return m, nil
+		}
+		return m.launchRelease(msg.state)
+
+	case releaseSimulationMsg:
+		m.confirmSimulating = false
+		if msg.err != nil {
+			m.closeAllModals()
+			m.showErrorModal = true
+			m.errorModalMsg = "Simulation failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.confirmSimulation = msg.simulation
+		if m.screen == screenConfirm {
+			m.initConfirmViewport()
+			m.confirmViewport.GotoBottom()
+		}
+		return m, nil
+
 	case sourceBranchCheckMsg:
 		// Only update if this check is for the current branch name
 		if msg.branchName == m.sourceBranchCheckedName {
@@ -621,6 +740,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case promotionCheckedMsg:
+		return m.handlePromotionChecked(msg)
+
+	case preflightMsg:
+		return m.handlePreflight(msg)
+
+	case mrDriftMsg:
+		return m.handleMRDrift(msg)
+
 	case loadHistoryDetailMsg:
 		m.loadingHistory = false
 		if msg.err != nil {
@@ -631,6 +759,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Only navigate to detail if still on the history list screen
 			m.historySelected = msg.entry
 			m.historyDetailTab = 0
+			m.historyNotesStatus = ""
 			m.historyMRIndex = 0
 			m.historyMRDetailsMap = make(map[int]*MergeRequestDetails)
 			m.historyMRsLoadError = false
@@ -642,11 +771,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case fetchAllHistoryMRsMsg:
 		m.loadingHistoryMRs = false
+		if errors.Is(msg.err, context.Canceled) {
+			// The history entry was left or reloaded
+			return m, nil
+		}
 		if msg.err != nil {
 			m.historyMRsLoadError = true
 			m.closeAllModals()
 			m.showErrorModal = true
-			m.errorModalMsg = msg.err.Error()
+			m.errorModalMsg = gitLabErrorText(msg.err)
 			m.historyMRDetailsMap = make(map[int]*MergeRequestDetails)
 		} else {
 			m.historyMRsLoadError = false
@@ -673,16 +806,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		m.settingsBaseBranch, cmd = m.settingsBaseBranch.Update(msg)
 		cmds = append(cmds, cmd)
-		for i := 0; i < 4; i++ {
-			m.settingsEnvNames[i], cmd = m.settingsEnvNames[i].Update(msg)
-			cmds = append(cmds, cmd)
-			m.settingsEnvBranches[i], cmd = m.settingsEnvBranches[i].Update(msg)
-			cmds = append(cmds, cmd)
+		for i := range m.settingsEnvs {
+			for column := 0; column < settingsEnvColumns; column++ {
+				input := m.settingsEnvs[i].input(column)
+				*input, cmd = input.Update(msg)
+				cmds = append(cmds, cmd)
+			}
 		}
 		m.settingsExcludePatterns, cmd = m.settingsExcludePatterns.Update(msg)
 		cmds = append(cmds, cmd)
 		m.settingsPipelineRegex, cmd = m.settingsPipelineRegex.Update(msg)
 		cmds = append(cmds, cmd)
+		for i := range m.settingsNaming {
+			m.settingsNaming[i], cmd = m.settingsNaming[i].Update(msg)
+			cmds = append(cmds, cmd)
+		}
 		m.refreshSettingsViewport()
 	}
 
@@ -700,6 +838,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
+	// Update skip reason input for non-KeyMsg messages (like cursor blink)
+	if m.showMRQueueModal && m.mrQueueReasonMode {
+		var cmd tea.Cmd
+		m.mrQueueReasonInput, cmd = m.mrQueueReasonInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -727,6 +872,8 @@ func (m model) View() string {
 		view = m.viewEnvMerge()
 	case screenRootMerge:
 		view = m.viewRootMerge()
+	case screenPreflight:
+		view = m.viewPreflight()
 	case screenConfirm:
 		view = m.viewConfirm()
 	case screenRelease:
@@ -737,6 +884,8 @@ func (m model) View() string {
 		view = m.viewHistoryDetail()
 	case screenSettings:
 		view = m.viewSettings()
+	case screenConflict:
+		view = m.viewConflict()
 	}
 
 	// Overlay loading modal if loading MRs or history
@@ -764,6 +913,11 @@ func (m model) View() string {
 		view = m.overlayHistoryDeleteConfirm(view)
 	}
 
+	// Overlay rollback confirmation if open
+	if m.showRollbackConfirm {
+		view = m.overlayRollbackConfirm(view)
+	}
+
 	// Overlay open options modal if open
 	if m.showOpenOptionsModal {
 		view = m.overlayOpenOptionsModal(view)
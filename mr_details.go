@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadHighlightedMRDetails returns a command fetching the details of the MR under the list
+// cursor if it was listed without them (lazy mode) and is not being fetched yet
+func (m *model) loadHighlightedMRDetails() tea.Cmd {
+	item, ok := m.list.SelectedItem().(mrListItem)
+	if !ok || item.MR() == nil || m.creds == nil {
+		return nil
+	}
+	mr := item.MR()
+	if mr.DetailsLoaded || m.mrDetailsLoading[mr] {
+		return nil
+	}
+	m.mrDetailsLoading[mr] = true
+
+	client := m.sharedGitLabClient("mr-details")
+	base := mr.MergeRequest
+	return func() tea.Msg {
+		details, err := client.GetMergeRequestDetails(base)
+		return mrDetailsMsg{mr: mr, details: details, err: err}
+	}
+}
+
+// applyMRDetails fills a lazily listed MR with its fetched details. On failure the MR
+// stays without details and is fetched again when focused next time.
+func (m *model) applyMRDetails(msg mrDetailsMsg) {
+	delete(m.mrDetailsLoading, msg.mr)
+	if msg.err != nil || msg.details == nil {
+		return
+	}
+	*msg.mr = *msg.details
+}
+
+// mrDetailUnavailable marks a part of the MR details that could not be fetched
+const mrDetailUnavailable = "unavailable"
+
+// mrInfoMarkdown returns the milestone, labels and the stats table of an MR shown above its
+// description. Parts not fetched yet (lazy mode) show "…", failed ones "unavailable".
+func mrInfoMarkdown(details *MergeRequestDetails) string {
+	discussions := fmt.Sprintf("%d/%d", details.DiscussionsResolved, details.DiscussionsTotal)
+	commits := fmt.Sprintf("%d", details.CommitsCount)
+	changes := details.ChangesCount
+	if changes == "" {
+		changes = "0"
+	}
+	approvals := "-"
+	if a := details.Approvals; a != nil {
+		approvals = fmt.Sprintf("%d", len(a.ApprovedBy))
+		if a.ApprovalsRequired > 0 {
+			approvals += fmt.Sprintf("/%d", a.ApprovalsRequired)
+		}
+		if a.Approved {
+			approvals += " ✓"
+		}
+	}
+	pipeline := "-"
+	if details.HeadPipeline != nil {
+		pipeline = details.HeadPipeline.Status
+	}
+
+	switch {
+	case !details.DetailsLoaded:
+		// Lazy mode: details are being fetched
+		discussions, commits, changes, approvals, pipeline = "…", "…", "…", "…", "…"
+	default:
+		if details.DiscussionsErr != nil {
+			discussions = mrDetailUnavailable
+		}
+		if details.CommitsErr != nil {
+			commits = mrDetailUnavailable
+		}
+		if details.ChangesErr != nil {
+			changes, pipeline = mrDetailUnavailable, mrDetailUnavailable
+		}
+		if details.ApprovalsErr != nil {
+			approvals = mrDetailUnavailable
+		}
+	}
+
+	var b strings.Builder
+	if details.Milestone != nil {
+		fmt.Fprintf(&b, "Milestone: **%s**", details.Milestone.Title)
+		if details.Milestone.DueDate != "" {
+			fmt.Fprintf(&b, " (due %s)", details.Milestone.DueDate)
+		}
+		b.WriteString("\n")
+	}
+	if len(details.Labels) > 0 {
+		fmt.Fprintf(&b, "Labels: `%s`\n", strings.Join(details.Labels, "` `"))
+	}
+	fmt.Fprintf(&b, `
+ | Overview | Commits | Changes | Approvals | Pipeline |
+ |:--------:|:-------:|:-------:|:---------:|:--------:|
+ | %s | %s | %s | %s | %s |
+`, discussions, commits, changes, approvals, pipeline)
+	return b.String()
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MR drift prompt options
+const (
+	mrDriftKeep = iota
+	mrDriftTake
+	mrDriftDrop
+)
+
+// mrDriftMaxCommits is the number of new commits listed in the drift prompt
+const mrDriftMaxCommits = 8
+
+// checkMRDrift compares the pinned commits of the pending MRs with their branches on origin
+// before the retry
+func (m model) checkMRDrift(pinned []MRDrift) (tea.Model, tea.Cmd) {
+	m.mrDriftChecking = true
+	m.appendReleaseOutput("")
+	m.appendReleaseOutput(fmt.Sprintf("Checking %d pending MR branches on origin...", len(pinned)))
+
+	workDir := m.releaseState.WorkDir
+	return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+		drifts, err := detectMRDrift(workDir, pinned)
+		return mrDriftMsg{drifts: drifts, err: err}
+	})
+}
+
+// handleMRDrift asks what to do with the MRs whose branches moved, or retries right away.
+// A failed check doesn't hold the retry back: the pinned commits are merged as selected.
+func (m model) handleMRDrift(msg mrDriftMsg) (tea.Model, tea.Cmd) {
+	m.mrDriftChecking = false
+	if m.screen != screenRelease || m.releaseState == nil || m.releaseState.LastError == nil {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.appendReleaseOutput(releaseOrangeStyle.Render(msg.err.Error() + ", merging the selected commits"))
+		return m.retryReleaseStep()
+	}
+	if len(msg.drifts) == 0 {
+		return m.retryReleaseStep()
+	}
+	m.mrDrifts = msg.drifts
+	m.mrDriftOption = mrDriftKeep
+	m.showMRDriftPrompt = true
+	return m, nil
+}
+
+// updateMRDriftPrompt handles key events in the MR drift prompt
+func (m model) updateMRDriftPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		return m.resolveMRDrift(m.mrDriftOption)
+	case "k":
+		return m.resolveMRDrift(mrDriftKeep)
+	case "t":
+		if m.mrDrifts[0].Head != "" {
+			return m.resolveMRDrift(mrDriftTake)
+		}
+	case "d":
+		return m.resolveMRDrift(mrDriftDrop)
+	case "esc":
+		m.showMRDriftPrompt = false
+		m.mrDrifts = nil
+		m.appendReleaseOutput("Retry cancelled")
+	case "tab", "right", "l":
+		m.mrDriftOption = m.nextMRDriftOption(1)
+	case "shift+tab", "left", "h":
+		m.mrDriftOption = m.nextMRDriftOption(-1)
+	}
+	return m, nil
+}
+
+// nextMRDriftOption returns the option next to the selected one in direction delta.
+// Taking the new head is not offered for a deleted branch.
+func (m model) nextMRDriftOption(delta int) int {
+	option := (m.mrDriftOption + delta + 3) % 3
+	if option == mrDriftTake && m.mrDrifts[0].Head == "" {
+		option = (option + delta + 3) % 3
+	}
+	return option
+}
+
+// resolveMRDrift applies the option to the shown drift and, once every drift is decided,
+// retries the release
+func (m model) resolveMRDrift(option int) (tea.Model, tea.Cmd) {
+	state := m.releaseState
+	drift := m.mrDrifts[0]
+
+	engine, executor := m.mrQueueEngine()
+	var err error
+	switch option {
+	case mrDriftKeep:
+		m.appendReleaseOutput(fmt.Sprintf("MR %s stays at selected %s", drift.Branch, shortSHA(drift.Pinned)))
+	case mrDriftTake:
+		err = engine.RepinMR(drift.Index, drift.Head)
+		m.appendReleaseOutput(fmt.Sprintf("MR %s moved from %s to %s on origin", drift.Branch, shortSHA(drift.Pinned), shortSHA(drift.Head)))
+	case mrDriftDrop:
+		err = engine.SkipMR(drift.Index, mrDriftReason)
+		m.appendReleaseOutput(releaseOrangeStyle.Render(fmt.Sprintf("MR %s skipped: %s", drift.Branch, mrDriftReason)))
+	}
+	executor.Close()
+	if err != nil {
+		m.showMRDriftPrompt = false
+		m.mrDrifts = nil
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+		return m, nil
+	}
+	SaveReleaseState(state)
+
+	m.mrDrifts = m.mrDrifts[1:]
+	if option == mrDriftDrop {
+		// The following MRs moved up the merge queue
+		for i := range m.mrDrifts {
+			m.mrDrifts[i].Index--
+		}
+	}
+	m.mrDriftOption = mrDriftKeep
+	if len(m.mrDrifts) > 0 {
+		return m, nil
+	}
+
+	m.showMRDriftPrompt = false
+	m.updateReleaseButtons()
+	// Dropping the suspended MR clears the error - continue the release
+	if state.LastError == nil {
+		m.releaseRunning = true
+		return m, tea.Batch(m.spinner.Tick, m.executeReleaseStep(state.CurrentStep))
+	}
+	return m.retryReleaseStep()
+}
+
+// overlayMRDriftPrompt renders the MR drift prompt
+func (m model) overlayMRDriftPrompt(background string) string {
+	if len(m.mrDrifts) == 0 {
+		return background
+	}
+	drift := m.mrDrifts[0]
+
+	var sb strings.Builder
+	sb.WriteString(errorTitleStyle.Render("MR Branch Moved"))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("%s moved on origin after the MR\nwas selected for the release.\n\n", releaseOrangeStyle.Render(drift.Branch)))
+	sb.WriteString(fmt.Sprintf("  selected: %s\n", drift.Pinned))
+	if drift.Head == "" {
+		sb.WriteString("  origin:   branch deleted\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("  origin:   %s\n\n", drift.Head))
+	}
+
+	if drift.Rewritten && drift.Head != "" {
+		sb.WriteString("History was rewritten: the selected commit\nis not in the new head.\n\n")
+	}
+	if len(drift.Commits) > 0 {
+		sb.WriteString(fmt.Sprintf("%d new commits:\n", len(drift.Commits)))
+		for i, commit := range drift.Commits {
+			if i == mrDriftMaxCommits {
+				sb.WriteString(fmt.Sprintf("  ... and %d more\n", len(drift.Commits)-mrDriftMaxCommits))
+				break
+			}
+			sb.WriteString("  " + truncateWithEllipsis(commit, 52) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	if len(m.mrDrifts) > 1 {
+		sb.WriteString(fmt.Sprintf("%d more moved MRs follow.\n\n", len(m.mrDrifts)-1))
+	}
+
+	labels := []string{"Keep selected", "Take new head", "Drop MR"}
+	var buttons []string
+	for option, label := range labels {
+		switch {
+		case option == mrDriftTake && drift.Head == "":
+			continue
+		case option == m.mrDriftOption && option == mrDriftDrop:
+			buttons = append(buttons, buttonDangerStyle.Render(label))
+		case option == m.mrDriftOption:
+			buttons = append(buttons, buttonActiveStyle.Render(label))
+		default:
+			buttons = append(buttons, buttonStyle.Render(label))
+		}
+	}
+	sb.WriteString("  " + strings.Join(buttons, "  "))
+
+	config := ModalConfig{
+		Width:    ModalWidth{Value: 60, Percent: false},
+		MinWidth: 50,
+		MaxWidth: 70,
+		Style:    errorBoxStyle,
+	}
+
+	modal := renderModal(sb.String(), config, m.width)
+	return placeOverlayCenter(modal, background, m.width, m.height)
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultSkipReason is recorded when the user skips an MR without typing a reason
+const defaultSkipReason = "skipped manually"
+
+// mrQueueEngine returns an engine over the release state using a silent git executor.
+// Queue edits run synchronously in Update, so the executor must not send to the program.
+func (m model) mrQueueEngine() (*ReleaseEngine, *GitExecutor) {
+	executor := NewGitExecutor(m.releaseState.WorkDir, nil)
+	return NewReleaseEngine(m.releaseState, executor, nil), executor
+}
+
+// canEditMRQueue reports whether the merge queue of the suspended release can be edited
+func (m model) canEditMRQueue() bool {
+	if m.releaseState == nil || m.releaseState.LastError == nil || m.releaseRunning {
+		return false
+	}
+	return NewReleaseEngine(m.releaseState, nil, nil).MRQueueEditable()
+}
+
+// openMRQueueModal shows the merge queue modal with the first pending MR selected
+func (m model) openMRQueueModal() (tea.Model, tea.Cmd) {
+	if !m.canEditMRQueue() {
+		return m, nil
+	}
+	m.showMRQueueModal = true
+	m.mrQueueIndex = m.releaseState.CurrentMRIndex
+	m.mrQueueReasonMode = false
+	return m, nil
+}
+
+// closeMRQueueModal hides the merge queue modal
+func (m *model) closeMRQueueModal() {
+	m.showMRQueueModal = false
+	m.mrQueueReasonMode = false
+	m.mrQueueReasonInput.Blur()
+}
+
+// updateMRQueueModal handles key events in the merge queue modal
+func (m model) updateMRQueueModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	state := m.releaseState
+
+	// Reason input for the MR being skipped
+	if m.mrQueueReasonMode {
+		switch msg.String() {
+		case "esc":
+			m.mrQueueReasonMode = false
+			m.mrQueueReasonInput.Blur()
+			return m, nil
+		case "enter":
+			reason := strings.TrimSpace(m.mrQueueReasonInput.Value())
+			if reason == "" {
+				reason = defaultSkipReason
+			}
+			m.mrQueueReasonMode = false
+			m.mrQueueReasonInput.Blur()
+			return m.skipReleaseMR(m.mrQueueIndex, reason)
+		}
+		var cmd tea.Cmd
+		m.mrQueueReasonInput, cmd = m.mrQueueReasonInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+q":
+		m.closeMRQueueModal()
+		return m, nil
+
+	case "up", "k":
+		if m.mrQueueIndex > state.CurrentMRIndex {
+			m.mrQueueIndex--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.mrQueueIndex < len(state.MRBranches)-1 {
+			m.mrQueueIndex++
+		}
+		return m, nil
+
+	case "K", "J":
+		delta := 1
+		if msg.String() == "K" {
+			delta = -1
+		}
+		engine, executor := m.mrQueueEngine()
+		newIndex, err := engine.MoveMR(m.mrQueueIndex, delta)
+		executor.Close()
+		if err != nil {
+			return m, nil
+		}
+		m.mrQueueIndex = newIndex
+		SaveReleaseState(state)
+		return m, nil
+
+	case "x", "d":
+		m.mrQueueReasonInput = textinput.New()
+		m.mrQueueReasonInput.Placeholder = defaultSkipReason
+		m.mrQueueReasonInput.CharLimit = 200
+		m.mrQueueReasonInput.Width = 40
+		m.mrQueueReasonInput.PlaceholderStyle = lipgloss.NewStyle().Foreground(currentTheme.Notion)
+		m.mrQueueReasonInput.PromptStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+		m.mrQueueReasonInput.TextStyle = lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+		m.mrQueueReasonInput.Cursor.Style = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+		m.mrQueueReasonMode = true
+		return m, m.mrQueueReasonInput.Focus()
+	}
+
+	return m, nil
+}
+
+// skipReleaseMR removes the MR at index from the merge queue and records the reason.
+// If the suspended MR itself was skipped, the release continues with the next one.
+func (m model) skipReleaseMR(index int, reason string) (tea.Model, tea.Cmd) {
+	state := m.releaseState
+	if index < 0 || index >= len(state.MRBranches) {
+		return m, nil
+	}
+	branch := state.MRBranches[index]
+
+	engine, executor := m.mrQueueEngine()
+	err := engine.SkipMR(index, reason)
+	executor.Close()
+	if err != nil {
+		m.closeMRQueueModal()
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+		return m, nil
+	}
+
+	SaveReleaseState(state)
+	m.releaseOutputBuffer = append(m.releaseOutputBuffer,
+		releaseOrangeStyle.Render(fmt.Sprintf("MR %s skipped: %s", branch, reason)), "")
+	m.updateReleaseViewport()
+
+	// Skipping the suspended MR clears the error - continue the release
+	if state.LastError == nil {
+		m.closeMRQueueModal()
+		m.updateReleaseButtons()
+		m.releaseRunning = true
+		return m, tea.Batch(m.spinner.Tick, m.executeReleaseStep(state.CurrentStep))
+	}
+
+	if !m.canEditMRQueue() {
+		m.closeMRQueueModal()
+	} else if m.mrQueueIndex >= len(state.MRBranches) {
+		m.mrQueueIndex = len(state.MRBranches) - 1
+	}
+	m.updateReleaseButtons()
+	return m, nil
+}
+
+// overlayMRQueueModal renders the merge queue modal
+func (m model) overlayMRQueueModal(background string) string {
+	state := m.releaseState
+	var sb strings.Builder
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(currentTheme.Accent).Render("Merge queue")
+	sb.WriteString(title)
+	sb.WriteString("\n\n")
+
+	notionStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
+	conflict := state.CurrentStep == ReleaseStepMergeBranches && DetectMergeConflict(state.WorkDir)
+
+	for i, branch := range state.MRBranches {
+		label := branch
+		if i == state.CurrentMRIndex && conflict {
+			label += " (conflict)"
+		}
+
+		var line string
+		switch {
+		case i < state.CurrentMRIndex:
+			line = notionStyle.Render("✓ " + label)
+		case i == m.mrQueueIndex:
+			line = commandItemSelectedStyle.Render("▸ " + label)
+		default:
+			line = commandItemStyle.Render("  " + label)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	if len(state.SkippedMRs) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(notionStyle.Render("Skipped:"))
+		sb.WriteString("\n")
+		for _, skipped := range state.SkippedMRs {
+			sb.WriteString(notionStyle.Render(fmt.Sprintf("– %s: %s", skipped.Branch, skipped.Reason)))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	if m.mrQueueReasonMode {
+		sb.WriteString(lipgloss.NewStyle().Foreground(currentTheme.Foreground).Render("Reason to skip " + state.MRBranches[m.mrQueueIndex] + ":"))
+		sb.WriteString("\n")
+		sb.WriteString(m.mrQueueReasonInput.View())
+		sb.WriteString("\n\n")
+		sb.WriteString(helpStyle.Render("enter: skip MR • esc: cancel"))
+	} else {
+		sb.WriteString(helpStyle.Render("j/k: nav • K/J: move up/down • x: skip • C+q: close"))
+	}
+
+	config := ModalConfig{
+		Width:    ModalWidth{Value: 60, Percent: false},
+		MinWidth: 40,
+		MaxWidth: 80,
+		Style:    commandMenuStyle,
+	}
+
+	modalContent := renderModal(sb.String(), config, m.width)
+	return placeOverlayCenter(modalContent, background, m.width, m.height)
+}
@@ -283,20 +283,26 @@ func (m *model) initListScreen() {
 
 // fetchMRs creates a command to fetch MRs from GitLab
 func (m *model) fetchMRs() tea.Cmd {
-	return func() tea.Msg {
-		if m.creds == nil {
+	if m.creds == nil {
+		return func() tea.Msg {
 			return fetchMRsMsg{err: fmt.Errorf("no credentials")}
 		}
+	}
 
-		client := NewGitLabClient(m.creds.GitLabURL, m.creds.Token)
+	// A refresh or project switch cancels the previous fetch
+	client := m.gitLabClient("mrs")
+	project := m.selectedProject
+	return func() tea.Msg {
+		config, _ := LoadConfig()
+		lazy := config != nil && config.LazyMRDetails
 
 		var mrs []*MergeRequestDetails
 		var err error
 
-		if m.selectedProject != nil {
-			mrs, err = client.GetProjectMergeRequests(m.selectedProject.ID)
+		if project != nil {
+			mrs, err = client.GetProjectMergeRequests(project.ID, lazy)
 		} else {
-			mrs, err = client.GetOpenMergeRequests()
+			mrs, err = client.GetOpenMergeRequests(lazy)
 		}
 
 		return fetchMRsMsg{mrs: mrs, err: err}
@@ -354,7 +360,7 @@ func (m model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		selected := m.list.SelectedItem()
 		if selected != nil {
 			if mr, ok := selected.(mrListItem); ok && mr.MR() != nil {
-				return m.handleOpenAction(buildMROpenOptions(mr.MR()))
+				return m.handleOpenAction(buildMROpenOptions(mr.MR(), m.trackerRules))
 			}
 		}
 		return m, nil
@@ -365,8 +371,9 @@ func (m model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.loadingProjects = true
 			return m, tea.Batch(m.spinner.Tick, m.fetchProjects())
 		}
-		// Refresh MRs with loading modal
+		// Refresh MRs with loading modal, refetching linked issues too
 		m.loadingMRs = true
+		m.trackerIssues = make(map[string]*TrackerIssueResult)
 		return m, tea.Batch(m.spinner.Tick, m.fetchMRs())
 	case " ":
 		// Toggle selection for currently focused MR (only for non-drafts)
@@ -388,7 +395,8 @@ func (m model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "ctrl+q":
 		// Go back to home screen
-		m.screen = screenHome
+		m.cancelGitLabRequests("mr-details")
+		m.showHome()
 		return m, nil
 	}
 
@@ -397,7 +405,8 @@ func (m model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	m.list, cmd = m.list.Update(msg)
 	cmds = append(cmds, cmd)
 
-	// Update content when selection changes
+	// Update content and fetch linked issues (and lazy MR details) when selection changes
+	cmds = append(cmds, m.loadTrackerIssues(m.highlightedMRIssues()), m.loadHighlightedMRDetails())
 	if m.ready {
 		m.viewport.SetContent(m.renderMarkdown())
 	}
@@ -441,18 +450,10 @@ func (m model) renderMarkdown() string {
 	// Clean up author name (replace multiple spaces with single space)
 	authorName := strings.Join(strings.Fields(details.Author.Name), " ")
 
-	// Build info table row
-	discussionInfo := fmt.Sprintf(
-		"%d/%d",
-		details.DiscussionsResolved,
-		details.DiscussionsTotal)
-	if details.DiscussionsTotal == 0 {
-		discussionInfo = "0/0"
-	}
-
-	changesCount := details.ChangesCount
-	if changesCount == "" {
-		changesCount = "0"
+	// Issue tracker links of keys in the branch name and title with their fetched details
+	issuesLine := ""
+	if issues := m.highlightedMRIssues(); len(issues) > 0 {
+		issuesLine = "\nIssues:\n" + m.trackerIssuesListMarkdown(issues, m.selectedEnvConfig())
 	}
 
 	// Build markdown content
@@ -460,11 +461,8 @@ func (m model) renderMarkdown() string {
 
 ### %s (@%s)
 **%s** -> %s (at %s)
- 
- | Overview | Commits | Changes |
- |:--------:|:-------:|:-------:|
- | %s | %d | %s |
- 
+%s 
+%s 
  %s
  `,
 		details.Title,
@@ -473,9 +471,8 @@ func (m model) renderMarkdown() string {
 		details.SourceBranch,
 		details.TargetBranch,
 		details.CreatedAt.Format("02.01.2006 15:04"),
-		discussionInfo,
-		details.CommitsCount,
-		changesCount,
+		issuesLine,
+		mrInfoMarkdown(details),
 		details.Description,
 	)
 
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,64 +14,53 @@ type OpenOption struct {
 	URL   string
 }
 
-// jiraTaskRegex extracts task numbers from branch names
-var jiraTaskRegex = regexp.MustCompile(`RUSSPASS-(\d+)`)
-
-// extractJiraTaskNumber extracts task number from branch name (e.g. "RUSSPASS-1234")
-func extractJiraTaskNumber(branchName string) string {
-	if matches := jiraTaskRegex.FindStringSubmatch(branchName); len(matches) > 1 {
-		return matches[1]
+// trackerOpenOptions builds options for the tracker issues found in the texts
+func trackerOpenOptions(rules []TrackerRule, texts ...string) []OpenOption {
+	var options []OpenOption
+	for _, issue := range findTrackerIssues(rules, texts...) {
+		options = append(options, OpenOption{
+			Label: fmt.Sprintf("%s (%s)", issue.Tracker, issue.Key),
+			URL:   issue.URL,
+		})
 	}
-	return ""
-}
-
-// buildJiraURL builds Jira URL from task number
-func buildJiraURL(taskNumber string) string {
-	return fmt.Sprintf("https://itpm.mos.ru/browse/RUSSPASS-%s", taskNumber)
+	return options
 }
 
 // buildMROpenOptions builds options for MRs list context
-func buildMROpenOptions(mr *MergeRequestDetails) []OpenOption {
+func buildMROpenOptions(mr *MergeRequestDetails, rules []TrackerRule) []OpenOption {
 	options := []OpenOption{
 		{Label: "GitLab MR", URL: mr.WebURL},
 	}
 
-	if taskNum := extractJiraTaskNumber(mr.SourceBranch); taskNum != "" {
-		options = append(options, OpenOption{
-			Label: fmt.Sprintf("Jira Task (RUSSPASS-%s)", taskNum),
-			URL:   buildJiraURL(taskNum),
-		})
-	}
+	// Tracker issues from branch name and title
+	options = append(options, trackerOpenOptions(rules, mr.SourceBranch, mr.Title)...)
 
 	return options
 }
 
 // buildHistoryOpenOptions builds options for history detail context
-// tab: 0=MRs, 1=Meta, 2=Logs
-func buildHistoryOpenOptions(entry *ReleaseHistoryEntry, mrIndex int, tab int) []OpenOption {
+// tab: 0=MRs, 1=Meta, 2=Logs, 3=Notes
+func buildHistoryOpenOptions(entry *ReleaseHistoryEntry, mrIndex int, tab int, rules []TrackerRule) []OpenOption {
 	options := []OpenOption{}
 
 	if tab == 0 {
-		// MRs tab: show selected individual MR and Jira task
+		// MRs tab: show selected individual MR and its tracker issues
 		if mrIndex >= 0 && mrIndex < len(entry.MRURLs) && entry.MRURLs[mrIndex] != "" {
 			options = append(options, OpenOption{Label: "GitLab MR", URL: entry.MRURLs[mrIndex]})
 		}
 
-		// Add Jira task URL (if available)
+		// Add tracker issue URLs (if available)
 		if mrIndex >= 0 && mrIndex < len(entry.MRBranches) {
-			branchName := entry.MRBranches[mrIndex]
-			if taskNum := extractJiraTaskNumber(branchName); taskNum != "" {
-				options = append(options, OpenOption{
-					Label: fmt.Sprintf("Jira Task (RUSSPASS-%s)", taskNum),
-					URL:   buildJiraURL(taskNum),
-				})
-			}
+			options = append(options, trackerOpenOptions(rules, entry.MRBranches[mrIndex])...)
 		}
 	} else {
 		// Meta/Logs tabs: show environment release MR
 		if entry.CreatedMRURL != "" {
 			options = append(options, OpenOption{Label: "GitLab MR", URL: entry.CreatedMRURL})
 		}
+		if entry.GitLabReleaseURL != "" {
+			options = append(options, OpenOption{Label: "GitLab release", URL: entry.GitLabReleaseURL})
+		}
 	}
 
 	return options
@@ -90,6 +78,10 @@ func buildReleaseOpenOptions(state *ReleaseState, pipelineStatus *PipelineStatus
 		options = append(options, OpenOption{Label: "Pipeline", URL: pipelineStatus.PipelineWebURL})
 	}
 
+	if releaseURL := state.gitLabReleaseURL(); releaseURL != "" {
+		options = append(options, OpenOption{Label: "GitLab release", URL: releaseURL})
+	}
+
 	return options
 }
 
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+)
+
+// maxPendingReleases is the number of pending releases listed on the home screen (keys 1-9)
+const maxPendingReleases = 9
+
+// pendingRelease is an in-progress release listed on the home screen
+type pendingRelease struct {
+	State  *ReleaseState
+	Holder int // PID of another relix process driving the release, 0 if none
+}
+
+// loadPendingReleases loads the in-progress releases for the home screen list
+func loadPendingReleases() []pendingRelease {
+	states, err := LoadReleaseStates()
+	if err != nil {
+		return nil
+	}
+	if len(states) > maxPendingReleases {
+		states = states[:maxPendingReleases]
+	}
+	pending := make([]pendingRelease, len(states))
+	for i, state := range states {
+		pending[i] = pendingRelease{State: state, Holder: ReleaseLockHolder(state)}
+	}
+	return pending
+}
+
+// showHome switches to the home screen, reloading the pending releases it lists.
+// The list is kept on the model so rendering the screen never touches the state files.
+func (m *model) showHome() {
+	m.screen = screenHome
+	m.pendingReleases = loadPendingReleases()
+}
+
+// pendingReleaseStatus returns a short description of where a pending release stopped
+func pendingReleaseStatus(state *ReleaseState) string {
+	switch {
+	case state.LastError != nil:
+		return "suspended"
+	case state.CurrentStep == ReleaseStepWaitForMR:
+		return "waiting for MR"
+	case state.CurrentStep == ReleaseStepWaitForRootPush:
+		return "waiting for root push"
+	case state.CurrentStep == ReleaseStepComplete:
+		return "completed"
+	default:
+		return "interrupted"
+	}
+}
+
+// unlockRelease releases the lock of the release driven by this process and cancels its
+// GitLab requests
+func (m *model) unlockRelease() {
+	m.cancelGitLabRequests("release")
+	m.releaseLock.Release()
+	m.releaseLock = nil
+}
+
+// clearRelease removes the persisted state of the current release and unlocks it
+func (m *model) clearRelease() {
+	if m.releaseState != nil {
+		ClearReleaseState(m.releaseState)
+	}
+	m.unlockRelease()
+}
+
+// openPendingRelease locks a pending release and resumes it on the release screen
+func (m model) openPendingRelease(state *ReleaseState) (tea.Model, tea.Cmd) {
+	if !m.lockRelease(state) {
+		return m, nil
+	}
+
+	// Switch to the project of the release
+	if state.ProjectID != 0 && (m.selectedProject == nil || m.selectedProject.ID != state.ProjectID) {
+		project := &Project{
+			ID:                state.ProjectID,
+			Name:              state.ProjectName,
+			PathWithNamespace: state.ProjectPath,
+			NameWithNamespace: state.ProjectPath,
+		}
+		if config, err := LoadConfig(); err == nil && config.SelectedProjectID == state.ProjectID {
+			project.Name = config.SelectedProjectShortName
+			project.PathWithNamespace = config.SelectedProjectPath
+			project.NameWithNamespace = config.SelectedProjectName
+		}
+		m.selectedProject = project
+		m.mrsLoaded = false
+	}
+
+	m.initListScreen()
+	m.updateListSize()
+	return m, m.resumeRelease(state)
+}
+
+// leaveRelease returns to the home screen leaving the current release pending
+func (m model) leaveRelease() (tea.Model, tea.Cmd) {
+	if m.releaseState == nil || m.releaseRunning {
+		return m, nil
+	}
+	if m.releaseState.CurrentStep == ReleaseStepComplete {
+		return m.completeRelease()
+	}
+
+	m.stopPipelineObserver()
+	m.pipelineStatus = nil
+	m.pipelineFailNotified = false
+
+	// Save terminal output for resume
+	m.releaseState.TerminalOutput = append([]string{}, m.releaseOutputBuffer...)
+	SaveReleaseState(m.releaseState)
+	m.unlockRelease()
+
+	m.releaseState = nil
+	m.releaseOutputBuffer = nil
+	m.releaseCurrentScreen = ""
+	m.releaseButtonIndex = 0
+	m.releaseButtons = nil
+	m.showHome()
+	return m, nil
+}
+
+// renderPendingReleases renders the home screen list of in-progress releases
+func renderPendingReleases(pending []pendingRelease) string {
+	if len(pending) == 0 {
+		return ""
+	}
+
+	notionStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
+	lines := []string{notionStyle.Render("Pending releases")}
+	for i, release := range pending {
+		state := release.State
+		project := state.ProjectName
+		if project == "" {
+			project = fmt.Sprintf("project %d", state.ProjectID)
+		}
+		status := pendingReleaseStatus(state)
+		if release.Holder != 0 {
+			status = fmt.Sprintf("running in pid %d", release.Holder)
+		}
+		details := status
+		if !state.UpdatedAt.IsZero() {
+			details += ", " + humanize.Time(state.UpdatedAt)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			homeMenuKeyStyle.Render(fmt.Sprintf("[%d]", i+1)),
+			homeMenuItemStyle.Render(fmt.Sprintf("%s → %s %s", project, state.Environment.Name, state.Version)),
+			notionStyle.Render("("+details+")"),
+		))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// preflightCellWidth is the width of a check column of the pre-flight table
+const preflightCellWidth = 13
+
+// preflightColumnTitles are the pre-flight table headers in preflightChecks order
+var preflightColumnTitles = []string{"Approvals", "Pipeline", "Discussions", "Draft", "Head"}
+
+// needsPreflight reports whether the wizard checks the selected MRs before the confirmation:
+// promoted releases ship MRs that were already released
+func (m model) needsPreflight() bool {
+	return m.promotion == nil && len(m.selectedMRDetails()) > 0
+}
+
+// startPreflight opens the pre-flight screen and checks the selected MRs
+func (m model) startPreflight() (tea.Model, tea.Cmd) {
+	m.screen = screenPreflight
+	m.preflightRunning = true
+	m.preflightResults = nil
+	m.preflightRun++
+	if m.creds == nil || m.selectedProject == nil {
+		m.preflightRunning = false
+		return m, nil
+	}
+
+	client := m.gitLabClient("preflight")
+	projectID := m.selectedProject.ID
+	selected := m.selectedMRDetails()
+	run := m.preflightRun
+	return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+		return preflightMsg{run: run, results: runPreflight(client, projectID, selected)}
+	})
+}
+
+// handlePreflight stores the results of the latest pre-flight run
+func (m model) handlePreflight(msg preflightMsg) (tea.Model, tea.Cmd) {
+	if msg.run != m.preflightRun {
+		return m, nil
+	}
+	m.preflightRunning = false
+	m.preflightResults = msg.results
+	return m, nil
+}
+
+// updatePreflight handles key events on the pre-flight screen
+func (m model) updatePreflight(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+q":
+		// Results of the cancelled checks are dropped as stale
+		m.cancelGitLabRequests("preflight")
+		m.preflightRun++
+		m.preflightRunning = false
+		m.screen = screenRootMerge
+		if m.rootMergeSelection {
+			m.rootMergeButtonIndex = 0
+		} else {
+			m.rootMergeButtonIndex = 1
+		}
+		return m, nil
+	case "r":
+		if m.preflightRunning {
+			return m, nil
+		}
+		return m.startPreflight()
+	case "enter":
+		if m.preflightRunning || len(preflightBlockers(m.preflightResults, m.selectedEnvConfig())) > 0 {
+			return m, nil
+		}
+		return m.openConfirm()
+	}
+	return m, nil
+}
+
+// openConfirm opens the confirmation screen of the wizard
+func (m model) openConfirm() (tea.Model, tea.Cmd) {
+	m.screen = screenConfirm
+	m.confirmSimulation = nil
+	m.initConfirmViewport()
+	return m, m.loadTrackerIssues(m.selectedMRsIssues())
+}
+
+// viewPreflight renders the pre-flight screen
+func (m model) viewPreflight() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	sidebarW := sidebarWidth(m.width)
+	contentWidth := m.width - sidebarW - 4
+	contentHeight := m.height - 4
+	totalHeight := contentHeight + 2
+
+	sidebar := m.renderSixSidebar(sidebarW, totalHeight)
+
+	content := contentStyle.
+		Width(contentWidth).
+		Height(contentHeight).
+		Render(m.renderPreflightContent(contentWidth - 4))
+
+	main := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, content)
+
+	helpText := "enter: continue • r: re-run • C+q: back • /: commands • C+c: quit"
+	help := helpStyle.Width(m.width).Align(lipgloss.Center).Render(helpText)
+
+	return lipgloss.JoinVertical(lipgloss.Left, main, help)
+}
+
+// renderPreflightContent renders the pre-flight table, the details of the checks that did not
+// pass and the environment policy
+func (m model) renderPreflightContent(width int) string {
+	var sb strings.Builder
+	textStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+	mutedStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
+
+	sb.WriteString(envTitleStepStyle.Render("[7]") + envTitleStyle.Render(" Pre-flight "))
+	sb.WriteString("\n\n")
+
+	envName := ""
+	if m.selectedEnv != nil {
+		envName = m.selectedEnv.Name
+	}
+	envStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(getEnvBranchColor(envName)))
+	sb.WriteString(envPromptStyle.Render("Selected MRs are checked before releasing them to ") +
+		envStyle.Render(envName))
+	sb.WriteString("\n\n")
+
+	if m.preflightRunning {
+		sb.WriteString(m.spinner.View() + " " + textStyle.Render("Checking selected MRs..."))
+		return sb.String()
+	}
+	if m.preflightResults == nil {
+		sb.WriteString(mutedStyle.Render("Pre-flight checks are unavailable without GitLab credentials and a project"))
+		return sb.String()
+	}
+
+	env := m.selectedEnvConfig()
+	sb.WriteString(m.renderPreflightTable(width, env))
+	sb.WriteString("\n")
+
+	// Details of the checks that did not pass
+	for _, mr := range m.preflightResults {
+		for _, result := range mr.Results {
+			if result.Status == PreflightPass {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%s %s %s\n",
+				preflightStatusStyle(result.Status).Render(preflightStatusSymbol(result.Status)),
+				textStyle.Render(fmt.Sprintf("!%d %s:", mr.IID, result.Check)),
+				mutedStyle.Render(result.Detail)))
+		}
+	}
+	sb.WriteString("\n")
+
+	// Environment policy
+	var policy string
+	blocking := []string(nil)
+	if env != nil {
+		blocking = env.preflightBlockingChecks()
+		if problem := validatePreflightBlocking(env.PreflightBlocking); problem != "" {
+			sb.WriteString(lipgloss.NewStyle().Foreground(currentTheme.Warning).Render("preflight_blocking: "+problem) + "\n")
+		}
+	}
+	if len(blocking) == 0 {
+		policy = fmt.Sprintf("No checks block releases to %s, failures are shown as warnings", envName)
+	} else {
+		policy = fmt.Sprintf("Failed %s checks block releases to %s", strings.Join(blocking, ", "), envName)
+	}
+	sb.WriteString(mutedStyle.Render(policy))
+	sb.WriteString("\n\n")
+
+	if blockers := preflightBlockers(m.preflightResults, env); len(blockers) > 0 {
+		sb.WriteString(lipgloss.NewStyle().Foreground(currentTheme.Error).Bold(true).
+			Render(fmt.Sprintf("Release to %s is blocked by %d failed checks, fix them and press r to re-run", envName, len(blockers))))
+		return sb.String()
+	}
+	sb.WriteString(buttonActiveStyle.Render("Continue"))
+	return sb.String()
+}
+
+// renderPreflightTable renders a row per MR with a status cell per check. Failures
+// blocking the release to env are shown as "block".
+func (m model) renderPreflightTable(width int, env *EnvConfig) string {
+	headerStyle := lipgloss.NewStyle().Foreground(currentTheme.Accent).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+
+	mrWidth := max(width-preflightCellWidth*len(preflightChecks), 12)
+
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Width(mrWidth).Render("MR"))
+	for _, title := range preflightColumnTitles {
+		sb.WriteString(headerStyle.Width(preflightCellWidth).Render(title))
+	}
+	sb.WriteString("\n")
+
+	for _, mr := range m.preflightResults {
+		name := truncateWithEllipsis(fmt.Sprintf("!%d %s", mr.IID, mr.Branch), mrWidth-1)
+		sb.WriteString(textStyle.Width(mrWidth).Render(name))
+		for _, result := range mr.Results {
+			label := [...]string{"pass", "warn", "fail"}[result.Status]
+			if result.Status == PreflightFail && preflightBlocks(env, result.Check) {
+				label = "block"
+			}
+			cell := preflightStatusSymbol(result.Status) + " " + label
+			sb.WriteString(preflightStatusStyle(result.Status).Width(preflightCellWidth).Render(cell))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// preflightStatusSymbol returns the symbol of a check status
+func preflightStatusSymbol(status PreflightStatus) string {
+	switch status {
+	case PreflightPass:
+		return "✓"
+	case PreflightWarn:
+		return "!"
+	default:
+		return "✗"
+	}
+}
+
+// preflightStatusStyle returns the color of a check status
+func preflightStatusStyle(status PreflightStatus) lipgloss.Style {
+	switch status {
+	case PreflightPass:
+		return lipgloss.NewStyle().Foreground(currentTheme.Success)
+	case PreflightWarn:
+		return lipgloss.NewStyle().Foreground(currentTheme.Warning)
+	default:
+		return lipgloss.NewStyle().Foreground(currentTheme.Error)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// repoConfigFileName is the checked-in config file in the project root with release settings shared by the team
+const repoConfigFileName = ".relix.json"
+
+// ConfigLayer identifies a source of release settings. Layers are merged in this order,
+// each one overriding the values set by the layers before it.
+type ConfigLayer int
+
+const (
+	ConfigLayerGlobal  ConfigLayer = iota // Top-level release settings of ~/.relix/config.json
+	ConfigLayerRepo                       // .relix.json checked in the project root
+	ConfigLayerProject                    // Personal per-project overrides in ~/.relix/config.json
+)
+
+// configLayerCount is the number of config layers
+const configLayerCount = 3
+
+// String returns the display name of the layer
+func (l ConfigLayer) String() string {
+	switch l {
+	case ConfigLayerRepo:
+		return "Repository"
+	case ConfigLayerProject:
+		return "Project"
+	default:
+		return "Global"
+	}
+}
+
+// ReleaseSettings holds the release settings of a config layer.
+// Unset (nil) fields are inherited from the layers below.
+type ReleaseSettings struct {
+	BaseBranch        *string          `json:"base_branch,omitempty"`
+	Environments      []EnvConfig      `json:"environments,omitempty"`
+	ExcludePatterns   *string          `json:"exclude_patterns,omitempty"`
+	PipelineJobsRegex *string          `json:"pipeline_jobs_regex,omitempty"`
+	Naming            *NamingTemplates `json:"naming,omitempty"`        // Only the set templates override
+	TrackerRules      []TrackerRule    `json:"tracker_rules,omitempty"` // Overridden as a whole list
+	GitLabRelease     *bool            `json:"gitlab_release,omitempty"`
+}
+
+// IsEmpty reports whether the layer sets no values
+func (s *ReleaseSettings) IsEmpty() bool {
+	return s == nil || (s.BaseBranch == nil && s.Environments == nil && s.ExcludePatterns == nil && s.PipelineJobsRegex == nil && s.Naming == nil && s.TrackerRules == nil && s.GitLabRelease == nil)
+}
+
+// applyTo overrides the release settings of config with the values set on the layer
+func (s *ReleaseSettings) applyTo(config *AppConfig) {
+	if s == nil {
+		return
+	}
+	if s.BaseBranch != nil {
+		config.BaseBranch = *s.BaseBranch
+	}
+	if s.Environments != nil {
+		config.Environments = s.Environments
+	}
+	if s.ExcludePatterns != nil {
+		config.ExcludePatterns = *s.ExcludePatterns
+	}
+	if s.PipelineJobsRegex != nil {
+		config.PipelineJobsRegex = *s.PipelineJobsRegex
+	}
+	config.Naming = config.Naming.overriddenBy(s.Naming)
+	if s.TrackerRules != nil {
+		config.TrackerRules = s.TrackerRules
+	}
+	if s.GitLabRelease != nil {
+		config.GitLabRelease = *s.GitLabRelease
+	}
+}
+
+// globalReleaseSettings returns the top-level release settings of the config as a layer
+func globalReleaseSettings(config *AppConfig) *ReleaseSettings {
+	return &ReleaseSettings{
+		BaseBranch:        &config.BaseBranch,
+		Environments:      config.Environments,
+		ExcludePatterns:   &config.ExcludePatterns,
+		PipelineJobsRegex: &config.PipelineJobsRegex,
+		Naming:            &config.Naming,
+		TrackerRules:      config.TrackerRules,
+		GitLabRelease:     &config.GitLabRelease,
+	}
+}
+
+// overridesOf returns the layer with only the values that differ from the inherited config,
+// so a layer never pins values it merely repeats
+func overridesOf(layer *ReleaseSettings, inherited *AppConfig) *ReleaseSettings {
+	if layer == nil {
+		return nil
+	}
+	result := &ReleaseSettings{}
+	if layer.BaseBranch != nil && *layer.BaseBranch != inherited.BaseBranch {
+		result.BaseBranch = layer.BaseBranch
+	}
+	if layer.Environments != nil && !envConfigsEqual(layer.Environments, inherited.Environments) {
+		result.Environments = layer.Environments
+	}
+	if layer.ExcludePatterns != nil && *layer.ExcludePatterns != inherited.ExcludePatterns {
+		result.ExcludePatterns = layer.ExcludePatterns
+	}
+	if layer.PipelineJobsRegex != nil && *layer.PipelineJobsRegex != inherited.PipelineJobsRegex {
+		result.PipelineJobsRegex = layer.PipelineJobsRegex
+	}
+	if layer.Naming != nil {
+		result.Naming = namingOverridesOf(*layer.Naming, inherited.Naming.withDefaults())
+	}
+	if layer.TrackerRules != nil && !slices.Equal(layer.TrackerRules, trackerRulesOf(inherited)) {
+		result.TrackerRules = layer.TrackerRules
+	}
+	if layer.GitLabRelease != nil && *layer.GitLabRelease != inherited.GitLabRelease {
+		result.GitLabRelease = layer.GitLabRelease
+	}
+	return result
+}
+
+// namingOverridesOf returns the templates that differ from the inherited ones, or nil if none do
+func namingOverridesOf(naming, inherited NamingTemplates) *NamingTemplates {
+	var result NamingTemplates
+	if naming.SourceBranch != inherited.SourceBranch {
+		result.SourceBranch = naming.SourceBranch
+	}
+	if naming.EnvBranch != inherited.EnvBranch {
+		result.EnvBranch = naming.EnvBranch
+	}
+	if naming.Tag != inherited.Tag {
+		result.Tag = naming.Tag
+	}
+	if naming.CommitTitle != inherited.CommitTitle {
+		result.CommitTitle = naming.CommitTitle
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return &result
+}
+
+// envConfigsEqual reports whether two environment lists are the same. Names are compared
+// case-insensitively as they are always shown uppercased.
+func envConfigsEqual(a, b []EnvConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := a[i], b[i]
+		if !strings.EqualFold(x.Name, y.Name) {
+			return false
+		}
+		x.Name, y.Name = "", ""
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadRepoConfig loads the .relix.json of the project root. Returns nil if the file doesn't exist.
+func LoadRepoConfig(projectRoot string) (*ReleaseSettings, error) {
+	if projectRoot == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(projectRoot, repoConfigFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var settings ReleaseSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveRepoConfig writes the .relix.json of the project root.
+// An empty layer is only written if the file already exists.
+func SaveRepoConfig(projectRoot string, settings *ReleaseSettings) error {
+	path := filepath.Join(projectRoot, repoConfigFileName)
+	if settings.IsEmpty() {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil
+		}
+		settings = &ReleaseSettings{}
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// configLayers returns the release settings of every layer for a project, indexed by ConfigLayer
+func configLayers(config *AppConfig, projectID int, projectRoot string) ([configLayerCount]*ReleaseSettings, error) {
+	var layers [configLayerCount]*ReleaseSettings
+	layers[ConfigLayerGlobal] = globalReleaseSettings(config)
+	if projectID != 0 {
+		layers[ConfigLayerProject] = config.Projects[projectID]
+	}
+	repo, err := LoadRepoConfig(projectRoot)
+	layers[ConfigLayerRepo] = repo
+	return layers, err
+}
+
+// mergeConfigLayers returns a copy of config with the release settings of the layers up to
+// and including the given one applied in order
+func mergeConfigLayers(config *AppConfig, layers [configLayerCount]*ReleaseSettings, upTo ConfigLayer) *AppConfig {
+	merged := *config
+	for layer := ConfigLayerGlobal; layer <= upTo; layer++ {
+		layers[layer].applyTo(&merged)
+	}
+	return &merged
+}
+
+// LoadEffectiveConfig loads the config with the release settings of a project resolved:
+// global settings, overridden by the repository .relix.json, overridden by the personal project overrides.
+// A broken .relix.json is reported as an error along with the config of the remaining layers.
+func LoadEffectiveConfig(projectID int, projectRoot string) (*AppConfig, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	layers, err := configLayers(config, projectID, projectRoot)
+	return mergeConfigLayers(config, layers, ConfigLayerProject), err
+}
+
+// LoadSelectedProjectConfig loads the effective config of the selected project and the current checkout
+func LoadSelectedProjectConfig() (*AppConfig, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	projectRoot, _ := FindProjectRoot()
+	layers, err := configLayers(config, config.SelectedProjectID, projectRoot)
+	return mergeConfigLayers(config, layers, ConfigLayerProject), err
+}
+
+// LoadReleaseConfig loads the effective config of the project a release runs for
+func LoadReleaseConfig(state *ReleaseState) (*AppConfig, error) {
+	projectRoot := state.ProjectRoot
+	if projectRoot == "" {
+		projectRoot = state.WorkDir
+	}
+	return LoadEffectiveConfig(state.ProjectID, projectRoot)
+}
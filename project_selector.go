@@ -229,12 +229,14 @@ func (m model) overlayProjectSelector(background string) string {
 
 // fetchProjects creates a command to fetch projects from GitLab
 func (m *model) fetchProjects() tea.Cmd {
-	return func() tea.Msg {
-		if m.creds == nil {
+	if m.creds == nil {
+		return func() tea.Msg {
 			return fetchProjectsMsg{err: nil}
 		}
+	}
 
-		client := NewGitLabClient(m.creds.GitLabURL, m.creds.Token)
+	client := m.gitLabClient("projects")
+	return func() tea.Msg {
 		projects, err := client.GetProjects()
 		return fetchProjectsMsg{projects: projects, err: err}
 	}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nextEnvironmentIndex returns the index of the environment following the one with the
+// given branch, or -1 if the branch is unknown or the last environment
+func nextEnvironmentIndex(environments []Environment, branch string) int {
+	for i, env := range environments {
+		if env.BranchName == branch && i+1 < len(environments) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// historyReleaseName returns the name a release is referred to by: its tag, or its
+// version and environment for releases saved without one
+func historyReleaseName(entry *ReleaseHistoryEntry) string {
+	if entry.TagName != "" {
+		return entry.TagName
+	}
+	return entry.Version + " " + entry.Environment
+}
+
+// promoteRelease starts promoting the selected history release to the next environment
+// once its source branch is verified to still point at the released commit
+func (m model) promoteRelease() (tea.Model, tea.Cmd) {
+	entry := m.historySelected
+	if entry == nil || m.promotionChecking {
+		return m, nil
+	}
+
+	var problem string
+	switch {
+	case m.selectedProject == nil:
+		problem = "select a project first"
+	case entry.Status != "completed":
+		problem = "only completed releases can be promoted"
+	case entry.SourceBranchSHA == "":
+		problem = "the source branch commit was not recorded for this release"
+	case nextEnvironmentIndex(m.environments, entry.EnvBranch) < 0:
+		problem = fmt.Sprintf("there is no environment after %s", entry.Environment)
+	}
+	if problem != "" {
+		m.showErrorModal = true
+		m.errorModalMsg = "Cannot promote release: " + problem
+		return m, nil
+	}
+
+	m.promotionChecking = true
+	return m, tea.Batch(m.spinner.Tick, checkPromotionSource(entry))
+}
+
+// checkPromotionSource verifies that the source branch of a release on origin still
+// points at the commit the release was made of
+func checkPromotionSource(entry *ReleaseHistoryEntry) tea.Cmd {
+	return func() tea.Msg {
+		workDir, err := FindProjectRoot()
+		if err != nil {
+			return promotionCheckedMsg{entry: entry, err: err}
+		}
+
+		cmd := exec.Command("git", "ls-remote", "--heads", "origin", entry.SourceBranch)
+		cmd.Dir = workDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return promotionCheckedMsg{entry: entry, err: fmt.Errorf("failed to check origin/%s: %s", entry.SourceBranch, strings.TrimSpace(string(output)))}
+		}
+
+		head := ""
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			if fields := strings.Fields(line); len(fields) == 2 && fields[1] == "refs/heads/"+entry.SourceBranch {
+				head = fields[0]
+			}
+		}
+		switch head {
+		case "":
+			err = fmt.Errorf("source branch %s no longer exists on origin", entry.SourceBranch)
+		case entry.SourceBranchSHA:
+		default:
+			err = fmt.Errorf("source branch %s moved since the release: origin has %s, %s was released",
+				entry.SourceBranch, shortSHA(head), shortSHA(entry.SourceBranchSHA))
+		}
+		return promotionCheckedMsg{entry: entry, err: err}
+	}
+}
+
+// handlePromotionChecked opens the release wizard for the verified release to promote
+func (m model) handlePromotionChecked(msg promotionCheckedMsg) (tea.Model, tea.Cmd) {
+	m.promotionChecking = false
+	if msg.err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = "Cannot promote release: " + msg.err.Error()
+		return m, nil
+	}
+	if m.screen != screenHistoryDetail || m.historySelected != msg.entry {
+		return m, nil
+	}
+	m.startPromotion(msg.entry)
+	return m, nil
+}
+
+// startPromotion prefills the release wizard with the release to promote and opens the
+// environment selection at the next environment. Version and source branch are taken as
+// released, so the wizard skips their screens.
+func (m *model) startPromotion(entry *ReleaseHistoryEntry) {
+	m.promotion = entry
+	m.envSelectIndex = nextEnvironmentIndex(m.environments, entry.EnvBranch)
+
+	m.versionInput = initVersionInput()
+	m.versionInput.SetValue(entry.Version)
+	m.versionError = ""
+
+	m.initSourceBranchInput()
+	m.sourceBranchInput.SetValue(entry.SourceBranch)
+	m.sourceBranchVersion = entry.Version
+	m.sourceBranchCheckedName = entry.SourceBranch
+	m.sourceBranchRemoteStatus = "exists"
+
+	if entry.EnvMergeMode != "" {
+		index := envMergeModeIndex(entry.EnvMergeMode)
+		m.envMergeOptionIndex, m.envMergeSelection = index, index
+	}
+	m.rootMergeSelection = entry.RootMerge
+	m.confirmSimulation = nil
+	m.screen = screenEnvSelect
+}
+
+// cancelPromotion leaves the release wizard back to the promoted release
+func (m *model) cancelPromotion() {
+	m.promotion = nil
+	m.versionInput.SetValue("")
+	m.sourceBranchInput.SetValue("")
+	m.sourceBranchRemoteStatus = ""
+	m.screen = screenHistoryDetail
+}
+
+// applyPromotion makes the release state ship the promoted release as is: its MRs,
+// version and notes, from its source branch at the released commit
+func (m model) applyPromotion(state *ReleaseState) {
+	entry := m.promotion
+	if entry == nil {
+		return
+	}
+	state.SelectedMRIIDs = entry.MRIIDs
+	state.MRBranches = entry.MRBranches
+	state.MRURLs = entry.MRURLs
+	state.MRCommitSHAs = entry.MRCommitSHAs
+	state.Version = entry.Version
+	state.SourceBranch = entry.SourceBranch
+	state.SourceBranchIsRemote = true
+	state.SourceBranchSHA = entry.SourceBranchSHA
+	state.PromotedFrom = historyReleaseName(entry)
+	state.ReleaseNotes = entry.ReleaseNotes
+	state.ReleaseNoteEntries = nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+)
+
+// cherryPickContinueCommand finishes the conflicted pick once its conflicts are resolved.
+// A pick left empty by the resolution (its changes are already on the env branch) is skipped.
+const cherryPickContinueCommand = "git diff --cached --quiet && git cherry-pick --skip || GIT_EDITOR=true git cherry-pick --continue"
+
+// cherryPickCommand returns the command picking a commit onto the current branch. The original
+// commit is referenced in the message; a pick whose changes are already there is skipped.
+func cherryPickCommand(pick CherryPick) string {
+	mainline := ""
+	if pick.Mainline {
+		mainline = "-m 1 "
+	}
+	return fmt.Sprintf("git cherry-pick -x %s%s || (git diff --cached --quiet && git diff --quiet && git cherry-pick --skip)", mainline, pick.SHA)
+}
+
+// planCherryPicks lists the commits picked onto the env release branch in cherry-pick mode,
+// MR by MR in merge order: the commits the MR adds to the base branch or, if it has none
+// left (e.g. it is already merged into base), the merge commit that brought it into source.
+// MRs are picked at the head SHA they had when the release started.
+func planCherryPicks(git GitRunner, state *ReleaseState, baseRef, sourceRef string) ([]CherryPick, error) {
+	picks := []CherryPick{}
+	for i, branch := range state.MRBranches {
+		head := "origin/" + branch
+		if i < len(state.MRCommitSHAs) && state.MRCommitSHAs[i] != "" {
+			head = state.MRCommitSHAs[i]
+		}
+
+		commits, err := git.ListCommits(baseRef, head)
+		if err != nil {
+			return nil, err
+		}
+		for _, sha := range commits {
+			picks = append(picks, CherryPick{Branch: branch, SHA: sha})
+		}
+		if len(commits) > 0 {
+			continue
+		}
+		if merge := git.FindMergeCommit(sourceRef, head); merge != "" {
+			picks = append(picks, CherryPick{Branch: branch, SHA: merge, Mainline: true})
+		}
+	}
+	if len(picks) == 0 {
+		return nil, fmt.Errorf("no commits to cherry-pick: selected MRs have no commits missing from %s", baseRef)
+	}
+	return picks, nil
+}
+
+// cherryPickSubSteps returns the number of CopyContent substeps of a cherry-pick release:
+// one per planned commit, estimated as one per MR until the picks are planned
+func cherryPickSubSteps(state *ReleaseState) int {
+	if state.CherryPicks != nil {
+		return len(state.CherryPicks)
+	}
+	return len(state.MRBranches)
+}
+
+// applyCherryPickPlan stores planned picks in the state, starting over from the first one
+func applyCherryPickPlan(state *ReleaseState, picks []CherryPick) {
+	state.TotalSubSteps += len(picks) - cherryPickSubSteps(state)
+	state.CompletedSubSteps -= state.CherryPickIndex
+	state.CherryPicks = picks
+	state.CherryPickIndex = 0
+}
+
+// currentCherryPick returns the commit being picked, or nil outside of the cherry-pick step
+func (s *ReleaseState) currentCherryPick() *CherryPick {
+	if s.EnvMergeMode != "cherry-pick" || s.CurrentStep != ReleaseStepCopyContent || s.CherryPickIndex >= len(s.CherryPicks) {
+		return nil
+	}
+	return &s.CherryPicks[s.CherryPickIndex]
+}
+
+// excludedFilesCommitTitle is the title of the commit restoring the excluded files after the picks
+const excludedFilesCommitTitle = "Restore files excluded from release"
+
+// cherryPickNext picks the next planned commit onto the env release branch, or finishes
+// the pick suspended on conflicts. After the last pick the files matching the exclude
+// patterns are restored from the env branch in a fix-up commit, as squash mode leaves them.
+func (e *ReleaseEngine) cherryPickNext(patterns []string) (string, error) {
+	var output string
+	var err error
+	if e.git.DetectMergeConflict() {
+		output, err = e.git.RunCommand(cherryPickContinueCommand)
+	} else {
+		pick := e.state.currentCherryPick()
+		if pick == nil {
+			return "", fmt.Errorf("no commits left to cherry-pick")
+		}
+		output, err = e.git.RunCommands([]string{
+			fmt.Sprintf("git checkout %s", e.EnvReleaseBranch()),
+			cherryPickCommand(*pick),
+		})
+	}
+	if err != nil || e.state.CherryPickIndex < len(e.state.CherryPicks)-1 {
+		return output, err
+	}
+
+	output += e.restoreExcludedFiles(patterns)
+	commitOutput, err := e.git.RunCommand(fmt.Sprintf("git diff --cached --quiet || git commit -m %q", excludedFilesCommitTitle))
+	return output + commitOutput, err
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// mrDriftReason is recorded for MRs dropped from the release because their branch moved
+const mrDriftReason = "dropped: branch moved on origin after selection"
+
+// MRDrift describes a pending MR whose branch moved on origin since the MR was selected
+type MRDrift struct {
+	Index     int      // Index of the MR in the merge queue
+	Branch    string   // MR source branch
+	Pinned    string   // Commit the branch had when the MR was selected
+	Head      string   // Current commit of the branch on origin, "" if the branch was deleted
+	Commits   []string // "sha subject" of the commits the head has over the pinned commit
+	Rewritten bool     // The pinned commit is not in the head: the branch was force-pushed
+}
+
+// PinnedMRs returns the MRs still to be merged whose commits were recorded at selection,
+// with Head unset. Empty once the merge queue can't be edited.
+func (e *ReleaseEngine) PinnedMRs() []MRDrift {
+	state := e.state
+	if !e.MRQueueEditable() {
+		return nil
+	}
+	var pinned []MRDrift
+	for i := e.firstMovableMR(); i < len(state.MRBranches); i++ {
+		if i < len(state.MRCommitSHAs) && state.MRCommitSHAs[i] != "" {
+			pinned = append(pinned, MRDrift{Index: i, Branch: state.MRBranches[i], Pinned: state.MRCommitSHAs[i]})
+		}
+	}
+	return pinned
+}
+
+// RepinMR makes the MR at index merge the new head of its branch
+func (e *ReleaseEngine) RepinMR(index int, head string) error {
+	state := e.state
+	if !e.MRQueueEditable() || index < e.firstMovableMR() || index >= len(state.MRCommitSHAs) {
+		return fmt.Errorf("MR %d is not pending", index+1)
+	}
+	state.MRCommitSHAs[index] = head
+	return nil
+}
+
+// detectMRDrift compares the pinned commits of the MRs with the heads of their branches on
+// origin and returns the MRs that moved. Moved branches are fetched to list their new commits.
+func detectMRDrift(workDir string, pinned []MRDrift) ([]MRDrift, error) {
+	if len(pinned) == 0 {
+		return nil, nil
+	}
+	git := func(args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workDir
+		output, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(output)), err
+	}
+
+	args := []string{"ls-remote", "--heads", "origin"}
+	for _, mr := range pinned {
+		args = append(args, mr.Branch)
+	}
+	output, err := git(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check MR branches on origin: %s", output)
+	}
+	heads := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			heads[strings.TrimPrefix(fields[1], "refs/heads/")] = fields[0]
+		}
+	}
+
+	var drifts []MRDrift
+	for _, mr := range pinned {
+		mr.Head = heads[mr.Branch]
+		if mr.Head == mr.Pinned {
+			continue
+		}
+		if mr.Head != "" {
+			if output, err := git("fetch", "origin", mr.Branch); err != nil {
+				return nil, fmt.Errorf("failed to fetch %s: %s", mr.Branch, output)
+			}
+			_, err := git("merge-base", "--is-ancestor", mr.Pinned, mr.Head)
+			mr.Rewritten = err != nil
+			// The pinned commit may be missing locally when the branch was rewritten before the fetch
+			log, err := git("log", "--format=%h %s", mr.Pinned+".."+mr.Head)
+			if err != nil && !mr.Rewritten {
+				return nil, fmt.Errorf("failed to list new commits of %s: %s", mr.Branch, log)
+			}
+			if err == nil && log != "" {
+				mr.Commits = strings.Split(log, "\n")
+			}
+		}
+		drifts = append(drifts, mr)
+	}
+	return drifts, nil
+}
@@ -0,0 +1,852 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReleaseGitLab is the subset of the GitLab API used by the release engine
+type ReleaseGitLab interface {
+	CreateMergeRequest(projectID int, sourceBranch, targetBranch, title, description string) (*MergeRequest, error)
+	GetMergeRequestPipelines(projectID, mrIID int) ([]Pipeline, error)
+	UpsertRelease(projectID int, release GitLabRelease) (string, error)
+}
+
+// ReleaseEvent is a progress event emitted by the release engine
+type ReleaseEvent interface {
+	releaseEvent()
+}
+
+// ReleaseStepStartedEvent is emitted before a step's commands run
+type ReleaseStepStartedEvent struct {
+	Step ReleaseStep
+}
+
+// ReleaseSubStepDoneEvent is emitted after each intermediate substep of a multi-command step
+type ReleaseSubStepDoneEvent struct {
+	Step ReleaseStep
+}
+
+// ReleaseStepFinishedEvent is emitted once a step result has been applied to the state
+// Next is the step the release moved to; Err is set if the step failed
+type ReleaseStepFinishedEvent struct {
+	Step ReleaseStep
+	Next ReleaseStep
+	Err  error
+}
+
+// ReleaseMRCreatedEvent is emitted when the env release MR has been created
+type ReleaseMRCreatedEvent struct {
+	URL string
+	IID int
+}
+
+func (ReleaseStepStartedEvent) releaseEvent()  {}
+func (ReleaseSubStepDoneEvent) releaseEvent()  {}
+func (ReleaseStepFinishedEvent) releaseEvent() {}
+func (ReleaseMRCreatedEvent) releaseEvent()    {}
+
+// releaseStepTitle returns a short description of the step for the release log
+func releaseStepTitle(state *ReleaseState, step ReleaseStep) string {
+	switch step {
+	case ReleaseStepGitFetch:
+		return "Fetching remote updates"
+	case ReleaseStepCheckoutRoot:
+		return "Checking out source branch " + state.SourceBranch
+	case ReleaseStepMergeBranches:
+		return fmt.Sprintf("Merging %s (%d/%d)", state.MRBranches[state.CurrentMRIndex], state.CurrentMRIndex+1, len(state.MRBranches))
+	case ReleaseStepCheckoutEnv:
+		return "Checking out environment branch " + state.Environment.BranchName
+	case ReleaseStepCopyContent:
+		if state.EnvMergeMode == "regular" {
+			return "Merging source branch into environment release branch"
+		}
+		if pick := state.currentCherryPick(); pick != nil {
+			return fmt.Sprintf("Cherry-picking %s of %s (%d/%d)", shortSHA(pick.SHA), pick.Branch, state.CherryPickIndex+1, len(state.CherryPicks))
+		}
+		if state.Rollback != nil {
+			return "Restoring content of " + state.Rollback.Restored
+		}
+		return "Copying release content"
+	case ReleaseStepCommit:
+		return "Creating release commit"
+	case ReleaseStepPushAndCreateMR:
+		return "Pushing environment release branch and creating MR"
+	case ReleaseStepPushRootBranches:
+		return "Tagging and pushing root branches"
+	case ReleaseStepCreateRelease:
+		if !state.GitLabRelease {
+			return "Skipping GitLab release (gitlab_release is off)"
+		}
+		return "Creating GitLab release " + state.TagName
+	case ReleaseStepSwitchToRoot:
+		return "Switching back to " + state.BaseBranch
+	}
+	return ""
+}
+
+// ReleaseStepResult is the outcome of executing a single step
+type ReleaseStepResult struct {
+	Step        ReleaseStep
+	Output      string
+	Err         error
+	CherryPicks []CherryPick // Commits planned by the env checkout in cherry-pick mode
+}
+
+// ReleaseEngine runs the release state machine independently of the UI.
+//
+// Execute and CreateMR only read the state and may run in a background goroutine;
+// Complete and RecordMR apply their results and must be called on the goroutine
+// owning the state. Subscribers receive events from whichever goroutine produced them.
+type ReleaseEngine struct {
+	state       *ReleaseState
+	git         GitRunner
+	gitlab      ReleaseGitLab
+	subscribers []func(ReleaseEvent)
+}
+
+// NewReleaseEngine creates an engine driving the given release state
+func NewReleaseEngine(state *ReleaseState, git GitRunner, gitlab ReleaseGitLab) *ReleaseEngine {
+	return &ReleaseEngine{
+		state:  state,
+		git:    git,
+		gitlab: gitlab,
+	}
+}
+
+// State returns the release state owned by the engine
+func (e *ReleaseEngine) State() *ReleaseState {
+	return e.state
+}
+
+// Subscribe registers a handler for progress events
+func (e *ReleaseEngine) Subscribe(fn func(ReleaseEvent)) {
+	e.subscribers = append(e.subscribers, fn)
+}
+
+// emit delivers an event to all subscribers
+func (e *ReleaseEngine) emit(ev ReleaseEvent) {
+	for _, fn := range e.subscribers {
+		fn(ev)
+	}
+}
+
+// baseBranch returns the release base branch with "root" fallback for old saved states
+func (e *ReleaseEngine) baseBranch() string {
+	if e.state.BaseBranch == "" {
+		return "root"
+	}
+	return e.state.BaseBranch
+}
+
+// commands returns the command builder for the current state
+func (e *ReleaseEngine) commands(patterns []string) *ReleaseCommands {
+	state := e.state
+	cmds := NewReleaseCommandsWithSourceBranch(state.WorkDir, state.Version, e.baseBranch(), &state.Environment, patterns, state.MRBranches, state.SourceBranch, state.SourceBranchIsRemote).
+		WithNaming(state.Naming, state.StartedAt).
+		WithMRCommitSHAs(state.MRCommitSHAs).
+		WithWorktree(state.InWorktree())
+	if state.Rollback != nil {
+		cmds.WithEnvReleaseBranch(state.rollbackBranch())
+	}
+	return cmds
+}
+
+// RootBranch returns the release-root (source) branch name
+func (e *ReleaseEngine) RootBranch() string {
+	return e.commands(nil).ReleaseRootBranch()
+}
+
+// EnvReleaseBranch returns the environment release branch name
+func (e *ReleaseEngine) EnvReleaseBranch() string {
+	return e.commands(nil).EnvReleaseBranch()
+}
+
+// Execute runs the git commands of a single step without applying the result.
+// Substep progress is only reported via ReleaseSubStepDoneEvent.
+func (e *ReleaseEngine) Execute(step ReleaseStep) ReleaseStepResult {
+	e.emit(ReleaseStepStartedEvent{Step: step})
+	return e.execute(step, nil)
+}
+
+// execute runs the git commands of a single step. onSubStep is called before
+// a ReleaseSubStepDoneEvent is emitted for each intermediate substep.
+func (e *ReleaseEngine) execute(step ReleaseStep, onSubStep func()) ReleaseStepResult {
+	state := e.state
+	executor := e.git
+	subStepDone := func() {
+		if onSubStep != nil {
+			onSubStep()
+		}
+		e.emit(ReleaseSubStepDoneEvent{Step: step})
+	}
+
+	// Load config for exclude patterns
+	config, _ := LoadReleaseConfig(state)
+	patterns := releaseExcludePatterns(config, state.Environment.BranchName)
+
+	baseBranch := e.baseBranch()
+	cmds := e.commands(patterns)
+
+	var command string
+	var output string
+	var err error
+
+	switch step {
+	case ReleaseStepGitFetch:
+		output, err = executor.RunCommand(cmds.StepGitFetch())
+
+	case ReleaseStepCheckoutRoot:
+		output, err = executor.RunCommands(cmds.Step1CheckoutRoot())
+		if err == nil && state.SourceBranchSHA != "" {
+			// A promoted release ships the source branch exactly as it was released
+			if head := executor.GetBranchCommitID("HEAD"); head != state.SourceBranchSHA {
+				err = fmt.Errorf("source branch %s points at %s instead of released %s", state.SourceBranch, shortSHA(head), shortSHA(state.SourceBranchSHA))
+			}
+		}
+
+	case ReleaseStepMergeBranches:
+		// Check if we need to continue a merge
+		if executor.DetectMergeConflict() {
+			command = "GIT_EDITOR=true git merge --continue"
+		} else if state.CurrentMRIndex < len(state.MRBranches) {
+			// Check if branch already merged
+			branch := state.MRBranches[state.CurrentMRIndex]
+			ref := "origin/" + branch
+			if pinned := cmds.PinnedCommit(state.CurrentMRIndex); pinned != "" {
+				// The pinned commit is gone if the branch was rewritten before it was fetched
+				if _, err := executor.RunCommand(fmt.Sprintf("git cat-file -e %s^{commit}", pinned)); err != nil {
+					return ReleaseStepResult{Step: step, Err: fmt.Errorf("commit %s of %s selected for the release is not available locally, the branch was rewritten on origin", shortSHA(pinned), branch)}
+				}
+				ref = pinned
+			}
+			merged, _ := executor.IsBranchMerged(ref)
+			if merged {
+				// Already merged, move to next
+				return ReleaseStepResult{Step: step, Err: nil, Output: fmt.Sprintf("Branch %s already merged\n", branch)}
+			}
+			command = cmds.Step2MergeBranch(state.CurrentMRIndex)
+		}
+		if command != "" {
+			output, err = executor.RunCommand(command)
+		}
+
+	case ReleaseStepCheckoutEnv:
+		// Check if remote env branch exists
+		if !executor.RemoteBranchExists(state.Environment.BranchName) {
+			return ReleaseStepResult{
+				Step: step,
+				Err:  fmt.Errorf("remote branch origin/%s does not exist", state.Environment.BranchName),
+			}
+		}
+		output, err = executor.RunCommands(cmds.Step3CheckoutEnv())
+		if err == nil && state.EnvMergeMode == "cherry-pick" {
+			// Plan the commits to pick onto the env release branch
+			picks, planErr := planCherryPicks(executor, state, "origin/"+baseBranch, cmds.ReleaseRootBranch())
+			if planErr != nil {
+				return ReleaseStepResult{Step: step, Err: planErr, Output: output}
+			}
+			output += fmt.Sprintf("Cherry-picking %d commits of %d MRs\n", len(picks), len(state.MRBranches))
+			return ReleaseStepResult{Step: step, Output: output, CherryPicks: picks}
+		}
+
+	case ReleaseStepCopyContent:
+		envReleaseBranch := cmds.EnvReleaseBranch()
+
+		if state.Rollback != nil {
+			// Rollback: restore the tree of the redeployed release
+			output, err = e.restoreRollbackTree(subStepDone)
+		} else if state.EnvMergeMode == "regular" {
+			// Regular merge mode: merge source branch into env release branch
+			if executor.DetectMergeConflict() {
+				// Continue a previously conflicted merge
+				command = "GIT_EDITOR=true git merge --continue"
+				output, err = executor.RunCommand(command)
+			} else {
+				// Ensure we're on the env release branch
+				checkoutOutput, checkoutErr := executor.RunCommand(fmt.Sprintf("git checkout %s", envReleaseBranch))
+				if checkoutErr != nil {
+					return ReleaseStepResult{Step: step, Err: checkoutErr, Output: checkoutOutput}
+				}
+				// Merge source branch
+				mergeCmd := fmt.Sprintf("GIT_EDITOR=true git merge --no-edit %s", state.SourceBranch)
+				mergeOutput, mergeErr := executor.RunCommand(mergeCmd)
+				output = checkoutOutput + mergeOutput
+				err = mergeErr
+			}
+			if err == nil {
+				subStepDone()
+			}
+		} else if state.EnvMergeMode == "cherry-pick" {
+			// Cherry-pick mode: pick one planned commit per run, advancing to the next one
+			output, err = e.cherryPickNext(patterns)
+		} else {
+			// Squash mode (default): existing content copy behavior
+			// First, ensure we're on the env-release-branch (needed when retrying after commit failure)
+			checkoutOutput, checkoutErr := executor.RunCommand(fmt.Sprintf("git checkout %s", envReleaseBranch))
+			if checkoutErr != nil {
+				return ReleaseStepResult{Step: step, Err: checkoutErr, Output: checkoutOutput}
+			}
+			subStepDone()
+
+			// Step 4.1: Remove all files
+			output1, err1 := executor.RunCommand(cmds.Step4RemoveAll())
+			if err1 != nil {
+				return ReleaseStepResult{Step: step, Err: err1, Output: checkoutOutput + output1}
+			}
+			subStepDone()
+
+			// Step 4.2: Checkout from root
+			output2, err2 := executor.RunCommand(cmds.Step4CheckoutFromRoot())
+			if err2 != nil {
+				return ReleaseStepResult{Step: step, Err: err2, Output: checkoutOutput + output1 + output2}
+			}
+
+			// Step 4.3: Exclude files - restore from env branch or remove if not exists
+			output3 := e.restoreExcludedFiles(patterns)
+			subStepDone()
+
+			output = checkoutOutput + output1 + output2 + output3
+		}
+
+	case ReleaseStepCommit:
+		var title, body string
+		if state.Rollback != nil {
+			title, body = state.rollbackMessage()
+		} else {
+			// Get next v-number and create commit
+			vNumber, verr := executor.GetNextVersionNumber(state.Environment.BranchName, state.Version, state.naming().CommitTitle)
+			if verr != nil {
+				return ReleaseStepResult{Step: step, Err: verr, Output: ""}
+			}
+			title, body = BuildCommitMessage(state.naming().CommitTitle, state.namingVars(vNumber), state.MRBranches, trackerRulesOf(config))
+		}
+		// Don't use "git add -A" - files are already staged from checkout
+		var commitCmd string
+		if body != "" {
+			// Use $'...' bash syntax to properly interpret \n as newlines in commit body
+			// Escape single quotes and convert actual newlines to \n escape sequences
+			escapedBody := strings.ReplaceAll(body, "'", "'\\''")
+			escapedBody = strings.ReplaceAll(escapedBody, "\n", "\\n")
+			commitCmd = fmt.Sprintf("git commit -m %q -m $'%s'", title, escapedBody)
+		} else {
+			commitCmd = fmt.Sprintf("git commit -m %q", title)
+		}
+		output, err = executor.RunCommand(commitCmd)
+
+		// After successful commit, clean up any remaining untracked files
+		if err == nil {
+			cleanOutput, _ := executor.RunCommand("git clean -fd")
+			output += cleanOutput
+		} else {
+			// Commit failed (likely linter errors) - reset to release-root branch so user can fix there
+			e.resetAfterCommitFailure()
+		}
+
+	case ReleaseStepPushAndCreateMR:
+		// Push env release branch to remote (for the MR)
+		// Release root branch will be pushed later when user clicks "Push root branches"
+		output, err = executor.RunCommand(cmds.Step6Push())
+		// MR will be created via API after this step completes
+
+	case ReleaseStepPushRootBranches:
+		if state.Rollback != nil {
+			// A rollback has no source branch or tag to push
+			break
+		}
+		if state.RootMerge {
+			// RootMerge: push release-root, merge to root, tag merge-commit on root, push root+tags, merge to develop
+
+			// Push release root branch
+			pushReleaseRootCmd := fmt.Sprintf("git push -u origin %s", state.SourceBranch)
+			output1, err1 := executor.RunCommand(pushReleaseRootCmd)
+			if err1 != nil {
+				return ReleaseStepResult{Step: step, Err: err1, Output: output1}
+			}
+			output = output1
+			subStepDone()
+
+			// Merge release root to base branch (creates merge-commit)
+			output2, err2 := executor.RunCommands(cmds.StepMergeToRoot())
+			if err2 != nil {
+				return ReleaseStepResult{Step: step, Err: err2, Output: output + output2}
+			}
+			output += output2
+			subStepDone()
+
+			// Tag the merge-commit on root (we are on root after StepMergeToRoot)
+			// and check the tag on origin before anything is pushed to root
+			outputTag, errTag := executor.RunCommand(state.tagCommand())
+			if errTag != nil {
+				return ReleaseStepResult{Step: step, Err: errTag, Output: output + outputTag}
+			}
+			output += outputTag
+			tagPushCmd, errTag := e.tagPushCommand()
+			if errTag != nil {
+				return ReleaseStepResult{Step: step, Err: errTag, Output: output}
+			}
+			subStepDone()
+
+			// Push base branch and the release tag only
+			pushRootCmds := []string{cmds.StepPushBase()}
+			if tagPushCmd != "" {
+				pushRootCmds = append(pushRootCmds, tagPushCmd)
+			}
+			output3, err3 := executor.RunCommands(pushRootCmds)
+			if err3 != nil {
+				return ReleaseStepResult{Step: step, Err: err3, Output: output + output3}
+			}
+			output += output3
+			subStepDone()
+
+			// Merge root to develop and push
+			output4, err4 := executor.RunCommands(cmds.StepMergeToDevelop())
+			if err4 != nil {
+				return ReleaseStepResult{Step: step, Err: err4, Output: output + output4}
+			}
+			output += output4
+			subStepDone()
+		} else {
+			// No RootMerge: checkout release-root, tag it, push with tags
+
+			// Checkout release root branch
+			checkoutCmd := fmt.Sprintf("git checkout %s", state.SourceBranch)
+			outputChk, errChk := executor.RunCommand(checkoutCmd)
+			if errChk != nil {
+				return ReleaseStepResult{Step: step, Err: errChk, Output: outputChk}
+			}
+			output = outputChk
+			subStepDone()
+
+			// Create tag on release root branch and check it on origin
+			outputTag, errTag := executor.RunCommand(state.tagCommand())
+			if errTag != nil {
+				return ReleaseStepResult{Step: step, Err: errTag, Output: output + outputTag}
+			}
+			output += outputTag
+			tagPushCmd, errTag := e.tagPushCommand()
+			if errTag != nil {
+				return ReleaseStepResult{Step: step, Err: errTag, Output: output}
+			}
+			subStepDone()
+
+			// Push release root branch and the release tag only
+			pushCmds := []string{fmt.Sprintf("git push -u origin %s --force", state.SourceBranch)}
+			if tagPushCmd != "" {
+				pushCmds = append(pushCmds, tagPushCmd)
+			}
+			outputPush, errPush := executor.RunCommands(pushCmds)
+			if errPush != nil {
+				return ReleaseStepResult{Step: step, Err: errPush, Output: output + outputPush}
+			}
+			output += outputPush
+			subStepDone()
+		}
+
+	case ReleaseStepCreateRelease:
+		// Optional: the tag is pushed, publish its GitLab Release
+		if state.GitLabRelease {
+			output, err = e.createGitLabRelease()
+		}
+
+	case ReleaseStepSwitchToRoot:
+		// Switch back to base branch as final step
+		output, err = executor.RunCommand(releaseExitCheckoutCommand(state, baseBranch))
+
+	default:
+		return ReleaseStepResult{Step: step, Err: nil}
+	}
+
+	return ReleaseStepResult{Step: step, Err: err, Output: output}
+}
+
+// Complete applies a step result to the state and returns the step to run next.
+// On failure the error is recorded in LastError and the step to retry from is returned.
+func (e *ReleaseEngine) Complete(result ReleaseStepResult) (ReleaseStep, error) {
+	state := e.state
+
+	if result.Err != nil {
+		state.LastError = releaseErrorFor(result.Step, result.Err)
+		var collision *TagCollisionError
+		if errors.As(result.Err, &collision) {
+			// Ask again: a previously confirmed move was for other commits
+			move := collision.Move
+			state.TagMove = &move
+		}
+		e.emit(ReleaseStepFinishedEvent{Step: result.Step, Next: state.LastError.Step, Err: result.Err})
+		return state.LastError.Step, result.Err
+	}
+
+	state.LastSuccessStep = result.Step
+	state.LastError = nil
+	state.ErrorOutput = ""
+	if result.CherryPicks != nil {
+		applyCherryPickPlan(state, result.CherryPicks)
+	}
+
+	nextStep, ok := advanceReleaseState(state, result.Step)
+	if !ok {
+		return e.Complete(ReleaseStepResult{Step: result.Step, Err: fmt.Errorf("unexpected release step %d", result.Step)})
+	}
+	e.emit(ReleaseStepFinishedEvent{Step: result.Step, Next: nextStep})
+	return nextStep, nil
+}
+
+// MRQueueEditable reports whether pending MRs can still be skipped or reordered:
+// true until the merge step is finished, never for a promoted release
+func (e *ReleaseEngine) MRQueueEditable() bool {
+	return e.state.PromotedFrom == "" && e.state.CurrentStep <= ReleaseStepMergeBranches && e.state.CurrentMRIndex < len(e.state.MRBranches)
+}
+
+// firstMovableMR returns the index of the first MR that can be reordered.
+// The current MR is fixed while its merge is in progress.
+func (e *ReleaseEngine) firstMovableMR() int {
+	state := e.state
+	if state.CurrentStep == ReleaseStepMergeBranches && e.git.DetectMergeConflict() {
+		return state.CurrentMRIndex + 1
+	}
+	return state.CurrentMRIndex
+}
+
+// SkipMR removes a pending MR from the merge queue and records it in SkippedMRs with reason.
+// Skipping the current MR aborts its conflicted merge and clears the step error,
+// so the merge step continues from CurrentMRIndex with the next MR.
+func (e *ReleaseEngine) SkipMR(index int, reason string) error {
+	state := e.state
+	if !e.MRQueueEditable() || index < state.CurrentMRIndex || index >= len(state.MRBranches) {
+		return fmt.Errorf("MR %d is not pending", index+1)
+	}
+
+	isCurrent := index == state.CurrentMRIndex && state.CurrentStep == ReleaseStepMergeBranches
+	if isCurrent && e.git.DetectMergeConflict() {
+		if output, err := e.git.RunCommand("git merge --abort"); err != nil {
+			return fmt.Errorf("failed to abort merge: %s", strings.TrimSpace(output))
+		}
+	}
+
+	skipped := SkippedMR{Branch: state.MRBranches[index], Reason: reason}
+	if index < len(state.MRURLs) {
+		skipped.URL = state.MRURLs[index]
+	}
+	if index < len(state.SelectedMRIIDs) {
+		skipped.IID = state.SelectedMRIIDs[index]
+	}
+	state.SkippedMRs = append(state.SkippedMRs, skipped)
+	removeReleaseMR(state, index)
+
+	if isCurrent {
+		state.LastError = nil
+		state.ErrorOutput = ""
+		// CurrentMRIndex now points at the MR following the skipped one
+		if state.CurrentMRIndex >= len(state.MRBranches) {
+			state.CurrentStep = ReleaseStepCheckoutEnv
+		}
+	}
+	return nil
+}
+
+// MoveMR moves a pending MR one position up (delta -1) or down (delta 1) within
+// the pending part of the merge queue. Returns the new index of the MR.
+func (e *ReleaseEngine) MoveMR(index, delta int) (int, error) {
+	state := e.state
+	first := e.firstMovableMR()
+	target := index + delta
+	if !e.MRQueueEditable() || index < first || index >= len(state.MRBranches) || (delta != -1 && delta != 1) {
+		return index, fmt.Errorf("MR %d can't be moved", index+1)
+	}
+	if target < first || target >= len(state.MRBranches) {
+		return index, nil
+	}
+
+	swap := func(items []string) {
+		if target < len(items) && index < len(items) {
+			items[index], items[target] = items[target], items[index]
+		}
+	}
+	swap(state.MRBranches)
+	swap(state.MRURLs)
+	swap(state.MRCommitSHAs)
+	if target < len(state.SelectedMRIIDs) && index < len(state.SelectedMRIIDs) {
+		state.SelectedMRIIDs[index], state.SelectedMRIIDs[target] = state.SelectedMRIIDs[target], state.SelectedMRIIDs[index]
+	}
+	return target, nil
+}
+
+// removeReleaseMR removes the MR at index from all per-MR state lists and its merge substep
+func removeReleaseMR(state *ReleaseState, index int) {
+	removeAt := func(items []string) []string {
+		if index >= len(items) {
+			return items
+		}
+		return append(items[:index:index], items[index+1:]...)
+	}
+
+	state.MRBranches = removeAt(state.MRBranches)
+	state.MRURLs = removeAt(state.MRURLs)
+	state.MRCommitSHAs = removeAt(state.MRCommitSHAs)
+	if index < len(state.SelectedMRIIDs) {
+		dropReleaseNotes(state, state.SelectedMRIIDs[index])
+		state.SelectedMRIIDs = append(state.SelectedMRIIDs[:index:index], state.SelectedMRIIDs[index+1:]...)
+	}
+	state.TotalSubSteps--
+	if state.EnvMergeMode == "cherry-pick" && state.CherryPicks == nil {
+		state.TotalSubSteps-- // Its estimated pick
+	}
+}
+
+// restoreExcludedFiles stages the files matching the exclude patterns as they are on the
+// env branch, removing the ones it doesn't have
+func (e *ReleaseEngine) restoreExcludedFiles(patterns []string) string {
+	excluded, _ := e.git.GetExcludedFiles(patterns)
+	var output string
+	for _, file := range excluded {
+		output += fmt.Sprintf("Excluding: %s\n", file)
+		// Try to restore file from environment branch (keeps it unchanged)
+		restoreCmd := fmt.Sprintf("git checkout origin/%s -- %q 2>/dev/null", e.state.Environment.BranchName, file)
+		_, restoreErr := e.git.RunCommand(restoreCmd)
+		if restoreErr != nil {
+			// File doesn't exist in env branch - remove it completely
+			e.git.RunCommand(fmt.Sprintf("rm -rf %q", file))
+			e.git.RunCommand(fmt.Sprintf("git rm -rf --cached %q 2>/dev/null || true", file))
+		}
+	}
+	return output
+}
+
+// resetAfterCommitFailure resets staged changes and switches to the release-root branch
+func (e *ReleaseEngine) resetAfterCommitFailure() {
+	e.git.RunCommand("git reset")
+	if e.state.Rollback != nil {
+		e.git.RunCommand(releaseExitCheckoutCommand(e.state, e.baseBranch()))
+		return
+	}
+	e.git.RunCommand(fmt.Sprintf("git checkout %s", e.RootBranch()))
+}
+
+// releaseErrorFor builds the persisted error for a failed step
+// Commit failures are retried from the copy content step
+func releaseErrorFor(step ReleaseStep, err error) *ReleaseError {
+	var collision *TagCollisionError
+	if errors.As(err, &collision) {
+		return &ReleaseError{
+			Step:    step,
+			Message: err.Error(),
+			Code:    "TAG_COLLISION",
+		}
+	}
+	if step == ReleaseStepCommit {
+		return &ReleaseError{
+			Step:    ReleaseStepCopyContent, // Retry from copy content step
+			Message: err.Error(),
+			Code:    "COMMIT_FAILED",
+		}
+	}
+	return &ReleaseError{
+		Step:    step,
+		Message: err.Error(),
+	}
+}
+
+// advanceReleaseState records a successfully completed step in state and returns the next step.
+// Returns false for steps that have no follow-up transition.
+// ReleaseStepPushAndCreateMR stays current until the MR is created via API.
+func advanceReleaseState(state *ReleaseState, step ReleaseStep) (ReleaseStep, bool) {
+	var nextStep ReleaseStep
+
+	switch step {
+	case ReleaseStepGitFetch:
+		state.CompletedSubSteps++
+		nextStep = ReleaseStepCheckoutRoot
+		if state.Rollback != nil {
+			// A rollback has no source branch, it starts from the env branch
+			nextStep = ReleaseStepCheckoutEnv
+		}
+
+	case ReleaseStepCheckoutRoot:
+		state.CompletedSubSteps++
+		state.CurrentMRIndex = 0
+		if state.PromotedFrom != "" {
+			// MRs of a promoted release are already merged into its source branch
+			state.MergedBranches = append([]string{}, state.MRBranches...)
+			state.CurrentMRIndex = len(state.MRBranches)
+			nextStep = ReleaseStepCheckoutEnv
+		} else if len(state.MRBranches) > 0 {
+			nextStep = ReleaseStepMergeBranches
+		} else {
+			nextStep = ReleaseStepCheckoutEnv
+		}
+
+	case ReleaseStepMergeBranches:
+		state.CompletedSubSteps++
+		// Mark current branch as merged
+		if state.CurrentMRIndex < len(state.MRBranches) {
+			state.MergedBranches = append(state.MergedBranches, state.MRBranches[state.CurrentMRIndex])
+			state.CurrentMRIndex++
+		}
+
+		// Check if more branches to merge
+		if state.CurrentMRIndex < len(state.MRBranches) {
+			nextStep = ReleaseStepMergeBranches
+		} else {
+			nextStep = ReleaseStepCheckoutEnv
+		}
+
+	case ReleaseStepCheckoutEnv:
+		state.CompletedSubSteps++
+		nextStep = ReleaseStepCopyContent
+
+	case ReleaseStepCopyContent:
+		if state.EnvMergeMode == "cherry-pick" {
+			// Each picked commit is a substep; picks create their own commits, skip commit step
+			state.CompletedSubSteps++
+			state.CherryPickIndex++
+			if state.CherryPickIndex < len(state.CherryPicks) {
+				nextStep = ReleaseStepCopyContent
+			} else {
+				nextStep = ReleaseStepWaitForMR
+			}
+			break
+		}
+		// substeps already incremented via releaseSubStepDoneMsg
+		if state.EnvMergeMode == "regular" {
+			// Regular merge creates its own commit, skip commit step
+			nextStep = ReleaseStepWaitForMR
+		} else {
+			nextStep = ReleaseStepCommit
+		}
+
+	case ReleaseStepCommit:
+		state.CompletedSubSteps++
+		// After commit, wait for user to click "Create MR" button
+		// Push will happen when user clicks the button
+		nextStep = ReleaseStepWaitForMR
+
+	case ReleaseStepPushAndCreateMR:
+		state.CompletedSubSteps++
+		// Keep same step until MR is created
+		nextStep = ReleaseStepPushAndCreateMR
+
+	case ReleaseStepPushRootBranches:
+		// substeps already incremented via releaseSubStepDoneMsg
+		// Root push completed, now create the GitLab release
+		nextStep = ReleaseStepCreateRelease
+
+	case ReleaseStepCreateRelease:
+		if state.GitLabRelease {
+			state.CompletedSubSteps++
+		}
+		nextStep = ReleaseStepSwitchToRoot
+
+	case ReleaseStepSwitchToRoot:
+		state.CompletedSubSteps++
+		// Switch to root completed, save to history and mark as complete
+		nextStep = ReleaseStepComplete
+
+	default:
+		return step, false
+	}
+
+	state.CurrentStep = nextStep
+	return nextStep, true
+}
+
+// CreateMR creates the env release branch MR targeting the environment branch
+func (e *ReleaseEngine) CreateMR() (*MergeRequest, error) {
+	if e.gitlab == nil {
+		return nil, fmt.Errorf("not authenticated to GitLab")
+	}
+	state := e.state
+
+	if state.Rollback != nil {
+		title, body := state.rollbackMessage()
+		return e.gitlab.CreateMergeRequest(state.ProjectID, e.EnvReleaseBranch(), state.Environment.BranchName, title, body)
+	}
+
+	// Get version number and build MR title/body
+	vNumber, _ := e.git.GetNextVersionNumber(state.Environment.BranchName, state.Version, state.naming().CommitTitle)
+	config, _ := LoadReleaseConfig(state)
+	title, body := BuildCommitMessage(state.naming().CommitTitle, state.namingVars(vNumber), state.MRBranches, trackerRulesOf(config))
+	if state.ReleaseNotes != "" {
+		body = state.ReleaseNotes
+	}
+
+	return e.gitlab.CreateMergeRequest(state.ProjectID, e.EnvReleaseBranch(), state.Environment.BranchName, title, body)
+}
+
+// RecordMR applies the MR creation result. On success the release waits for the root push.
+func (e *ReleaseEngine) RecordMR(mr *MergeRequest, err error) error {
+	state := e.state
+	if err != nil {
+		state.LastError = &ReleaseError{
+			Step:    ReleaseStepPushAndCreateMR,
+			Message: err.Error(),
+		}
+		state.CurrentStep = ReleaseStepPushAndCreateMR
+		return err
+	}
+
+	state.CreatedMRURL = mr.WebURL
+	state.CreatedMRIID = mr.IID
+	state.CompletedSubSteps++ // MR created via API = 1 substep
+	if state.Rollback == nil {
+		state.ReleaseNumber, _ = e.git.GetNextVersionNumber(state.Environment.BranchName, state.Version, state.naming().CommitTitle)
+		state.TagName = e.TagName()
+	}
+	state.CurrentStep = ReleaseStepWaitForRootPush
+	e.emit(ReleaseMRCreatedEvent{URL: mr.WebURL, IID: mr.IID})
+	return nil
+}
+
+// TagName calculates the tag name for the release from the tag template (default {env}-{version}-v{n})
+func (e *ReleaseEngine) TagName() string {
+	state := e.state
+	vNumber, _ := e.git.GetNextVersionNumber(state.Environment.BranchName, state.Version, state.naming().CommitTitle)
+	return renderNamingTemplate(state.naming().Tag, state.namingVars(vNumber))
+}
+
+// Run drives the release from the current step to completion without pausing
+// at the steps that wait for user actions. Returns the first step error.
+func (e *ReleaseEngine) Run() error {
+	return e.RunContext(context.Background())
+}
+
+// RunContext is Run stopping before the next step once ctx is cancelled. The release is
+// suspended with an error at that step, so a retry continues from it.
+func (e *ReleaseEngine) RunContext(ctx context.Context) error {
+	state := e.state
+	step := state.CurrentStep
+	for step != ReleaseStepComplete {
+		switch step {
+		case ReleaseStepWaitForMR:
+			step = ReleaseStepPushAndCreateMR
+		case ReleaseStepWaitForRootPush:
+			step = ReleaseStepPushRootBranches
+		}
+		state.CurrentStep = step
+		if err := ctx.Err(); err != nil {
+			state.LastError = &ReleaseError{Step: step, Message: "release interrupted"}
+			return fmt.Errorf("release interrupted: %w", err)
+		}
+
+		e.emit(ReleaseStepStartedEvent{Step: step})
+		result := e.execute(step, func() {
+			state.CompletedSubSteps++
+		})
+		nextStep, err := e.Complete(result)
+		if err != nil {
+			return err
+		}
+
+		if step == ReleaseStepPushAndCreateMR {
+			mr, err := e.CreateMR()
+			if err := e.RecordMR(mr, err); err != nil {
+				return fmt.Errorf("failed to create MR: %w", err)
+			}
+			nextStep = state.CurrentStep
+		}
+		step = nextStep
+	}
+	return nil
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeReleaseGitLab creates env MRs without a GitLab instance
+type fakeReleaseGitLab struct {
+	created      []string // "source -> target" of created MRs
+	descriptions []string // Descriptions of created MRs
+}
+
+func (f *fakeReleaseGitLab) CreateMergeRequest(projectID int, sourceBranch, targetBranch, title, description string) (*MergeRequest, error) {
+	f.created = append(f.created, sourceBranch+" -> "+targetBranch)
+	f.descriptions = append(f.descriptions, description)
+	return &MergeRequest{IID: len(f.created), WebURL: "https://gitlab.local/group/project/-/merge_requests/1"}, nil
+}
+
+func (f *fakeReleaseGitLab) GetMergeRequestPipelines(projectID, mrIID int) ([]Pipeline, error) {
+	return nil, nil
+}
+
+func (f *fakeReleaseGitLab) UpsertRelease(projectID int, release GitLabRelease) (string, error) {
+	return "", nil
+}
+
+// newTestHarness creates a harness with root, develop and test branches and
+// MR branches feat/a and feat/b. HOME points to a temp dir so no user config is read.
+func newTestHarness(t *testing.T) *GitHarness {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	h, err := NewGitHarness("root", "develop", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.Cleanup() })
+
+	if _, err := h.PushBranch("feat/a", "root", map[string]string{"a.txt": "a\n"}, "add a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.PushBranch("feat/b", "root", map[string]string{"b.txt": "b\n"}, "add b"); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// newTestRelease returns the state of a release of branches to test
+func newTestRelease(h *GitHarness, branches ...string) *ReleaseState {
+	if len(branches) == 0 {
+		branches = []string{"feat/a", "feat/b"}
+	}
+	return h.NewState(Environment{Name: "TEST", BranchName: "test"}, "1.0.0", branches)
+}
+
+// runRelease runs the release engine in the release checkout until it completes or fails
+func runRelease(t *testing.T, state *ReleaseState, gitlab *fakeReleaseGitLab) error {
+	t.Helper()
+	executor := NewGitExecutor(state.WorkDir, nil)
+	defer executor.Close()
+	return NewReleaseEngine(state, executor, gitlab).Run()
+}
+
+// retryTestRelease clears the error of a suspended release and runs it again from the failed step
+func retryTestRelease(t *testing.T, state *ReleaseState, gitlab *fakeReleaseGitLab) error {
+	t.Helper()
+	if state.LastError == nil {
+		t.Fatal("release is not suspended")
+	}
+	state.CurrentStep = state.LastError.Step
+	state.LastError = nil
+	return runRelease(t, state, gitlab)
+}
+
+// assertFile checks the content of path at rev in origin
+func assertFile(t *testing.T, h *GitHarness, rev, path, want string) {
+	t.Helper()
+	got, err := h.FileAt(rev, path)
+	if err != nil {
+		t.Fatalf("%s:%s: %v", rev, path, err)
+	}
+	if got != strings.TrimSpace(want) {
+		t.Errorf("%s:%s = %q, want %q", rev, path, got, want)
+	}
+}
+
+func TestReleaseSquash(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	gitlab := &fakeReleaseGitLab{}
+
+	if err := runRelease(t, state, gitlab); err != nil {
+		t.Fatal(err)
+	}
+
+	envRelease := NewReleaseEngine(state, nil, nil).EnvReleaseBranch()
+	assertFile(t, h, envRelease, "a.txt", "a")
+	assertFile(t, h, envRelease, "b.txt", "b")
+
+	// Squash: a single commit on top of the env branch
+	count, err := h.run(h.Origin, "rev-list", "--count", "test.."+envRelease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != "1" {
+		t.Errorf("%s has %s commits over test, want 1", envRelease, count)
+	}
+	if len(gitlab.created) != 1 || gitlab.created[0] != envRelease+" -> test" {
+		t.Errorf("created MRs = %v", gitlab.created)
+	}
+	if h.RemoteHead(state.SourceBranch) == "" {
+		t.Errorf("source branch %s was not pushed", state.SourceBranch)
+	}
+	if tag, _ := h.run(h.Origin, "rev-parse", "-q", "--verify", "refs/tags/"+state.TagName+"^{commit}"); tag != h.RemoteHead(state.SourceBranch) {
+		t.Errorf("tag %s = %q, want source branch head", state.TagName, tag)
+	}
+}
+
+func TestReleaseRegularMerge(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	state.EnvMergeMode = "regular"
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+
+	if err := runRelease(t, state, &fakeReleaseGitLab{}); err != nil {
+		t.Fatal(err)
+	}
+
+	envRelease := NewReleaseEngine(state, nil, nil).EnvReleaseBranch()
+	assertFile(t, h, envRelease, "a.txt", "a")
+	assertFile(t, h, envRelease, "b.txt", "b")
+
+	// Regular: the source branch is merged, so it is an ancestor of the env release branch
+	if _, err := h.run(h.Origin, "merge-base", "--is-ancestor", state.SourceBranch, envRelease); err != nil {
+		t.Errorf("%s is not merged into %s", state.SourceBranch, envRelease)
+	}
+}
+
+func TestReleaseRootMerge(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	state.RootMerge = true
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+
+	if err := runRelease(t, state, &fakeReleaseGitLab{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, branch := range []string{"root", "develop"} {
+		assertFile(t, h, branch, "a.txt", "a")
+		assertFile(t, h, branch, "b.txt", "b")
+	}
+	if tag, _ := h.run(h.Origin, "rev-parse", "-q", "--verify", "refs/tags/"+state.TagName+"^{commit}"); tag != h.RemoteHead("root") {
+		t.Errorf("tag %s = %q, want the merge commit on root", state.TagName, tag)
+	}
+	if branch, _ := h.Git("rev-parse", "--abbrev-ref", "HEAD"); branch != "root" {
+		t.Errorf("checkout is on %s after the release, want root", branch)
+	}
+}
+
+// pushConflictingMR pushes feat/c changing a.txt differently than feat/a
+func pushConflictingMR(t *testing.T, h *GitHarness) {
+	t.Helper()
+	if _, err := h.PushBranch("feat/c", "root", map[string]string{"a.txt": "c\n"}, "change a"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testMergeConflict runs a release whose second MR conflicts with the first one, resolves
+// the conflict in the release checkout and retries
+func testMergeConflict(t *testing.T, h *GitHarness, state *ReleaseState) {
+	t.Helper()
+	gitlab := &fakeReleaseGitLab{}
+
+	err := runRelease(t, state, gitlab)
+	if err == nil {
+		t.Fatal("release with conflicting MRs succeeded")
+	}
+	if state.LastError == nil || state.LastError.Step != ReleaseStepMergeBranches || state.CurrentMRIndex != 1 {
+		t.Fatalf("release suspended at %+v, MR %d; want the merge of MR 2", state.LastError, state.CurrentMRIndex)
+	}
+	if !DetectMergeConflict(state.WorkDir) {
+		t.Fatal("merge conflict not detected")
+	}
+	files, err := GetConflictedFiles(state.WorkDir)
+	if err != nil || len(files) != 1 || files[0] != "a.txt" {
+		t.Fatalf("conflicted files = %v, %v", files, err)
+	}
+
+	// Resolve like the conflict screen: edit, stage, retry
+	if err := os.WriteFile(filepath.Join(state.WorkDir, "a.txt"), []byte("a+c\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.run(state.WorkDir, "add", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := retryTestRelease(t, state, gitlab); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFile(t, h, NewReleaseEngine(state, nil, nil).EnvReleaseBranch(), "a.txt", "a+c")
+	if len(state.MergedBranches) != 2 {
+		t.Errorf("merged branches = %v", state.MergedBranches)
+	}
+}
+
+func TestReleaseMergeConflict(t *testing.T) {
+	h := newTestHarness(t)
+	pushConflictingMR(t, h)
+	testMergeConflict(t, h, newTestRelease(h, "feat/a", "feat/c"))
+}
+
+func TestReleaseSkipMRRebuildsNotes(t *testing.T) {
+	h := newTestHarness(t)
+	pushConflictingMR(t, h)
+	state := newTestRelease(h, "feat/a", "feat/c")
+	state.SelectedMRIIDs = []int{1, 3}
+	state.ReleaseNoteEntries = BuildReleaseNoteEntries([]*MergeRequestDetails{
+		{MergeRequest: MergeRequest{IID: 1, Title: "feat: add a", SourceBranch: "feat/a"}},
+		{MergeRequest: MergeRequest{IID: 3, Title: "fix: change a", SourceBranch: "feat/c"}},
+	}, nil, nil)
+	state.ReleaseNotes = formatReleaseNotes(state.ReleaseNoteEntries)
+
+	gitlab := &fakeReleaseGitLab{}
+	if err := runRelease(t, state, gitlab); err == nil {
+		t.Fatal("release with conflicting MRs succeeded")
+	}
+	// Drop the conflicting MR like the conflict screen does and continue
+	executor := NewGitExecutor(state.WorkDir, nil)
+	err := NewReleaseEngine(state, executor, gitlab).SkipMR(1, "conflict")
+	executor.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runRelease(t, state, gitlab); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gitlab.descriptions) != 1 {
+		t.Fatalf("created MRs = %v", gitlab.created)
+	}
+	description := gitlab.descriptions[0]
+	if !strings.Contains(description, "1 merge request\n") || !strings.Contains(description, "add a ([!1]") || strings.Contains(description, "!3") {
+		t.Errorf("env MR description lists the skipped MR:\n%s", description)
+	}
+	if state.ReleaseNotes != description {
+		t.Errorf("release notes = %q, want the env MR description", state.ReleaseNotes)
+	}
+}
+
+func TestReleaseRetryAfterCommitFailure(t *testing.T) {
+	fake := NewFakeGitRunner()
+	fake.RemoteBranches["test"] = true
+	fake.OnCommand("git commit", "lint failed\n", errors.New("exit status 1"))
+
+	state := &ReleaseState{
+		Environment:     Environment{Name: "TEST", BranchName: "test"},
+		Version:         "1.0.0",
+		BaseBranch:      "root",
+		SourceBranch:    "release/rpb-1.0.0-root",
+		MRBranches:      []string{"feat/a"},
+		EnvMergeMode:    "squash",
+		CurrentStep:     ReleaseStepGitFetch,
+		LastSuccessStep: ReleaseStepIdle,
+		MergedBranches:  []string{},
+		WorkDir:         t.TempDir(),
+	}
+	t.Setenv("HOME", t.TempDir())
+	engine := NewReleaseEngine(state, fake, &fakeReleaseGitLab{})
+
+	if err := engine.Run(); err == nil {
+		t.Fatal("release with a failing commit succeeded")
+	}
+	// A failed commit is retried from the content copy, on the release root branch
+	if state.LastError.Step != ReleaseStepCopyContent || state.LastError.Code != "COMMIT_FAILED" {
+		t.Fatalf("last error = %+v", state.LastError)
+	}
+
+	state.CurrentStep = state.LastError.Step
+	state.LastError = nil
+	if err := engine.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	commits := 0
+	for _, command := range fake.Commands() {
+		if strings.HasPrefix(command, "git commit") {
+			commits++
+		}
+	}
+	if commits != 2 {
+		t.Errorf("git commit ran %d times, want 2", commits)
+	}
+	if state.CurrentStep != ReleaseStepComplete {
+		t.Errorf("release ended at step %d", state.CurrentStep)
+	}
+}
+
+func TestReleaseRetryMergeAfterConflictFake(t *testing.T) {
+	fake := NewFakeGitRunner()
+	fake.RemoteBranches["test"] = true
+	fake.OnConflict("GIT_EDITOR=true git merge --no-edit origin/feat/b")
+
+	state := &ReleaseState{
+		Environment:     Environment{Name: "TEST", BranchName: "test"},
+		Version:         "1.0.0",
+		BaseBranch:      "root",
+		SourceBranch:    "release/rpb-1.0.0-root",
+		MRBranches:      []string{"feat/a", "feat/b"},
+		EnvMergeMode:    "squash",
+		CurrentStep:     ReleaseStepGitFetch,
+		LastSuccessStep: ReleaseStepIdle,
+		MergedBranches:  []string{},
+		WorkDir:         t.TempDir(),
+	}
+	t.Setenv("HOME", t.TempDir())
+	engine := NewReleaseEngine(state, fake, &fakeReleaseGitLab{})
+
+	if err := engine.Run(); err == nil {
+		t.Fatal("conflicted merge succeeded")
+	}
+	if state.LastError.Step != ReleaseStepMergeBranches || state.CurrentMRIndex != 1 {
+		t.Fatalf("release suspended at %+v, MR %d", state.LastError, state.CurrentMRIndex)
+	}
+
+	state.CurrentStep = state.LastError.Step
+	state.LastError = nil
+	if err := engine.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The retry continues the merge instead of starting it again
+	var merges []string
+	for _, command := range fake.Commands() {
+		if strings.Contains(command, "git merge") && strings.Contains(command, "feat/b") || strings.Contains(command, "merge --continue") {
+			merges = append(merges, command)
+		}
+	}
+	want := []string{"GIT_EDITOR=true git merge --no-edit origin/feat/b", "GIT_EDITOR=true git merge --continue"}
+	if strings.Join(merges, "\n") != strings.Join(want, "\n") {
+		t.Errorf("merge commands = %q, want %q", merges, want)
+	}
+}
+
+func TestReleaseRunContextCancelled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	fake := NewFakeGitRunner()
+	fake.RemoteBranches["test"] = true
+	state := &ReleaseState{
+		Environment:     Environment{Name: "TEST", BranchName: "test"},
+		Version:         "1.0.0",
+		BaseBranch:      "root",
+		SourceBranch:    "release/rpb-1.0.0-root",
+		MRBranches:      []string{"feat/a"},
+		EnvMergeMode:    "squash",
+		CurrentStep:     ReleaseStepMergeBranches,
+		LastSuccessStep: ReleaseStepCheckoutRoot,
+		MergedBranches:  []string{},
+		WorkDir:         t.TempDir(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewReleaseEngine(state, fake, &fakeReleaseGitLab{}).RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want cancelled", err)
+	}
+	// Suspended before the step, so a retry runs it
+	if state.LastError == nil || state.LastError.Step != ReleaseStepMergeBranches || len(fake.Commands()) != 0 {
+		t.Errorf("last error = %+v, commands = %v", state.LastError, fake.Commands())
+	}
+}
+
+func TestReleaseCherryPick(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	state.EnvMergeMode = "cherry-pick"
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+
+	if err := runRelease(t, state, &fakeReleaseGitLab{}); err != nil {
+		t.Fatal(err)
+	}
+
+	envRelease := NewReleaseEngine(state, nil, nil).EnvReleaseBranch()
+	assertFile(t, h, envRelease, "a.txt", "a")
+	assertFile(t, h, envRelease, "b.txt", "b")
+	// One commit per pick, no fix-up commit without excluded files changes
+	if count, _ := h.run(h.Origin, "rev-list", "--count", "test.."+envRelease); count != "2" {
+		t.Errorf("%s has %s commits over test, want 2", envRelease, count)
+	}
+}
+
+func TestReleaseCherryPickExcludedFiles(t *testing.T) {
+	h := newTestHarness(t)
+	// The env branch has the CI config of root, an MR changes it
+	if err := h.commitFiles(map[string]string{".gitlab-ci.yml": "base\n"}, "add ci"); err != nil {
+		t.Fatal(err)
+	}
+	for _, branch := range []string{"root", "test"} {
+		if _, err := h.Git("push", "-f", "origin", "root:"+branch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{".gitlab-ci.yml": "feat\n", "sprite.gen.ts": "gen\n", "c.txt": "c\n"}
+	if _, err := h.PushBranch("feat/ci", "root", files, "change ci"); err != nil {
+		t.Fatal(err)
+	}
+	state := newTestRelease(h, "feat/a", "feat/ci")
+	state.EnvMergeMode = "cherry-pick"
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+
+	// The default exclude patterns are .gitlab-ci.yml and sprite.gen.ts
+	if err := runRelease(t, state, &fakeReleaseGitLab{}); err != nil {
+		t.Fatal(err)
+	}
+
+	envRelease := NewReleaseEngine(state, nil, nil).EnvReleaseBranch()
+	assertFile(t, h, envRelease, "a.txt", "a")
+	assertFile(t, h, envRelease, "c.txt", "c")
+	assertFile(t, h, envRelease, ".gitlab-ci.yml", "base")
+	if _, err := h.FileAt(envRelease, "sprite.gen.ts"); err == nil {
+		t.Error("sprite.gen.ts missing in the env branch was released")
+	}
+	if subject, _ := h.run(h.Origin, "log", "-1", "--format=%s", envRelease); subject != excludedFilesCommitTitle {
+		t.Errorf("last commit of %s is %q, want the excluded files fix-up", envRelease, subject)
+	}
+}
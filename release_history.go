@@ -34,12 +34,16 @@ func generateReleaseID() string {
 
 // buildReleaseTag constructs the tag string from version and env (e.g., "5.2-v13")
 func buildReleaseTag(state *ReleaseState) string {
+	if state.Rollback != nil {
+		return "rollback " + state.Version
+	}
 	// Tag is stored in state if root merge was done
 	if state.TagName != "" {
-		// Strip env prefix from tag name (e.g., "dev-1.0.0-v2" -> "1.0.0-v2")
-		parts := strings.SplitN(state.TagName, "-", 2)
-		if len(parts) > 1 {
-			return parts[1]
+		// Strip env prefix from tag name (e.g., "dev-1.0.0-v2" -> "1.0.0-v2"),
+		// tags of custom templates are kept as is
+		envPrefix := strings.ToLower(state.Environment.Name) + "-"
+		if strings.HasPrefix(state.TagName, envPrefix) {
+			return strings.TrimPrefix(state.TagName, envPrefix)
 		}
 		return state.TagName
 	}
@@ -56,6 +60,12 @@ func SaveReleaseHistory(state *ReleaseState, status string, terminalOutput []str
 	id := generateReleaseID()
 	now := time.Now()
 
+	// The source branch head is kept to promote the release later exactly as released
+	sourceBranchSHA := state.SourceBranchSHA
+	if sourceBranchSHA == "" {
+		sourceBranchSHA = GetBranchCommitID(state.WorkDir, state.SourceBranch)
+	}
+
 	indexEntry := HistoryIndexEntry{
 		ID:          id,
 		Tag:         buildReleaseTag(state),
@@ -64,6 +74,8 @@ func SaveReleaseHistory(state *ReleaseState, status string, terminalOutput []str
 		MRCount:     len(state.MRBranches),
 		Status:      status,
 		Version:     state.Version,
+		Number:      state.ReleaseNumber,
+		ProjectID:   state.ProjectID,
 	}
 
 	detail := &ReleaseHistoryEntry{
@@ -72,8 +84,17 @@ func SaveReleaseHistory(state *ReleaseState, status string, terminalOutput []str
 		MRURLs:            state.MRURLs,
 		MRIIDs:            state.SelectedMRIIDs,
 		MRCommitSHAs:      state.MRCommitSHAs,
+		SkippedMRs:        state.SkippedMRs,
 		SourceBranch:      state.SourceBranch,
+		SourceBranchSHA:   sourceBranchSHA,
+		PromotedFrom:      state.PromotedFrom,
 		EnvBranch:         state.Environment.BranchName,
+		EnvReleaseSHA:     GetBranchCommitID(state.WorkDir, NewReleaseEngine(state, nil, nil).EnvReleaseBranch()),
+		Rollback:          state.Rollback,
+		TagName:           state.TagName,
+		ReleaseNotes:      state.ReleaseNotes,
+		GitLabReleaseURL:  state.gitLabReleaseURL(),
+		TagMove:           state.TagMove,
 		RootMerge:         state.RootMerge,
 		EnvMergeMode:      state.EnvMergeMode,
 		CreatedMRURL:      state.CreatedMRURL,
@@ -82,13 +103,13 @@ func SaveReleaseHistory(state *ReleaseState, status string, terminalOutput []str
 	}
 
 	// Save individual detail file
-	detailPath := filepath.Join(dir, id+".json")
-	detailData, err := json.MarshalIndent(detail, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal detail: %w", err)
+	if err := writeHistoryDetail(detail); err != nil {
+		return err
 	}
-	if err := os.WriteFile(detailPath, detailData, 0o644); err != nil {
-		return fmt.Errorf("write detail: %w", err)
+
+	// Link the rolled back release to its rollback
+	if status == "completed" && state.Rollback != nil && state.Rollback.RevertedID != "" {
+		markRolledBack(state.Rollback.RevertedID, fmt.Sprintf("rollback to %s at %s", state.Rollback.Restored, now.Format("02.01.2006 15:04")))
 	}
 
 	// Update index file (preserve existing entries, only start fresh if file doesn't exist)
@@ -117,6 +138,22 @@ func SaveReleaseHistory(state *ReleaseState, status string, terminalOutput []str
 	return nil
 }
 
+// writeHistoryDetail saves the detail file of a history entry
+func writeHistoryDetail(entry *ReleaseHistoryEntry) error {
+	dir, err := getReleasesDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal detail: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, entry.ID+".json"), data, 0o644); err != nil {
+		return fmt.Errorf("write detail: %w", err)
+	}
+	return nil
+}
+
 // LoadHistoryIndex loads the history index for quick list display
 func LoadHistoryIndex() ([]HistoryIndexEntry, error) {
 	dir, err := getReleasesDir()
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ReleaseLock marks a release as driven by this relix process.
+// The lock file holds the owner PID; a lock of a dead process is stale and taken over.
+type ReleaseLock struct {
+	path string
+}
+
+// getReleaseLockPath returns the lock file path next to the release state file
+func getReleaseLockPath(state *ReleaseState) (string, error) {
+	dir, err := getReleaseStatesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, releaseStateKey(state.ProjectID, state.Environment.BranchName)+".lock"), nil
+}
+
+// processAlive reports whether a process with the given PID exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// readLockPID returns the PID stored in a lock file or 0 if it can't be read
+func readLockPID(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
+
+// ReleaseLockHolder returns the PID of another live relix process driving the release, or 0
+func ReleaseLockHolder(state *ReleaseState) int {
+	path, err := getReleaseLockPath(state)
+	if err != nil {
+		return 0
+	}
+	pid := readLockPID(path)
+	if pid == os.Getpid() || !processAlive(pid) {
+		return 0
+	}
+	return pid
+}
+
+// AcquireReleaseLock locks the release for this process. Fails if another live relix process holds it.
+func AcquireReleaseLock(state *ReleaseState) (*ReleaseLock, error) {
+	path, err := getReleaseLockPath(state)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, err = fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return &ReleaseLock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		pid := readLockPID(path)
+		if pid == os.Getpid() {
+			return &ReleaseLock{path: path}, nil
+		}
+		if processAlive(pid) {
+			return nil, fmt.Errorf("release %s to %s is driven by another relix process (pid %d)",
+				state.Version, state.Environment.Name, pid)
+		}
+		// Stale lock of a crashed process
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("failed to lock release %s to %s", state.Version, state.Environment.Name)
+}
+
+// Release removes the lock file if it still belongs to this process
+func (l *ReleaseLock) Release() error {
+	if l == nil {
+		return nil
+	}
+	if readLockPID(l.path) != os.Getpid() {
+		return nil
+	}
+	err := os.Remove(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
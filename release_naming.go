@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Naming template placeholders
+const (
+	namingVersion   = "{version}"   // Release version, e.g. 1.0.0
+	namingEnv       = "{env}"       // Environment name in lowercase, e.g. dev
+	namingEnvBranch = "{envBranch}" // Environment branch, e.g. develop
+	namingNumber    = "{n}"         // Ordinal number of the release within the version on the env branch
+	namingDate      = "{date}"      // Release start date as YYYY-MM-DD
+)
+
+// namingDateLayout is the time layout of the {date} placeholder
+const namingDateLayout = "2006-01-02"
+
+// namingPlaceholders lists all supported placeholders
+var namingPlaceholders = []string{namingVersion, namingEnv, namingEnvBranch, namingNumber, namingDate}
+
+// namingPlaceholderRe matches a placeholder-like token in a template
+var namingPlaceholderRe = regexp.MustCompile(`\{[^{}]*\}`)
+
+// NamingTemplates holds the templates of the names relix creates. Empty fields use the defaults.
+type NamingTemplates struct {
+	SourceBranch string `json:"source_branch,omitempty"` // Cumulative source branch, e.g. release/rpb-{version}-root
+	EnvBranch    string `json:"env_branch,omitempty"`    // Environment release branch, e.g. release/rpb-{version}-{envBranch}
+	Tag          string `json:"tag,omitempty"`           // Release tag, e.g. {env}-{version}-v{n}
+	CommitTitle  string `json:"commit_title,omitempty"`  // Release commit and env MR title, e.g. release:{version} {envBranch} v{n}
+}
+
+// defaultNamingTemplates returns the naming relix used before templates were configurable
+func defaultNamingTemplates() NamingTemplates {
+	return NamingTemplates{
+		SourceBranch: "release/rpb-{version}-root",
+		EnvBranch:    "release/rpb-{version}-{envBranch}",
+		Tag:          "{env}-{version}-v{n}",
+		CommitTitle:  "release:{version} {envBranch} v{n}",
+	}
+}
+
+// IsEmpty reports whether no template is set
+func (n NamingTemplates) IsEmpty() bool {
+	return n == NamingTemplates{}
+}
+
+// overriddenBy returns the templates with the non-empty templates of o applied
+func (n NamingTemplates) overriddenBy(o *NamingTemplates) NamingTemplates {
+	if o == nil {
+		return n
+	}
+	if o.SourceBranch != "" {
+		n.SourceBranch = o.SourceBranch
+	}
+	if o.EnvBranch != "" {
+		n.EnvBranch = o.EnvBranch
+	}
+	if o.Tag != "" {
+		n.Tag = o.Tag
+	}
+	if o.CommitTitle != "" {
+		n.CommitTitle = o.CommitTitle
+	}
+	return n
+}
+
+// withDefaults returns the templates with the unset ones filled from the defaults
+func (n NamingTemplates) withDefaults() NamingTemplates {
+	return defaultNamingTemplates().overriddenBy(&n)
+}
+
+// NamingVars holds the values substituted into naming templates
+type NamingVars struct {
+	Version   string
+	Env       string // Environment name, lowercased when substituted
+	EnvBranch string
+	N         int
+	Date      time.Time
+}
+
+// renderNamingTemplate substitutes the placeholders of a template
+func renderNamingTemplate(template string, vars NamingVars) string {
+	date := vars.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return strings.NewReplacer(
+		namingVersion, vars.Version,
+		namingEnv, strings.ToLower(vars.Env),
+		namingEnvBranch, vars.EnvBranch,
+		namingNumber, strconv.Itoa(vars.N),
+		namingDate, date.Format(namingDateLayout),
+	).Replace(template)
+}
+
+// namingTemplatePattern converts a template into a regexp source. The first {version} and
+// {n} are captured as the groups "version" and "n"; envBranch, when set, must match literally.
+// Like titles written by hand, a "v" before {n} is optional.
+func namingTemplatePattern(template, envBranch string) string {
+	var b strings.Builder
+	captured := make(map[string]bool)
+	last := 0
+	for _, loc := range namingPlaceholderRe.FindAllStringIndex(template, -1) {
+		token := template[loc[0]:loc[1]]
+		literal := template[last:loc[0]]
+		if token == namingNumber && strings.HasSuffix(literal, "v") {
+			b.WriteString(namingLiteralPattern(strings.TrimSuffix(literal, "v")) + "v?")
+		} else {
+			b.WriteString(namingLiteralPattern(literal))
+		}
+		last = loc[1]
+
+		var pattern string
+		switch token {
+		case namingVersion:
+			pattern = `[-0-9.\s]+?`
+		case namingNumber:
+			pattern = `\d+`
+		case namingEnvBranch:
+			pattern = `\S+?`
+			if envBranch != "" {
+				pattern = regexp.QuoteMeta(envBranch)
+			}
+		case namingDate:
+			pattern = `\d{4}-\d{2}-\d{2}`
+		case namingEnv:
+			pattern = `.+?`
+		default:
+			b.WriteString(namingLiteralPattern(token))
+			continue
+		}
+		if (token == namingVersion || token == namingNumber) && !captured[token] {
+			captured[token] = true
+			pattern = fmt.Sprintf("(?P<%s>%s)", strings.Trim(token, "{}"), pattern)
+		}
+		b.WriteString(pattern)
+	}
+	b.WriteString(namingLiteralPattern(template[last:]))
+	return b.String()
+}
+
+// namingLiteralPattern quotes the literal part of a template, allowing any whitespace where it
+// has spaces and around colons
+func namingLiteralPattern(literal string) string {
+	fields := strings.Fields(literal)
+	if len(fields) == 0 {
+		if literal != "" {
+			return `\s+`
+		}
+		return ""
+	}
+	for i, field := range fields {
+		fields[i] = strings.ReplaceAll(regexp.QuoteMeta(field), ":", `\s*:\s*`)
+	}
+	pattern := strings.Join(fields, `\s+`)
+	if strings.TrimLeft(literal, " \t") != literal {
+		pattern = `\s+` + pattern
+	}
+	if strings.TrimRight(literal, " \t") != literal {
+		pattern += `\s+`
+	}
+	return pattern
+}
+
+// validateNamingTemplate checks a template for unknown and missing placeholders. Templates of
+// git refs (branches and tags) cannot contain spaces. Returns an error message or "" if valid.
+func validateNamingTemplate(template string, ref bool, required ...string) string {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return "cannot be empty"
+	}
+	if ref && strings.ContainsAny(template, " \t") {
+		return "cannot contain spaces"
+	}
+	for _, token := range namingPlaceholderRe.FindAllString(template, -1) {
+		known := false
+		for _, placeholder := range namingPlaceholders {
+			if token == placeholder {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Sprintf("unknown placeholder %s, use %s", token, strings.Join(namingPlaceholders, ", "))
+		}
+	}
+	for _, placeholder := range required {
+		if !strings.Contains(template, placeholder) {
+			return "must contain " + placeholder
+		}
+	}
+	return ""
+}
+
+// validateNamingTemplates validates all templates and returns an error message or "" if valid
+func validateNamingTemplates(n NamingTemplates) string {
+	n = n.withDefaults()
+	if err := validateNamingTemplate(n.SourceBranch, true, namingVersion); err != "" {
+		return "Source branch template " + err
+	}
+	if err := validateNamingTemplate(n.EnvBranch, true, namingVersion); err != "" {
+		return "Env release branch template " + err
+	}
+	if !strings.Contains(n.EnvBranch, namingEnv) && !strings.Contains(n.EnvBranch, namingEnvBranch) {
+		return "Env release branch template must contain " + namingEnv + " or " + namingEnvBranch
+	}
+	if err := validateNamingTemplate(n.Tag, true, namingVersion, namingNumber); err != "" {
+		return "Tag template " + err
+	}
+	// The commit title is parsed back to find the next release number
+	if err := validateNamingTemplate(n.CommitTitle, false, namingVersion, namingNumber); err != "" {
+		return "Commit title template " + err
+	}
+	return ""
+}
+
+// naming returns the naming templates of the release with defaults for unset ones
+func (s *ReleaseState) naming() NamingTemplates {
+	return s.Naming.withDefaults()
+}
+
+// namingVars returns the template values of the release for the given release number
+func (s *ReleaseState) namingVars(n int) NamingVars {
+	return NamingVars{
+		Version:   s.Version,
+		Env:       s.Environment.Name,
+		EnvBranch: s.Environment.BranchName,
+		N:         n,
+		Date:      s.StartedAt,
+	}
+}
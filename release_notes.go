@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// releaseNotesSections maps conventional commit types of MR titles to release notes sections, in order
+var releaseNotesSections = []struct {
+	title string
+	types []string
+}{
+	{"Features", []string{"feat", "feature"}},
+	{"Bug Fixes", []string{"fix", "bugfix", "hotfix"}},
+	{"Performance", []string{"perf"}},
+	{"Refactoring", []string{"refactor"}},
+	{"Documentation", []string{"docs"}},
+	{"Tests", []string{"test", "tests"}},
+	{"Maintenance", []string{"chore", "build", "ci", "style"}},
+}
+
+// releaseNotesOtherSection is the section of MRs without a known type or labels
+const releaseNotesOtherSection = "Other Changes"
+
+// conventionalTitleRe matches a conventional commit title: type(scope)!: subject
+var conventionalTitleRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?!?:\s*(.+)$`)
+
+// releaseNotesSection returns the section of an MR and its title without the conventional
+// commit type. MRs are grouped by type, then by their first label.
+func releaseNotesSection(mr *MergeRequestDetails) (section, title string) {
+	if match := conventionalTitleRe.FindStringSubmatch(mr.Title); match != nil {
+		for _, s := range releaseNotesSections {
+			for _, t := range s.types {
+				if strings.EqualFold(match[1], t) {
+					title = match[3]
+					if match[2] != "" {
+						title = "**" + match[2] + ":** " + title
+					}
+					return s.title, title
+				}
+			}
+		}
+	}
+	if len(mr.Labels) > 0 {
+		return mr.Labels[0], mr.Title
+	}
+	return releaseNotesOtherSection, mr.Title
+}
+
+// BuildReleaseNotes builds Markdown release notes of the MRs grouped into sections. Each MR
+// lists its author, labels, diff stats and linked issues with the fetched details, if any.
+func BuildReleaseNotes(mrs []*MergeRequestDetails, rules []TrackerRule, issues map[string]*TrackerIssueResult) string {
+	return formatReleaseNotes(BuildReleaseNoteEntries(mrs, rules, issues))
+}
+
+// BuildReleaseNoteEntries builds the release notes items of the MRs, in MR order
+func BuildReleaseNoteEntries(mrs []*MergeRequestDetails, rules []TrackerRule, issues map[string]*TrackerIssueResult) []ReleaseNoteEntry {
+	notes := make([]ReleaseNoteEntry, 0, len(mrs))
+	for _, mr := range mrs {
+		section, title := releaseNotesSection(mr)
+		notes = append(notes, ReleaseNoteEntry{IID: mr.IID, Section: section, Text: releaseNotesEntry(mr, title, rules, issues)})
+	}
+	return notes
+}
+
+// formatReleaseNotes builds the Markdown release notes of the items grouped into sections
+func formatReleaseNotes(notes []ReleaseNoteEntry) string {
+	if len(notes) == 0 {
+		return "No merge requests in this release.\n"
+	}
+
+	entries := make(map[string][]string)
+	for _, note := range notes {
+		entries[note.Section] = append(entries[note.Section], note.Text)
+	}
+
+	// Type sections first, then label sections alphabetically, then the rest
+	var order []string
+	for _, s := range releaseNotesSections {
+		order = append(order, s.title)
+	}
+	var labels []string
+	for section := range entries {
+		if section != releaseNotesOtherSection && !slices.Contains(order, section) {
+			labels = append(labels, section)
+		}
+	}
+	sort.Strings(labels)
+	order = append(append(order, labels...), releaseNotesOtherSection)
+
+	var b strings.Builder
+	if len(notes) == 1 {
+		b.WriteString("1 merge request\n")
+	} else {
+		fmt.Fprintf(&b, "%d merge requests\n", len(notes))
+	}
+	for _, section := range order {
+		if len(entries[section]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", section)
+		for _, entry := range entries[section] {
+			b.WriteString(entry)
+		}
+	}
+	return b.String()
+}
+
+// releaseNotesEntry renders the list item of an MR with its linked issues as nested items
+func releaseNotesEntry(mr *MergeRequestDetails, title string, rules []TrackerRule, issues map[string]*TrackerIssueResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "- %s ([!%d](%s)) by @%s", title, mr.IID, mr.WebURL, mr.Author.Username)
+
+	var stats []string
+	if mr.CommitsCount > 0 {
+		stats = append(stats, fmt.Sprintf("%d commits", mr.CommitsCount))
+	}
+	if mr.ChangesCount != "" && mr.ChangesCount != "0" {
+		stats = append(stats, mr.ChangesCount+" files")
+	}
+	if mr.DiffStats.Additions > 0 || mr.DiffStats.Deletions > 0 {
+		stats = append(stats, fmt.Sprintf("+%d -%d", mr.DiffStats.Additions, mr.DiffStats.Deletions))
+	}
+	if len(stats) > 0 {
+		b.WriteString(", " + strings.Join(stats, ", "))
+	}
+	for _, label := range mr.Labels {
+		b.WriteString(" `" + label + "`")
+	}
+	b.WriteString("\n")
+
+	for _, issue := range findTrackerIssues(rules, mr.SourceBranch, mr.Title) {
+		fmt.Fprintf(&b, "  - [%s](%s)", issue.Key, issue.URL)
+		if result := issues[issue.Key]; result != nil && result.Details != nil {
+			fmt.Fprintf(&b, " %s `%s`", result.Details.Title, result.Details.Status)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// selectedMRDetails returns the MRs selected for release in list order
+func (m model) selectedMRDetails() []*MergeRequestDetails {
+	var mrs []*MergeRequestDetails
+	for _, item := range m.list.Items() {
+		if mr, ok := item.(mrListItem); ok && m.selectedMRs[mr.MR().IID] {
+			mrs = append(mrs, mr.MR())
+		}
+	}
+	return mrs
+}
+
+// releaseNotes builds the release notes of the selected MRs with the issue details fetched so far
+func (m model) releaseNotes() string {
+	return formatReleaseNotes(m.releaseNoteEntries())
+}
+
+// releaseNoteEntries builds the release notes items of the selected MRs
+func (m model) releaseNoteEntries() []ReleaseNoteEntry {
+	return BuildReleaseNoteEntries(m.selectedMRDetails(), m.trackerRules, m.trackerIssues)
+}
+
+// dropReleaseNotes removes the release notes item of a dropped MR and rebuilds the notes,
+// so the env MR description, GitLab Release, history and changelog don't list it
+func dropReleaseNotes(state *ReleaseState, iid int) {
+	if state.ReleaseNoteEntries == nil {
+		return
+	}
+	state.ReleaseNoteEntries = slices.DeleteFunc(state.ReleaseNoteEntries, func(note ReleaseNoteEntry) bool {
+		return note.IID == iid
+	})
+	state.ReleaseNotes = formatReleaseNotes(state.ReleaseNoteEntries)
+}
+
+// changelogHeading returns the CHANGELOG.md section heading of a release
+func changelogHeading(entry *ReleaseHistoryEntry) string {
+	name := entry.TagName
+	if name == "" {
+		name = entry.Version + " " + entry.Environment
+	}
+	return fmt.Sprintf("## %s (%s)", name, entry.DateTime.Format(namingDateLayout))
+}
+
+// WriteChangelogSection adds a section to a changelog file above its previous sections,
+// creating the file if missing. A section with the same heading is replaced.
+func WriteChangelogSection(path, heading, notes string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data, err = []byte("# Changelog\n"), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	start, end := slices.Index(lines, heading), -1
+	if start < 0 {
+		// Insert above the first section, or at the end without sections
+		start = slices.IndexFunc(lines, func(line string) bool { return strings.HasPrefix(line, "## ") })
+		if start < 0 {
+			start = len(lines)
+		}
+		end = start
+	} else {
+		end = slices.IndexFunc(lines[start+1:], func(line string) bool { return strings.HasPrefix(line, "## ") })
+		if end < 0 {
+			end = len(lines)
+		} else {
+			end += start + 1
+		}
+	}
+
+	var parts []string
+	if head := strings.TrimRight(strings.Join(lines[:start], "\n"), "\n"); head != "" {
+		parts = append(parts, head)
+	}
+	parts = append(parts, heading+"\n\n"+strings.TrimRight(notes, "\n"))
+	if tail := strings.Trim(strings.Join(lines[end:], "\n"), "\n"); tail != "" {
+		parts = append(parts, tail)
+	}
+	return os.WriteFile(path, []byte(strings.Join(parts, "\n\n")+"\n"), 0644)
+}
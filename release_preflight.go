@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// PreflightStatus is the outcome of a pre-flight check of an MR
+type PreflightStatus int
+
+const (
+	PreflightPass PreflightStatus = iota
+	PreflightWarn                 // Not confirmed: data unavailable or still in progress, never blocks
+	PreflightFail                 // Blocks the release if the environment policy lists the check
+)
+
+// Pre-flight checks, as listed in the preflight_blocking environment setting
+const (
+	preflightApprovals   = "approvals"
+	preflightPipeline    = "pipeline"
+	preflightDiscussions = "discussions"
+	preflightDraft       = "draft"
+	preflightHead        = "head"
+)
+
+// preflightChecks lists the pre-flight checks in table order
+var preflightChecks = []string{preflightApprovals, preflightPipeline, preflightDiscussions, preflightDraft, preflightHead}
+
+// PreflightResult is the outcome of one check of an MR
+type PreflightResult struct {
+	Check  string
+	Status PreflightStatus
+	Detail string
+}
+
+// PreflightMR holds the pre-flight results of an MR in preflightChecks order
+type PreflightMR struct {
+	IID     int
+	Branch  string
+	Results []PreflightResult
+}
+
+// runPreflight fetches the current state of the selected MRs and checks them. The MR heads
+// are compared with the SHAs the MRs had when selected.
+func runPreflight(client *GitLabClient, projectID int, selected []*MergeRequestDetails) []PreflightMR {
+	results := make([]PreflightMR, len(selected))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(gitLabDetailWorkers, len(selected)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				mr := selected[i]
+				current, err := client.GetMergeRequest(projectID, mr.IID)
+				approvals, approvalsErr := client.GetMergeRequestApprovals(projectID, mr.IID)
+				discussions, discussionsErr := client.GetMergeRequestDiscussions(projectID, mr.IID)
+				results[i] = PreflightMR{
+					IID:    mr.IID,
+					Branch: mr.SourceBranch,
+					Results: []PreflightResult{
+						checkApprovals(approvals, approvalsErr),
+						checkPipeline(current, err),
+						checkDiscussions(current, err, discussions, discussionsErr),
+						checkDraft(current, err),
+						checkHead(mr.SHA, current, err),
+					},
+				}
+			}
+		}()
+	}
+	for i := range selected {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// preflightUnavailable returns the warning of a check whose data could not be fetched
+func preflightUnavailable(check string, err error) PreflightResult {
+	return PreflightResult{Check: check, Status: PreflightWarn, Detail: "unavailable: " + gitLabErrorLabel(err)}
+}
+
+// checkApprovals fails an MR missing required approvals
+func checkApprovals(approvals *Approvals, err error) PreflightResult {
+	if err != nil {
+		return preflightUnavailable(preflightApprovals, err)
+	}
+	if !approvals.Approved || approvals.ApprovalsLeft > 0 {
+		return PreflightResult{Check: preflightApprovals, Status: PreflightFail,
+			Detail: fmt.Sprintf("%d of %d required approvals missing", approvals.ApprovalsLeft, approvals.ApprovalsRequired)}
+	}
+	if len(approvals.ApprovedBy) == 0 {
+		return PreflightResult{Check: preflightApprovals, Status: PreflightPass, Detail: "no approvals required"}
+	}
+	var names []string
+	for _, approver := range approvals.ApprovedBy {
+		names = append(names, approver.User.Username)
+	}
+	return PreflightResult{Check: preflightApprovals, Status: PreflightPass, Detail: "approved by " + strings.Join(names, ", ")}
+}
+
+// checkPipeline fails an MR whose head pipeline failed and warns while it is not finished
+func checkPipeline(mr *MergeRequestDetails, err error) PreflightResult {
+	if err != nil {
+		return preflightUnavailable(preflightPipeline, err)
+	}
+	pipeline := mr.HeadPipeline
+	if pipeline == nil {
+		return PreflightResult{Check: preflightPipeline, Status: PreflightWarn, Detail: "no pipeline"}
+	}
+	detail := fmt.Sprintf("#%d %s", pipeline.ID, pipeline.Status)
+	if pipeline.SHA != "" && pipeline.SHA != mr.SHA {
+		return PreflightResult{Check: preflightPipeline, Status: PreflightWarn, Detail: detail + " of an older commit"}
+	}
+	switch pipeline.Status {
+	case "success":
+		return PreflightResult{Check: preflightPipeline, Status: PreflightPass, Detail: detail}
+	case "failed", "canceled":
+		return PreflightResult{Check: preflightPipeline, Status: PreflightFail, Detail: detail}
+	default:
+		return PreflightResult{Check: preflightPipeline, Status: PreflightWarn, Detail: detail}
+	}
+}
+
+// checkDiscussions fails an MR with unresolved threads. Without its threads the MR's
+// blocking discussions flag is used.
+func checkDiscussions(mr *MergeRequestDetails, mrErr error, discussions []Discussion, err error) PreflightResult {
+	if err != nil {
+		if mrErr == nil && !mr.BlockingDiscussionsResolved {
+			return PreflightResult{Check: preflightDiscussions, Status: PreflightFail, Detail: "blocking discussions unresolved"}
+		}
+		return preflightUnavailable(preflightDiscussions, err)
+	}
+	total, unresolved := 0, 0
+	for _, discussion := range discussions {
+		if len(discussion.Notes) == 0 || !discussion.Notes[0].Resolvable {
+			continue
+		}
+		total++
+		if !discussion.Notes[0].Resolved {
+			unresolved++
+		}
+	}
+	switch {
+	case unresolved > 0:
+		return PreflightResult{Check: preflightDiscussions, Status: PreflightFail,
+			Detail: fmt.Sprintf("%d of %d threads unresolved", unresolved, total)}
+	case total == 0:
+		return PreflightResult{Check: preflightDiscussions, Status: PreflightPass, Detail: "no threads"}
+	}
+	return PreflightResult{Check: preflightDiscussions, Status: PreflightPass, Detail: fmt.Sprintf("%d threads resolved", total)}
+}
+
+// checkDraft fails a draft MR
+func checkDraft(mr *MergeRequestDetails, err error) PreflightResult {
+	if err != nil {
+		return preflightUnavailable(preflightDraft, err)
+	}
+	if mr.Draft {
+		return PreflightResult{Check: preflightDraft, Status: PreflightFail, Detail: "marked as draft"}
+	}
+	return PreflightResult{Check: preflightDraft, Status: PreflightPass, Detail: "ready"}
+}
+
+// checkHead fails an MR whose head moved since it was selected: the release would ship
+// commits that were not reviewed with the selection
+func checkHead(selectedSHA string, mr *MergeRequestDetails, err error) PreflightResult {
+	if err != nil {
+		return preflightUnavailable(preflightHead, err)
+	}
+	switch {
+	case mr.State != "opened":
+		return PreflightResult{Check: preflightHead, Status: PreflightFail, Detail: "MR is " + mr.State}
+	case selectedSHA == "":
+		return PreflightResult{Check: preflightHead, Status: PreflightWarn, Detail: "head at selection unknown"}
+	case mr.SHA != selectedSHA:
+		return PreflightResult{Check: preflightHead, Status: PreflightFail,
+			Detail: fmt.Sprintf("moved %s → %s", shortSHA(selectedSHA), shortSHA(mr.SHA))}
+	}
+	return PreflightResult{Check: preflightHead, Status: PreflightPass, Detail: "unchanged at " + shortSHA(mr.SHA)}
+}
+
+// preflightBlockingChecks returns the checks whose failures block releases to the
+// environment; "all" lists every check
+func (e EnvConfig) preflightBlockingChecks() []string {
+	var checks []string
+	for _, check := range strings.Split(e.PreflightBlocking, ",") {
+		check = strings.ToLower(strings.TrimSpace(check))
+		if check == "all" {
+			return preflightChecks
+		}
+		if check != "" {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// preflightBlocks reports whether a failed check blocks releases to the environment, env may be nil
+func preflightBlocks(env *EnvConfig, check string) bool {
+	if env == nil {
+		return false
+	}
+	for _, blocking := range env.preflightBlockingChecks() {
+		if blocking == check {
+			return true
+		}
+	}
+	return false
+}
+
+// preflightBlockers returns the failed checks blocking releases to the environment as
+// "!iid check: detail" lines
+func preflightBlockers(results []PreflightMR, env *EnvConfig) []string {
+	var blockers []string
+	for _, mr := range results {
+		for _, result := range mr.Results {
+			if result.Status == PreflightFail && preflightBlocks(env, result.Check) {
+				blockers = append(blockers, fmt.Sprintf("!%d %s: %s", mr.IID, result.Check, result.Detail))
+			}
+		}
+	}
+	return blockers
+}
+
+// validatePreflightBlocking returns an error message if the setting lists unknown checks
+func validatePreflightBlocking(value string) string {
+	for _, check := range strings.Split(value, ",") {
+		check = strings.ToLower(strings.TrimSpace(check))
+		if check == "" || check == "all" {
+			continue
+		}
+		known := false
+		for _, c := range preflightChecks {
+			known = known || c == check
+		}
+		if !known {
+			return fmt.Sprintf("unknown pre-flight check %q, expected %s or all", check, strings.Join(preflightChecks, ", "))
+		}
+	}
+	return ""
+}
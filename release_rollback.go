@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// rollbackReleases picks the releases of a rollback started on the history entry at index:
+// the entry itself is redeployed over the latest completed release of its environment or,
+// when it is the latest one, the completed release before it is redeployed over it.
+// entries are ordered newest first; releases of other projects are ignored.
+func rollbackReleases(entries []HistoryIndexEntry, index, projectID int) (restored, reverted *HistoryIndexEntry, err error) {
+	selected := entries[index]
+	if selected.Status != "completed" {
+		return nil, nil, fmt.Errorf("only completed releases can be redeployed")
+	}
+	if selected.ProjectID != 0 && selected.ProjectID != projectID {
+		return nil, nil, fmt.Errorf("the release belongs to another project")
+	}
+
+	var completed []*HistoryIndexEntry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Status == "completed" && entry.Environment == selected.Environment &&
+			(entry.ProjectID == 0 || entry.ProjectID == projectID) {
+			completed = append(completed, entry)
+		}
+	}
+
+	if completed[0].ID != selected.ID {
+		return &entries[index], completed[0], nil
+	}
+	if len(completed) < 2 {
+		return nil, nil, fmt.Errorf("%s has no previous release to roll back to", selected.Environment)
+	}
+	return completed[1], completed[0], nil
+}
+
+// newRollbackState creates the release state redeploying the content the restored release
+// deployed to its environment, as a new env release branch and MR
+func newRollbackState(restored, reverted *ReleaseHistoryEntry, env Environment, project *Project, workDir string) (*ReleaseState, error) {
+	if restored.EnvReleaseSHA == "" {
+		return nil, fmt.Errorf("the environment commit of %s was not recorded", historyReleaseName(restored))
+	}
+
+	rollback := &Rollback{
+		RestoredID: restored.ID,
+		Restored:   historyReleaseName(restored),
+		SHA:        restored.EnvReleaseSHA,
+	}
+	if reverted != nil {
+		rollback.RevertedID = reverted.ID
+		rollback.Reverted = historyReleaseName(reverted)
+	}
+
+	state := &ReleaseState{
+		Environment:     env,
+		Version:         restored.Version,
+		BaseBranch:      getBaseBranch(),
+		EnvMergeMode:    "squash",
+		ProjectID:       project.ID,
+		ProjectName:     project.Name,
+		ProjectPath:     project.PathWithNamespace,
+		Naming:          getNamingTemplates(),
+		StartedAt:       time.Now(),
+		Rollback:        rollback,
+		CurrentStep:     ReleaseStepGitFetch,
+		LastSuccessStep: ReleaseStepIdle,
+		MergedBranches:  []string{},
+		WorkDir:         workDir,
+	}
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+	return state, nil
+}
+
+// rollbackBranch returns the env release branch of a rollback, named after the restored
+// version and the rollback start so that repeated rollbacks don't collide
+func (s *ReleaseState) rollbackBranch() string {
+	return fmt.Sprintf("rollback/%s-%s-%s", s.Environment.BranchName, s.Version, s.StartedAt.Format("20060102-150405"))
+}
+
+// rollbackMessage returns the commit and MR title and description of a rollback
+func (s *ReleaseState) rollbackMessage() (string, string) {
+	rollback := s.Rollback
+	title := fmt.Sprintf("rollback: %s to %s", s.Environment.BranchName, rollback.Restored)
+
+	var body strings.Builder
+	if rollback.Reverted != "" {
+		fmt.Fprintf(&body, "Rolls %s back from %s to %s.\n\n", s.Environment.Name, rollback.Reverted, rollback.Restored)
+	} else {
+		fmt.Fprintf(&body, "Rolls %s back to %s.\n\n", s.Environment.Name, rollback.Restored)
+	}
+	fmt.Fprintf(&body, "Restores the content deployed by %s (%s).", rollback.Restored, shortSHA(rollback.SHA))
+	return title, body.String()
+}
+
+// restoreRollbackTree replaces the content of the env release branch with the tree of the
+// restored release commit, staging the difference for the rollback commit
+func (e *ReleaseEngine) restoreRollbackTree(subStepDone func()) (string, error) {
+	state := e.state
+	output, err := e.git.RunCommand(fmt.Sprintf("git checkout %s", e.EnvReleaseBranch()))
+	if err != nil {
+		return output, err
+	}
+	if _, err := e.git.RunCommand(fmt.Sprintf("git cat-file -e %s^{tree}", state.Rollback.SHA)); err != nil {
+		return output, fmt.Errorf("commit %s of %s is not available locally", shortSHA(state.Rollback.SHA), state.Rollback.Restored)
+	}
+	subStepDone()
+
+	rmOutput, err := e.git.RunCommand("git rm -rf .")
+	output += rmOutput
+	if err != nil {
+		return output, err
+	}
+	subStepDone()
+
+	checkoutOutput, err := e.git.RunCommand(fmt.Sprintf("git checkout %s -- .", state.Rollback.SHA))
+	output += checkoutOutput
+	if err != nil {
+		return output, err
+	}
+	if _, err := e.git.RunCommand("git diff --cached --quiet"); err == nil {
+		return output, fmt.Errorf("%s already has the content of %s, nothing to roll back", state.Environment.Name, state.Rollback.Restored)
+	}
+	subStepDone()
+	return output, nil
+}
+
+// markRolledBack records on the history entry of the rolled back release the rollback undoing it
+func markRolledBack(id, rollback string) error {
+	entry, err := LoadHistoryDetail(id)
+	if err != nil {
+		return err
+	}
+	entry.RolledBackBy = rollback
+	return writeHistoryDetail(entry)
+}
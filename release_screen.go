@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -70,13 +72,23 @@ var (
 
 // calculateReleaseTotalSteps returns the total number of substeps for the release
 func calculateReleaseTotalSteps(state *ReleaseState) int {
-	total := 1                     // GitFetch
-	total += 1                     // CheckoutRoot
-	total += len(state.MRBranches) // MergeBranches (one per MR)
-	total += 1                     // CheckoutEnv
+	if state.Rollback != nil {
+		// GitFetch, CheckoutEnv, CopyContent (checkout + rm all + checkout restored tree),
+		// Commit, Push env branch, Create MR (API), SwitchToRoot
+		return 9
+	}
+	total := 1 // GitFetch
+	total += 1 // CheckoutRoot
+	if state.PromotedFrom == "" {
+		total += len(state.MRBranches) // MergeBranches (one per MR)
+	}
+	total += 1 // CheckoutEnv
 	if state.EnvMergeMode == "regular" {
 		total += 1 // CopyContent: just git merge
 		// No Commit step — merge creates commit automatically
+	} else if state.EnvMergeMode == "cherry-pick" {
+		total += cherryPickSubSteps(state) // CopyContent: one per picked commit
+		// No Commit step — picks create their own commits
 	} else {
 		total += 3 // CopyContent (checkout env-release + rm all + checkout from root)
 		total += 1 // Commit
@@ -88,6 +100,9 @@ func calculateReleaseTotalSteps(state *ReleaseState) int {
 	} else {
 		total += 3 // checkout release-root, tag, push with tags
 	}
+	if state.GitLabRelease {
+		total += 1 // Create GitLab release (API)
+	}
 	total += 1 // SwitchToRoot
 	return total
 }
@@ -134,6 +149,21 @@ func (m *model) updateReleaseButtons() {
 		m.releaseButtons = append(m.releaseButtons, ReleaseButtonRetry)
 	}
 
+	// Resolve opens the conflict screen while a merge is suspended on conflicts
+	if state.LastError != nil && DetectMergeConflict(state.WorkDir) {
+		m.releaseButtons = append(m.releaseButtons, ReleaseButtonResolve)
+	}
+
+	// Move tag asks to move the release tag that exists on origin at another commit
+	if m.tagCollision() != nil {
+		m.releaseButtons = append(m.releaseButtons, ReleaseButtonMoveTag)
+	}
+
+	// Pending MRs can be skipped or reordered while suspended before the merge step is done
+	if m.canEditMRQueue() {
+		m.releaseButtons = append(m.releaseButtons, ReleaseButtonQueue)
+	}
+
 	// Create MR is available at step 5 (waiting) and no error
 	if state.CurrentStep == ReleaseStepWaitForMR && state.LastError == nil {
 		m.releaseButtons = append(m.releaseButtons, ReleaseButtonCreateMR)
@@ -194,6 +224,19 @@ func (m *model) appendReleaseOutput(line string) {
 	m.updateReleaseViewport()
 }
 
+// flushReleaseScreen moves the streamed virtual terminal screen to the output buffer
+func (m *model) flushReleaseScreen() {
+	if m.releaseCurrentScreen == "" {
+		return
+	}
+	m.releaseOutputBuffer = append(m.releaseOutputBuffer, strings.Split(m.releaseCurrentScreen, "\n")...)
+	// Enforce buffer limit
+	if len(m.releaseOutputBuffer) > maxOutputLines {
+		m.releaseOutputBuffer = m.releaseOutputBuffer[len(m.releaseOutputBuffer)-maxOutputLines:]
+	}
+	m.releaseCurrentScreen = ""
+}
+
 // appendRecoveryMetadata adds recovery metadata to the terminal output at release start
 func (m *model) appendRecoveryMetadata(workDir string, state *ReleaseState) {
 	headerStyle := lipgloss.NewStyle().Foreground(currentTheme.Success)
@@ -234,6 +277,9 @@ func (m *model) appendRecoveryMetadata(workDir string, state *ReleaseState) {
 		originSourceCommit := GetBranchCommitID(workDir, "origin/"+state.SourceBranch)
 		m.releaseOutputBuffer = append(m.releaseOutputBuffer, fmt.Sprintf(format, sourceBranchLabel, originSourceCommit))
 	}
+	if state.InWorktree() {
+		m.releaseOutputBuffer = append(m.releaseOutputBuffer, fmt.Sprintf(format, "worktree:", state.WorkDir))
+	}
 	m.releaseOutputBuffer = append(m.releaseOutputBuffer, "") // Empty line after metadata
 }
 
@@ -244,6 +290,21 @@ func (m model) updateRelease(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateOpenOptionsModal(msg)
 	}
 
+	// Handle merge queue modal
+	if m.showMRQueueModal {
+		return m.updateMRQueueModal(msg)
+	}
+
+	// Handle tag move confirmation modal
+	if m.showTagMoveConfirm {
+		return m.updateTagMoveConfirm(msg)
+	}
+
+	// Handle MR drift prompt
+	if m.showMRDriftPrompt {
+		return m.updateMRDriftPrompt(msg)
+	}
+
 	// Handle delete remote branch confirmation modal (second step after abort confirm)
 	if m.showDeleteRemoteConfirm {
 		switch msg.String() {
@@ -365,6 +426,10 @@ func (m model) updateRelease(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		return m.executeReleaseButton()
 
+	case "ctrl+q":
+		// Leave the release pending and go home (not while a step is running)
+		return m.leaveRelease()
+
 	case "up", "k":
 		m.releaseViewport.LineUp(1)
 		return m, nil
@@ -419,6 +484,19 @@ func (m model) executeReleaseButton() (tea.Model, tea.Cmd) {
 	case ReleaseButtonRetry:
 		return m.retryRelease()
 
+	case ReleaseButtonResolve:
+		return m.openConflictScreen()
+
+	case ReleaseButtonQueue:
+		return m.openMRQueueModal()
+
+	case ReleaseButtonMoveTag:
+		if m.tagCollision() != nil {
+			m.showTagMoveConfirm = true
+			m.tagMoveConfirmIndex = 1
+		}
+		return m, nil
+
 	case ReleaseButtonCreateMR:
 		return m.startCreateMR()
 
@@ -467,6 +545,9 @@ func (m model) viewRelease() string {
 	if m.releaseState != nil && (m.releaseState.CreatedMRURL != "" || (m.pipelineStatus != nil && m.pipelineStatus.PipelineWebURL != "")) {
 		helpText += " • o: open"
 	}
+	if !m.releaseRunning {
+		helpText += " • C+q: home"
+	}
 	helpText += " • /: commands"
 	help := helpStyle.Width(m.width).Align(lipgloss.Center).Render(helpText)
 
@@ -482,6 +563,21 @@ func (m model) viewRelease() string {
 		view = m.overlayDeleteRemoteConfirm(view)
 	}
 
+	// Overlay merge queue modal if shown
+	if m.showMRQueueModal {
+		view = m.overlayMRQueueModal(view)
+	}
+
+	// Overlay tag move confirmation if shown
+	if m.showTagMoveConfirm {
+		view = m.overlayTagMoveConfirm(view)
+	}
+
+	// Overlay MR drift prompt if shown
+	if m.showMRDriftPrompt {
+		view = m.overlayMRDriftPrompt(view)
+	}
+
 	return view
 }
 
@@ -582,6 +678,11 @@ func (m model) renderReleaseStatus(width int) string {
 				errorType = fmt.Sprintf("%s regular merge %s.",
 					releaseOrangeStyle.Render(state.SourceBranch),
 					releaseConflictStyle.Render("CONFLICT"))
+			} else if pick := state.currentCherryPick(); pick != nil {
+				errorType = fmt.Sprintf("%s commit %s cherry-pick %s.",
+					releaseOrangeStyle.Render(pick.Branch),
+					shortSHA(pick.SHA),
+					releaseConflictStyle.Render("CONFLICT"))
 			} else {
 				branchName := ""
 				if state.CurrentMRIndex < len(state.MRBranches) {
@@ -591,19 +692,32 @@ func (m model) renderReleaseStatus(width int) string {
 					releaseOrangeStyle.Render(branchName),
 					releaseConflictStyle.Render("CONFLICT"))
 			}
-			status = fmt.Sprintf("Release is %s on %s because of\n%s\nResolve merge issues and press %s",
+			status = fmt.Sprintf("Release is %s on %s because of\n%s\nPress %s to fix conflicts here, or resolve them in terminal and press %s",
 				releaseSuspendedStyle.Render("SUSPENDED"),
 				releasePercentStyle.Render(progressText),
 				errorType,
+				releaseActiveTextStyle.Render("Resolve"),
 				releaseActiveTextStyle.Render("Retry"),
 			)
+		} else if move := m.tagCollision(); move != nil {
+			// Tag exists on origin at another commit - moving it needs confirmation
+			status = fmt.Sprintf("Release is %s on %s because tag %s\nalready exists on origin at %s, this release is at %s\nPress %s to move it, or %s to keep it",
+				releaseSuspendedStyle.Render("SUSPENDED"),
+				releasePercentStyle.Render(progressText),
+				releaseOrangeStyle.Render(move.Tag),
+				shortSHA(move.OldSHA),
+				shortSHA(move.NewSHA),
+				releaseActiveTextStyle.Render("Move tag"),
+				releaseActiveTextStyle.Render("Abort"),
+			)
 		} else if state.LastError.Code == "COMMIT_FAILED" {
 			// Commit failed (likely linter error) - tell user to fix in release-root branch
 			statusBaseBranch := state.BaseBranch
 			if statusBaseBranch == "" {
 				statusBaseBranch = "root"
 			}
-			cmds := NewReleaseCommandsWithSourceBranch(state.WorkDir, state.Version, statusBaseBranch, &state.Environment, nil, nil, state.SourceBranch, state.SourceBranchIsRemote)
+			cmds := NewReleaseCommandsWithSourceBranch(state.WorkDir, state.Version, statusBaseBranch, &state.Environment, nil, nil, state.SourceBranch, state.SourceBranchIsRemote).
+				WithNaming(state.Naming, state.StartedAt)
 			status = fmt.Sprintf("Release is %s on %s because of\n%s %s\nFix errors in %s branch, commit them and press %s",
 				releaseSuspendedStyle.Render("SUSPENDED"),
 				releasePercentStyle.Render(progressText),
@@ -660,6 +774,24 @@ func (m model) renderReleaseStatus(width int) string {
 			getReleaseEnvStyle(state.Environment.Name).Render(state.Environment.Name))
 
 	case ReleaseStepCopyContent:
+		if pick := state.currentCherryPick(); pick != nil {
+			status = fmt.Sprintf("%s %s %s\nCherry-picking %s commit of %d: %s",
+				m.spinner.View(),
+				getReleaseEnvStyle(state.Environment.Name).Render("RELEASING"),
+				releasePercentStyle.Render(progressText),
+				ordinal(state.CherryPickIndex+1),
+				len(state.CherryPicks),
+				pick.Branch)
+			break
+		}
+		if state.Rollback != nil {
+			status = fmt.Sprintf("%s %s %s\nRestoring content of %s...",
+				m.spinner.View(),
+				getReleaseEnvStyle(state.Environment.Name).Render("RELEASING"),
+				releasePercentStyle.Render(progressText),
+				releaseOrangeStyle.Render(state.Rollback.Restored))
+			break
+		}
 		status = fmt.Sprintf("%s %s %s\nCopying content from root branch...",
 			m.spinner.View(),
 			getReleaseEnvStyle(state.Environment.Name).Render("RELEASING"),
@@ -695,18 +827,24 @@ func (m model) renderReleaseStatus(width int) string {
 
 	case ReleaseStepWaitForRootPush:
 		hintText := m.renderRootPushHint()
+		nextText := "Now you can push release branch to root and develop:"
+		if state.Rollback != nil {
+			nextText = "Now you can finish the rollback:"
+		}
 		pipelineStatus := m.renderPipelineStatus()
 		if pipelineStatus != "" {
-			status = fmt.Sprintf("Merge request is %s %s\n%s\nNow you can push release branch to root and develop:\n%s",
+			status = fmt.Sprintf("Merge request is %s %s\n%s\n%s\n%s",
 				releaseSuccessGreenStyle.Render(" CREATED "),
 				releasePercentStyle.Render(progressText),
 				pipelineStatus,
+				nextText,
 				hintText,
 			)
 		} else {
-			status = fmt.Sprintf("Merge request is %s %s\nNow you can push release branch to root and develop:\n%s",
+			status = fmt.Sprintf("Merge request is %s %s\n%s\n%s",
 				releaseSuccessGreenStyle.Render(" CREATED "),
 				releasePercentStyle.Render(progressText),
+				nextText,
 				hintText,
 			)
 		}
@@ -717,6 +855,12 @@ func (m model) renderReleaseStatus(width int) string {
 			getReleaseEnvStyle(state.Environment.Name).Render("RELEASING"),
 			releasePercentStyle.Render(progressText))
 
+	case ReleaseStepCreateRelease:
+		status = fmt.Sprintf("%s %s %s\nCreating GitLab release...",
+			m.spinner.View(),
+			getReleaseEnvStyle(state.Environment.Name).Render("RELEASING"),
+			releasePercentStyle.Render(progressText))
+
 	case ReleaseStepSwitchToRoot:
 		status = fmt.Sprintf("%s %s %s\nSwitching back to root branch...",
 			m.spinner.View(),
@@ -821,6 +965,27 @@ func (m model) renderReleaseButtons(width int) string {
 			} else {
 				style = buttonStyle
 			}
+		case ReleaseButtonResolve:
+			label = "Resolve"
+			if isFocused {
+				style = buttonActiveStyle
+			} else {
+				style = buttonStyle
+			}
+		case ReleaseButtonQueue:
+			label = "Edit MRs"
+			if isFocused {
+				style = buttonActiveStyle
+			} else {
+				style = buttonStyle
+			}
+		case ReleaseButtonMoveTag:
+			label = "Move tag"
+			if isFocused {
+				style = buttonDangerStyle
+			} else {
+				style = buttonStyle
+			}
 		}
 
 		buttons = append(buttons, style.Render(label))
@@ -901,20 +1066,90 @@ func (m *model) startRelease() (tea.Model, tea.Cmd) {
 		m.errorModalMsg = fmt.Sprintf("Failed to find project root: %v", err)
 		return m, nil
 	}
+	return m.beginRelease(m.buildReleaseState(workDir))
+}
+
+// beginRelease checks that the release can run, locks it and launches it, preparing its
+// dedicated worktree first if configured
+func (m *model) beginRelease(state *ReleaseState) (tea.Model, tea.Cmd) {
+	workDir := state.WorkDir
+
+	// One release per project and environment at a time
+	if existing, err := LoadReleaseState(state.ProjectID, state.Environment.BranchName); err == nil && existing != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = fmt.Sprintf("Release %s to %s is already in progress. Resume or abort it from the home screen first.",
+			existing.Version, existing.Environment.Name)
+		return m, nil
+	}
+	// Locked before the worktree is reset, so another relix process can't reset it under a running release
+	if !m.lockRelease(state) {
+		return m, nil
+	}
+
+	// The dedicated worktree is prepared in the background, the user's checkout may stay dirty
+	if config, err := LoadConfig(); err == nil && config.ReleaseInWorktree {
+		m.confirmPreparingWorktree = true
+		return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+			err := UseReleaseWorktree(state)
+			return releaseWorktreeMsg{state: state, err: err}
+		})
+	}
 
 	// Check for uncommitted changes
 	hasChanges, err := HasUncommittedChanges(workDir)
 	if err != nil {
+		m.unlockRelease()
 		m.showErrorModal = true
 		m.errorModalMsg = fmt.Sprintf("Failed to check git status: %v", err)
 		return m, nil
 	}
 	if hasChanges {
+		m.unlockRelease()
 		m.showErrorModal = true
 		m.errorModalMsg = "Cannot start release: there are uncommitted changes in the working directory"
 		return m, nil
 	}
 
+	return m.launchRelease(state)
+}
+
+// lockRelease locks the release for this process in place of the current one.
+// Shows the error and returns false if another relix process drives it.
+func (m *model) lockRelease(state *ReleaseState) bool {
+	lock, err := AcquireReleaseLock(state)
+	if err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = err.Error()
+		return false
+	}
+	m.unlockRelease()
+	m.releaseLock = lock
+	return true
+}
+
+// launchRelease switches to the release screen and starts executing the locked release
+func (m *model) launchRelease(state *ReleaseState) (tea.Model, tea.Cmd) {
+	m.promotion = nil
+	m.releaseState = state
+	m.screen = screenRelease
+	m.releaseOutputBuffer = []string{}
+	m.releaseCurrentScreen = ""
+
+	// Add recovery metadata to terminal output
+	m.appendRecoveryMetadata(state.WorkDir, state)
+
+	m.initReleaseScreen()
+
+	// Save initial state (includes recovery metadata in terminal output)
+	SaveReleaseState(state)
+
+	// Start execution with spinner
+	m.releaseRunning = true
+	return m, tea.Batch(m.spinner.Tick, m.executeReleaseStep(ReleaseStepGitFetch))
+}
+
+// buildReleaseState creates the initial release state from the selections of the release wizard
+func (m model) buildReleaseState(workDir string) *ReleaseState {
 	// Collect selected MR branches
 	items := m.list.Items()
 	var mrIIDs []int
@@ -935,10 +1170,8 @@ func (m *model) startRelease() (tea.Model, tea.Cmd) {
 	// Determine if source branch exists remotely based on the check status
 	sourceBranchIsRemote := m.sourceBranchRemoteStatus == "exists-same" || m.sourceBranchRemoteStatus == "exists-diff" || m.sourceBranchRemoteStatus == "exists"
 
-	envMergeMode := "squash"
-	if m.envMergeSelection == 1 {
-		envMergeMode = "regular"
-	}
+	envMergeMode := envMergeModes[m.envMergeSelection]
+	notes := m.releaseNoteEntries()
 
 	// Create release state
 	state := &ReleaseState{
@@ -954,299 +1187,120 @@ func (m *model) startRelease() (tea.Model, tea.Cmd) {
 		RootMerge:            m.rootMergeSelection,
 		EnvMergeMode:         envMergeMode,
 		ProjectID:            m.selectedProject.ID,
+		ProjectName:          m.selectedProject.Name,
+		ProjectPath:          m.selectedProject.PathWithNamespace,
+		Naming:               getNamingTemplates(),
+		StartedAt:            time.Now(),
+		ReleaseNotes:         formatReleaseNotes(notes),
+		ReleaseNoteEntries:   notes,
+		GitLabRelease:        getGitLabRelease(),
 		CurrentStep:          ReleaseStepGitFetch,
 		LastSuccessStep:      ReleaseStepIdle,
 		MergedBranches:       []string{},
 		WorkDir:              workDir,
 	}
 
+	m.applyPromotion(state)
+
 	state.TotalSubSteps = calculateReleaseTotalSteps(state)
 	state.CompletedSubSteps = 0
-
-	m.releaseState = state
-	m.screen = screenRelease
-	m.releaseOutputBuffer = []string{}
-	m.releaseCurrentScreen = ""
-
-	// Add recovery metadata to terminal output
-	m.appendRecoveryMetadata(workDir, state)
-
-	m.initReleaseScreen()
-
-	// Save initial state (includes recovery metadata in terminal output)
-	SaveReleaseState(state)
-
-	// Start execution with spinner
-	m.releaseRunning = true
-	return m, tea.Batch(m.spinner.Tick, m.executeReleaseStep(ReleaseStepGitFetch))
+	return state
 }
 
-// executeReleaseStep runs the appropriate command for a step
-func (m *model) executeReleaseStep(step ReleaseStep) tea.Cmd {
-	return func() tea.Msg {
-		if m.releaseState == nil {
-			return releaseStepCompleteMsg{step: step, err: fmt.Errorf("no release state")}
+// releaseEngine returns an engine driving the current release state.
+// Git output is streamed to the release terminal and substep progress is forwarded as messages.
+func (m *model) releaseEngine() *ReleaseEngine {
+	executor := NewGitExecutor(m.releaseState.WorkDir, m.program) // Pass program for real-time output
+
+	// Set executor size based on viewport dimensions
+	// Calculate width: total width - sidebar - content padding - viewport padding
+	if m.width > 0 {
+		sidebarW := sidebarWidth(m.width)
+		terminalWidth := m.width - sidebarW - 4 - 4 // content padding, viewport padding
+		if terminalWidth < 40 {
+			terminalWidth = 40
 		}
-
-		state := m.releaseState
-		workDir := state.WorkDir
-
-		// Load config for exclude patterns
-		config, _ := LoadConfig()
-		patterns := strings.Split(config.ExcludePatterns, "\n")
-
-		baseBranch := state.BaseBranch
-		if baseBranch == "" {
-			baseBranch = "root"
+		terminalHeight := m.releaseViewport.Height
+		if terminalHeight < 10 {
+			terminalHeight = 10
 		}
-		cmds := NewReleaseCommandsWithSourceBranch(workDir, state.Version, baseBranch, &state.Environment, patterns, state.MRBranches, state.SourceBranch, state.SourceBranchIsRemote)
-
-		var command string
-		var output string
-		var err error
+		executor.SetSize(uint16(terminalWidth), uint16(terminalHeight))
+	}
 
-		executor := NewGitExecutor(workDir, m.program) // Pass program for real-time output
+	var gitlab ReleaseGitLab
+	if m.creds != nil {
+		// Cancelled when the release is left, see unlockRelease
+		gitlab = m.sharedGitLabClient("release")
+	}
 
-		// Set executor size based on viewport dimensions
-		// Calculate width: total width - sidebar - content padding - viewport padding
-		if m.width > 0 {
-			sidebarW := sidebarWidth(m.width)
-			terminalWidth := m.width - sidebarW - 4 - 4 // content padding, viewport padding
-			if terminalWidth < 40 {
-				terminalWidth = 40
-			}
-			terminalHeight := m.releaseViewport.Height
-			if terminalHeight < 10 {
-				terminalHeight = 10
-			}
-			executor.SetSize(uint16(terminalWidth), uint16(terminalHeight))
+	engine := NewReleaseEngine(m.releaseState, executor, gitlab)
+	program := m.program
+	engine.Subscribe(func(ev ReleaseEvent) {
+		// Steps run in a goroutine, their progress is applied to the model in Update
+		if program == nil {
+			return
 		}
+		switch ev := ev.(type) {
+		case ReleaseStepStartedEvent:
+			program.Send(releaseStepStartedMsg{step: ev.Step})
+		case ReleaseSubStepDoneEvent:
+			program.Send(releaseSubStepDoneMsg{})
+		}
+	})
+	return engine
+}
 
-		switch step {
-		case ReleaseStepGitFetch:
-			output, err = executor.RunCommand(cmds.StepGitFetch())
-
-		case ReleaseStepCheckoutRoot:
-			output, err = executor.RunCommands(cmds.Step1CheckoutRoot())
-
-		case ReleaseStepMergeBranches:
-			// Check if we need to continue a merge
-			if DetectMergeConflict(workDir) {
-				command = "GIT_EDITOR=true git merge --continue"
-			} else if state.CurrentMRIndex < len(state.MRBranches) {
-				// Check if branch already merged
-				branch := state.MRBranches[state.CurrentMRIndex]
-				merged, _ := IsBranchMerged(workDir, "origin/"+branch)
-				if merged {
-					// Already merged, move to next
-					return releaseStepCompleteMsg{step: step, err: nil, output: fmt.Sprintf("Branch %s already merged\n", branch)}
-				}
-				command = cmds.Step2MergeBranch(state.CurrentMRIndex)
-			}
-			if command != "" {
-				output, err = executor.RunCommand(command)
-			}
-
-		case ReleaseStepCheckoutEnv:
-			// Check if remote env branch exists
-			if !RemoteBranchExists(workDir, state.Environment.BranchName) {
-				return releaseStepCompleteMsg{
-					step:   step,
-					err:    fmt.Errorf("remote branch origin/%s does not exist", state.Environment.BranchName),
-					output: "",
-				}
-			}
-			output, err = executor.RunCommands(cmds.Step3CheckoutEnv())
-
-		case ReleaseStepCopyContent:
-			envReleaseBranch := cmds.EnvReleaseBranch()
-
-			if state.EnvMergeMode == "regular" {
-				// Regular merge mode: merge source branch into env release branch
-				if DetectMergeConflict(workDir) {
-					// Continue a previously conflicted merge
-					command = "GIT_EDITOR=true git merge --continue"
-					output, err = executor.RunCommand(command)
-				} else {
-					// Ensure we're on the env release branch
-					checkoutOutput, checkoutErr := executor.RunCommand(fmt.Sprintf("git checkout %s", envReleaseBranch))
-					if checkoutErr != nil {
-						return releaseStepCompleteMsg{step: step, err: checkoutErr, output: checkoutOutput}
-					}
-					// Merge source branch
-					mergeCmd := fmt.Sprintf("GIT_EDITOR=true git merge --no-edit %s", state.SourceBranch)
-					mergeOutput, mergeErr := executor.RunCommand(mergeCmd)
-					output = checkoutOutput + mergeOutput
-					err = mergeErr
-				}
-				if err == nil {
-					m.program.Send(releaseSubStepDoneMsg{})
-				}
-			} else {
-				// Squash mode (default): existing content copy behavior
-				// First, ensure we're on the env-release-branch (needed when retrying after commit failure)
-				checkoutOutput, checkoutErr := executor.RunCommand(fmt.Sprintf("git checkout %s", envReleaseBranch))
-				if checkoutErr != nil {
-					return releaseStepCompleteMsg{step: step, err: checkoutErr, output: checkoutOutput}
-				}
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Step 4.1: Remove all files
-				output1, err1 := executor.RunCommand(cmds.Step4RemoveAll())
-				if err1 != nil {
-					return releaseStepCompleteMsg{step: step, err: err1, output: checkoutOutput + output1}
-				}
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Step 4.2: Checkout from root
-				output2, err2 := executor.RunCommand(cmds.Step4CheckoutFromRoot())
-				if err2 != nil {
-					return releaseStepCompleteMsg{step: step, err: err2, output: checkoutOutput + output1 + output2}
-				}
-
-				// Step 4.3: Exclude files - restore from env branch or remove if not exists
-				excluded, _ := GetExcludedFiles(workDir, patterns)
-				var output3 string
-				if len(excluded) > 0 {
-					for _, file := range excluded {
-						output3 += fmt.Sprintf("Excluding: %s\n", releaseOrangeStyle.Render(file))
-						// Try to restore file from environment branch (keeps it unchanged)
-						restoreCmd := fmt.Sprintf("git checkout origin/%s -- %q 2>/dev/null", state.Environment.BranchName, file)
-						_, restoreErr := executor.RunCommand(restoreCmd)
-						if restoreErr != nil {
-							// File doesn't exist in env branch - remove it completely
-							executor.RunCommand(fmt.Sprintf("rm -rf %q", file))
-							executor.RunCommand(fmt.Sprintf("git rm -rf --cached %q 2>/dev/null || true", file))
-						}
-					}
-				}
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				output = checkoutOutput + output1 + output2 + output3
-			}
-
-		case ReleaseStepCommit:
-			// Get next v-number and create commit
-			vNumber, verr := GetNextVersionNumber(workDir, state.Environment.BranchName, state.Version)
-			if verr != nil {
-				return releaseStepCompleteMsg{step: step, err: verr, output: ""}
-			}
-
-			title, body := BuildCommitMessage(state.Version, state.Environment.BranchName, vNumber, state.MRBranches)
-			// Don't use "git add -A" - files are already staged from checkout
-			var commitCmd string
-			if body != "" {
-				// Use $'...' bash syntax to properly interpret \n as newlines in commit body
-				// Escape single quotes and convert actual newlines to \n escape sequences
-				escapedBody := strings.ReplaceAll(body, "'", "'\\''")
-				escapedBody = strings.ReplaceAll(escapedBody, "\n", "\\n")
-				commitCmd = fmt.Sprintf("git commit -m %q -m $'%s'", title, escapedBody)
-			} else {
-				commitCmd = fmt.Sprintf("git commit -m %q", title)
-			}
-			output, err = executor.RunCommand(commitCmd)
-
-			// After successful commit, clean up any remaining untracked files
-			if err == nil {
-				cleanOutput, _ := executor.RunCommand("git clean -fd")
-				output += cleanOutput
-			}
-
-		case ReleaseStepPushAndCreateMR:
-			// Push env release branch to remote (for the MR)
-			// Release root branch will be pushed later when user clicks "Push root branches"
-			output, err = executor.RunCommand(cmds.Step6Push())
-			// MR will be created via API after this step completes
-
-		case ReleaseStepPushRootBranches:
-			tagName := state.TagName
-
-			if state.RootMerge {
-				// RootMerge: push release-root, merge to root, tag merge-commit on root, push root+tags, merge to develop
-
-				// Push release root branch
-				pushReleaseRootCmd := fmt.Sprintf("git push -u origin %s", state.SourceBranch)
-				output1, err1 := executor.RunCommand(pushReleaseRootCmd)
-				if err1 != nil {
-					return releaseStepCompleteMsg{step: step, err: err1, output: output1}
-				}
-				output = output1
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Merge release root to base branch (creates merge-commit)
-				output2, err2 := executor.RunCommands(cmds.StepMergeToRoot())
-				if err2 != nil {
-					return releaseStepCompleteMsg{step: step, err: err2, output: output + output2}
-				}
-				output += output2
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Tag the merge-commit on root (we are on root after StepMergeToRoot)
-				tagCmd := fmt.Sprintf("git tag -f %s", tagName)
-				outputTag, errTag := executor.RunCommand(tagCmd)
-				if errTag != nil {
-					return releaseStepCompleteMsg{step: step, err: errTag, output: output + outputTag}
-				}
-				output += outputTag
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Push base branch with tags
-				pushRootCmd := fmt.Sprintf("git push origin %s --tags --force", baseBranch)
-				output3, err3 := executor.RunCommand(pushRootCmd)
-				if err3 != nil {
-					return releaseStepCompleteMsg{step: step, err: err3, output: output + output3}
-				}
-				output += output3
-				m.program.Send(releaseSubStepDoneMsg{})
+// completeReleaseStep applies a step result through the engine. Complete runs in Update,
+// so a failed step is reported in the terminal right from its finished event.
+func (m *model) completeReleaseStep(result ReleaseStepResult) (ReleaseStep, error) {
+	engine := m.releaseEngine()
+	engine.Subscribe(func(ev ReleaseEvent) {
+		if ev, ok := ev.(ReleaseStepFinishedEvent); ok && ev.Err != nil {
+			m.showReleaseStepError(engine, ev)
+		}
+	})
+	return engine.Complete(result)
+}
 
-				// Merge root to develop and push
-				output4, err4 := executor.RunCommands(cmds.StepMergeToDevelop())
-				if err4 != nil {
-					return releaseStepCompleteMsg{step: step, err: err4, output: output + output4}
-				}
-				output += output4
-				m.program.Send(releaseSubStepDoneMsg{})
-			} else {
-				// No RootMerge: checkout release-root, tag it, push with tags
+// showReleaseStepError prints the error of a failed step to the release terminal
+func (m *model) showReleaseStepError(engine *ReleaseEngine, ev ReleaseStepFinishedEvent) {
+	// Display in terminal with pale red color (no background)
+	terminalErrorStyle := lipgloss.NewStyle().Foreground(currentTheme.Error)
+	m.appendReleaseOutput("")
+	m.appendReleaseOutput(terminalErrorStyle.Render("ERROR: " + ev.Err.Error()))
 
-				// Checkout release root branch
-				checkoutCmd := fmt.Sprintf("git checkout %s", state.SourceBranch)
-				outputChk, errChk := executor.RunCommand(checkoutCmd)
-				if errChk != nil {
-					return releaseStepCompleteMsg{step: step, err: errChk, output: outputChk}
-				}
-				output = outputChk
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Create tag on release root branch
-				tagCmd := fmt.Sprintf("git tag -f %s", tagName)
-				outputTag, errTag := executor.RunCommand(tagCmd)
-				if errTag != nil {
-					return releaseStepCompleteMsg{step: step, err: errTag, output: output + outputTag}
-				}
-				output += outputTag
-				m.program.Send(releaseSubStepDoneMsg{})
-
-				// Push release root branch with tags
-				pushCmd := fmt.Sprintf("git push -u origin %s --tags --force", state.SourceBranch)
-				outputPush, errPush := executor.RunCommand(pushCmd)
-				if errPush != nil {
-					return releaseStepCompleteMsg{step: step, err: errPush, output: output + outputPush}
-				}
-				output += outputPush
-				m.program.Send(releaseSubStepDoneMsg{})
-			}
+	// Commit step errors (likely linter errors) reset to release-root branch so user can fix there
+	if ev.Step == ReleaseStepCommit {
+		m.appendReleaseOutput("")
+		m.appendReleaseOutput(fmt.Sprintf("Switched to %s for fixes", releaseOrangeStyle.Render(engine.RootBranch())))
+	}
+}
 
-		case ReleaseStepSwitchToRoot:
-			// Switch back to base branch as final step
-			output, err = executor.RunCommand(fmt.Sprintf("git checkout %s", baseBranch))
+// handleReleaseStepStarted titles the output of the step the engine started running
+func (m *model) handleReleaseStepStarted(msg releaseStepStartedMsg) (tea.Model, tea.Cmd) {
+	if m.releaseState == nil {
+		return m, nil
+	}
+	m.flushReleaseScreen()
+	if len(m.releaseOutputBuffer) > 0 && strings.TrimSpace(m.releaseOutputBuffer[len(m.releaseOutputBuffer)-1]) != "" {
+		m.appendReleaseOutput("")
+	}
+	m.appendReleaseOutput(releaseActiveTextStyle.Render("==> " + releaseStepTitle(m.releaseState, msg.step)))
+	return m, nil
+}
 
-		default:
-			return releaseStepCompleteMsg{step: step, err: nil}
+// executeReleaseStep runs the appropriate command for a step
+func (m *model) executeReleaseStep(step ReleaseStep) tea.Cmd {
+	if m.releaseState == nil {
+		return func() tea.Msg {
+			return releaseStepCompleteMsg{step: step, err: fmt.Errorf("no release state")}
 		}
+	}
 
-		executor.Close()
-		return releaseStepCompleteMsg{step: step, err: err, output: output}
+	engine := m.releaseEngine()
+	return func() tea.Msg {
+		result := engine.Execute(step)
+		return releaseStepCompleteMsg{step: result.Step, err: result.Err, output: result.Output, cherryPicks: result.CherryPicks}
 	}
 }
 
@@ -1258,21 +1312,14 @@ func (m *model) handleReleaseStepComplete(msg releaseStepCompleteMsg) (tea.Model
 	// Save current terminal screen to buffer (for real-time streaming mode)
 	// This preserves the command output before the next command starts
 	if m.releaseCurrentScreen != "" {
-		lines := strings.Split(m.releaseCurrentScreen, "\n")
-		for _, line := range lines {
-			m.releaseOutputBuffer = append(m.releaseOutputBuffer, line)
-		}
-		// Enforce buffer limit
-		if len(m.releaseOutputBuffer) > maxOutputLines {
-			m.releaseOutputBuffer = m.releaseOutputBuffer[len(m.releaseOutputBuffer)-maxOutputLines:]
-		}
-		m.releaseCurrentScreen = ""
+		m.flushReleaseScreen()
 		m.updateReleaseViewport()
 	}
 
 	// Append output to buffer only if not streamed in real-time
-	// (when program is set, output is already streamed via releaseOutputMsg)
-	if msg.output != "" && m.program == nil {
+	// (when program is set, output is already streamed via releaseOutputMsg;
+	// the GitLab release step calls the API and has nothing to stream)
+	if msg.output != "" && (m.program == nil || msg.step == ReleaseStepCreateRelease) {
 		lines := strings.Split(msg.output, "\n")
 		for _, line := range lines {
 			m.appendReleaseOutput(line)
@@ -1288,41 +1335,8 @@ func (m *model) handleReleaseStepComplete(msg releaseStepCompleteMsg) (tea.Model
 	state := m.releaseState
 
 	if msg.err != nil {
-		// Handle error - display in terminal with pale red color (no background)
-		terminalErrorStyle := lipgloss.NewStyle().Foreground(currentTheme.Error)
-		m.appendReleaseOutput("")
-		m.appendReleaseOutput(terminalErrorStyle.Render("ERROR: " + msg.err.Error()))
-
-		// Special handling for commit step errors (likely linter errors)
-		// Reset to release-root branch so user can fix there
-		if msg.step == ReleaseStepCommit {
-			m.appendReleaseOutput("")
-			m.appendReleaseOutput("Resetting to release-root branch for fixes...")
-
-			// Reset staged changes and switch to release-root branch
-			executor := NewGitExecutor(state.WorkDir, nil)
-			executor.RunCommand("git reset")
-			errBaseBranch := state.BaseBranch
-			if errBaseBranch == "" {
-				errBaseBranch = "root"
-			}
-			cmds := NewReleaseCommandsWithSourceBranch(state.WorkDir, state.Version, errBaseBranch, &state.Environment, nil, nil, state.SourceBranch, state.SourceBranchIsRemote)
-			executor.RunCommand(fmt.Sprintf("git checkout %s", cmds.ReleaseRootBranch()))
-			executor.Close()
-
-			m.appendReleaseOutput(fmt.Sprintf("Switched to %s", releaseOrangeStyle.Render(cmds.ReleaseRootBranch())))
-
-			state.LastError = &ReleaseError{
-				Step:    ReleaseStepCopyContent, // Retry from copy content step
-				Message: msg.err.Error(),
-				Code:    "COMMIT_FAILED",
-			}
-		} else {
-			state.LastError = &ReleaseError{
-				Step:    msg.step,
-				Message: msg.err.Error(),
-			}
-		}
+		// The error is printed by completeReleaseStep from the step finished event
+		m.completeReleaseStep(ReleaseStepResult{Step: msg.step, Output: msg.output, Err: msg.err})
 		// Save last 5000 lines of terminal output (buffer + current screen)
 		fullOutput := strings.Join(m.releaseOutputBuffer, "\n")
 		if m.releaseCurrentScreen != "" {
@@ -1337,104 +1351,28 @@ func (m *model) handleReleaseStepComplete(msg releaseStepCompleteMsg) (tea.Model
 		return m, nil
 	}
 
-	// Step succeeded
-	state.LastSuccessStep = msg.step
-	state.LastError = nil
-	state.ErrorOutput = ""
-
-	// Determine next step
-	var nextStep ReleaseStep
+	// Step succeeded - determine next step
 	var nextCmd tea.Cmd
-
-	switch msg.step {
-	case ReleaseStepGitFetch:
-		state.CompletedSubSteps++
-		nextStep = ReleaseStepCheckoutRoot
-		state.CurrentStep = nextStep
-
-	case ReleaseStepCheckoutRoot:
-		state.CompletedSubSteps++
-		state.CurrentMRIndex = 0
-		if len(state.MRBranches) > 0 {
-			nextStep = ReleaseStepMergeBranches
-		} else {
-			nextStep = ReleaseStepCheckoutEnv
-		}
-		state.CurrentStep = nextStep
-
-	case ReleaseStepMergeBranches:
-		state.CompletedSubSteps++
-		// Mark current branch as merged
-		if state.CurrentMRIndex < len(state.MRBranches) {
-			state.MergedBranches = append(state.MergedBranches, state.MRBranches[state.CurrentMRIndex])
-			state.CurrentMRIndex++
-		}
-
-		// Check if more branches to merge
-		if state.CurrentMRIndex < len(state.MRBranches) {
-			nextStep = ReleaseStepMergeBranches
-			state.CurrentStep = nextStep
-		} else {
-			nextStep = ReleaseStepCheckoutEnv
-			state.CurrentStep = nextStep
-		}
-
-	case ReleaseStepCheckoutEnv:
-		state.CompletedSubSteps++
-		nextStep = ReleaseStepCopyContent
-		state.CurrentStep = nextStep
-
-	case ReleaseStepCopyContent:
-		// substeps already incremented via releaseSubStepDoneMsg
-		if state.EnvMergeMode == "regular" {
-			// Regular merge creates its own commit, skip commit step
-			nextStep = ReleaseStepWaitForMR
-			state.CurrentStep = nextStep
-		} else {
-			nextStep = ReleaseStepCommit
-			state.CurrentStep = nextStep
-		}
-
-	case ReleaseStepCommit:
-		state.CompletedSubSteps++
-		// After commit, wait for user to click "Create MR" button
-		// Push will happen when user clicks the button
-		nextStep = ReleaseStepWaitForMR
-		state.CurrentStep = nextStep
-
-	case ReleaseStepPushAndCreateMR:
-		state.CompletedSubSteps++
-		// Now create the MR via GitLab API
-		// After MR is created, handleMRCreated will trigger root merge if enabled
-		state.CurrentStep = ReleaseStepPushAndCreateMR // Keep same step until MR is created
+	nextStep, err := m.completeReleaseStep(ReleaseStepResult{Step: msg.step, Output: msg.output, CherryPicks: msg.cherryPicks})
+	if err != nil {
 		// Save terminal output buffer for resume
 		state.TerminalOutput = make([]string, len(m.releaseOutputBuffer))
 		copy(state.TerminalOutput, m.releaseOutputBuffer)
 		SaveReleaseState(state)
 		m.updateReleaseButtons()
+		return m, nil
+	}
 
-		// Create MR asynchronously
-		return m, m.createGitLabMR()
-
-	case ReleaseStepPushRootBranches:
-		// substeps already incremented via releaseSubStepDoneMsg
-		// Root push completed, now switch back to root branch
-		nextStep = ReleaseStepSwitchToRoot
-		state.CurrentStep = nextStep
-
-	case ReleaseStepSwitchToRoot:
-		state.CompletedSubSteps++
-		// Switch to root completed, save to history and mark as complete
-		nextStep = ReleaseStepComplete
-		state.CurrentStep = nextStep
-
-	default:
+	if msg.step == ReleaseStepPushAndCreateMR {
 		// Save terminal output buffer for resume
 		state.TerminalOutput = make([]string, len(m.releaseOutputBuffer))
 		copy(state.TerminalOutput, m.releaseOutputBuffer)
 		SaveReleaseState(state)
 		m.updateReleaseButtons()
-		return m, nil
+
+		// Create MR asynchronously
+		// After MR is created, handleMRCreated will move on to the root push step
+		return m, m.createGitLabMR()
 	}
 
 	// Save terminal output buffer for resume
@@ -1465,7 +1403,7 @@ func (m *model) handleReleaseStepComplete(msg releaseStepCompleteMsg) (tea.Model
 		SaveReleaseHistory(state, "completed", terminalOutput)
 
 		// Clear release state so Ctrl+C goes to MRs list
-		ClearReleaseState()
+		ClearReleaseState(state)
 
 		// Reset selected MRs for next release
 		m.initListScreen()
@@ -1485,27 +1423,15 @@ func (m *model) handleReleaseStepComplete(msg releaseStepCompleteMsg) (tea.Model
 
 // createGitLabMR creates the merge request via GitLab API
 func (m *model) createGitLabMR() tea.Cmd {
-	return func() tea.Msg {
-		if m.releaseState == nil || m.creds == nil {
+	if m.releaseState == nil || m.creds == nil {
+		return func() tea.Msg {
 			return releaseMRCreatedMsg{err: fmt.Errorf("invalid state")}
 		}
+	}
 
-		state := m.releaseState
-		client := NewGitLabClient(m.creds.GitLabURL, m.creds.Token)
-
-		mrBaseBranch := state.BaseBranch
-		if mrBaseBranch == "" {
-			mrBaseBranch = "root"
-		}
-		cmds := NewReleaseCommands(state.WorkDir, state.Version, mrBaseBranch, &state.Environment, nil, nil)
-		sourceBranch := cmds.EnvReleaseBranch()
-		targetBranch := state.Environment.BranchName
-
-		// Get version number and build MR title/body
-		vNumber, _ := GetNextVersionNumber(state.WorkDir, state.Environment.BranchName, state.Version)
-		title, body := BuildCommitMessage(state.Version, state.Environment.BranchName, vNumber, state.MRBranches)
-
-		mr, err := client.CreateMergeRequest(state.ProjectID, sourceBranch, targetBranch, title, body)
+	engine := m.releaseEngine()
+	return func() tea.Msg {
+		mr, err := engine.CreateMR()
 		if err != nil {
 			return releaseMRCreatedMsg{err: err}
 		}
@@ -1516,16 +1442,13 @@ func (m *model) createGitLabMR() tea.Cmd {
 
 // handleMRCreated processes MR creation result
 func (m *model) handleMRCreated(msg releaseMRCreatedMsg) (tea.Model, tea.Cmd) {
-	if m.releaseState == nil {
+	if m.releaseState == nil || errors.Is(msg.err, context.Canceled) {
+		// The release was left while the MR was being created
 		return m, nil
 	}
 
-	if msg.err != nil {
-		m.releaseState.LastError = &ReleaseError{
-			Step:    ReleaseStepPushAndCreateMR,
-			Message: msg.err.Error(),
-		}
-		m.releaseState.CurrentStep = ReleaseStepPushAndCreateMR
+	err := m.releaseEngine().RecordMR(&MergeRequest{WebURL: msg.url, IID: msg.iid}, msg.err)
+	if err != nil {
 		m.appendReleaseOutput(fmt.Sprintf("ERROR: Failed to create MR: %v", msg.err))
 		// Save state for retry
 		m.releaseState.TerminalOutput = make([]string, len(m.releaseOutputBuffer))
@@ -1535,22 +1458,10 @@ func (m *model) handleMRCreated(msg releaseMRCreatedMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	m.releaseState.CreatedMRURL = msg.url
-	m.releaseState.CreatedMRIID = msg.iid
-	m.releaseState.CompletedSubSteps++ // MR created via API = 1 substep
 	m.appendReleaseOutput("")
 	m.appendReleaseOutput(fmt.Sprintf("Merge request created: %s", msg.url))
 
-	// Calculate and store tag name for display
-	vNumber, _ := GetNextVersionNumber(m.releaseState.WorkDir, m.releaseState.Environment.BranchName, m.releaseState.Version)
-	m.releaseState.TagName = fmt.Sprintf("%s-%s-v%d",
-		strings.ToLower(m.releaseState.Environment.Name),
-		m.releaseState.Version,
-		vNumber,
-	)
-
-	// Go to wait for root push step (user must click "Push root branches")
-	m.releaseState.CurrentStep = ReleaseStepWaitForRootPush
+	// Release now waits for root push step (user must click "Push root branches")
 
 	// Save state
 	m.releaseState.TerminalOutput = make([]string, len(m.releaseOutputBuffer))
@@ -1565,8 +1476,23 @@ func (m *model) handleMRCreated(msg releaseMRCreatedMsg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(m.startPipelineObserver(), openInSafariWithFallback(msg.url))
 }
 
-// retryRelease retries from the last failed step
+// retryRelease retries from the last failed step once the pending MRs are checked for
+// branches that moved on origin since they were selected
 func (m model) retryRelease() (tea.Model, tea.Cmd) {
+	if m.releaseState == nil || m.releaseState.LastError == nil || m.mrDriftChecking {
+		return m, nil
+	}
+	engine, executor := m.mrQueueEngine()
+	pinned := engine.PinnedMRs()
+	executor.Close()
+	if len(pinned) > 0 {
+		return m.checkMRDrift(pinned)
+	}
+	return m.retryReleaseStep()
+}
+
+// retryReleaseStep clears the error and runs the failed step again
+func (m model) retryReleaseStep() (tea.Model, tea.Cmd) {
 	if m.releaseState == nil || m.releaseState.LastError == nil {
 		return m, nil
 	}
@@ -1603,11 +1529,12 @@ func (m model) abortRelease() (tea.Model, tea.Cmd) {
 	if m.releaseState != nil {
 		workDir := m.releaseState.WorkDir
 		version := m.releaseState.Version
-		envBranch := m.releaseState.Environment.BranchName
 		abortBase := m.releaseState.BaseBranch
 		if abortBase == "" {
 			abortBase = "root"
 		}
+		cmds := NewReleaseCommandsWithSourceBranch(workDir, version, abortBase, &m.releaseState.Environment, nil, nil, m.releaseState.SourceBranch, m.releaseState.SourceBranchIsRemote).
+			WithNaming(m.releaseState.Naming, m.releaseState.StartedAt)
 
 		// Kill any running process
 		if m.releaseExecutor != nil {
@@ -1619,15 +1546,18 @@ func (m model) abortRelease() (tea.Model, tea.Cmd) {
 		// Reset to clean state
 		exec := NewGitExecutor(workDir, nil)
 		exec.RunCommand("git reset --hard")
-		exec.RunCommand(fmt.Sprintf("git checkout %s", abortBase))
+		exec.RunCommand(releaseExitCheckoutCommand(m.releaseState, abortBase))
 		exec.Close()
 
 		// Delete created branches
-		DeleteLocalBranches(workDir, version, envBranch, abortBase)
+		DeleteLocalBranches(workDir, abortBase, cmds.ReleaseRootBranch(), cmds.EnvReleaseBranch())
+		if m.releaseState.InWorktree() {
+			DetachReleaseWorktree(workDir)
+		}
 	}
 
 	// Clear state
-	ClearReleaseState()
+	m.clearRelease()
 	m.releaseState = nil
 	m.releaseOutputBuffer = nil
 	m.releaseCurrentScreen = ""
@@ -1642,7 +1572,7 @@ func (m model) abortRelease() (tea.Model, tea.Cmd) {
 	m.mrsLoaded = false
 
 	// Go back to home screen
-	m.screen = screenHome
+	m.showHome()
 
 	return m, nil
 }
@@ -1666,11 +1596,12 @@ func (m model) abortReleaseWithRemoteDeletion(deleteRemote bool) (tea.Model, tea
 	if m.releaseState != nil {
 		workDir := m.releaseState.WorkDir
 		version := m.releaseState.Version
-		envBranch := m.releaseState.Environment.BranchName
 		abortBaseBranch := m.releaseState.BaseBranch
 		if abortBaseBranch == "" {
 			abortBaseBranch = "root"
 		}
+		cmds := NewReleaseCommandsWithSourceBranch(workDir, version, abortBaseBranch, &m.releaseState.Environment, nil, nil, m.releaseState.SourceBranch, m.releaseState.SourceBranchIsRemote).
+			WithNaming(m.releaseState.Naming, m.releaseState.StartedAt)
 
 		// Kill any running process
 		if m.releaseExecutor != nil {
@@ -1681,7 +1612,6 @@ func (m model) abortReleaseWithRemoteDeletion(deleteRemote bool) (tea.Model, tea
 
 		// Delete remote branches if requested
 		if deleteRemote {
-			cmds := NewReleaseCommandsWithSourceBranch(workDir, version, abortBaseBranch, &m.releaseState.Environment, nil, nil, m.releaseState.SourceBranch, m.releaseState.SourceBranchIsRemote)
 			exec := NewGitExecutor(workDir, nil)
 
 			// Delete env release branch (e.g. release/rpb-1.0.0-dev)
@@ -1698,15 +1628,18 @@ func (m model) abortReleaseWithRemoteDeletion(deleteRemote bool) (tea.Model, tea
 		// Reset to clean state
 		exec2 := NewGitExecutor(workDir, nil)
 		exec2.RunCommand("git reset --hard")
-		exec2.RunCommand(fmt.Sprintf("git checkout %s", abortBaseBranch))
+		exec2.RunCommand(releaseExitCheckoutCommand(m.releaseState, abortBaseBranch))
 		exec2.Close()
 
 		// Delete created branches
-		DeleteLocalBranches(workDir, version, envBranch, abortBaseBranch)
+		DeleteLocalBranches(workDir, abortBaseBranch, cmds.ReleaseRootBranch(), cmds.EnvReleaseBranch())
+		if m.releaseState.InWorktree() {
+			DetachReleaseWorktree(workDir)
+		}
 	}
 
 	// Clear state
-	ClearReleaseState()
+	m.clearRelease()
 	m.releaseState = nil
 	m.releaseOutputBuffer = nil
 	m.releaseCurrentScreen = ""
@@ -1721,7 +1654,7 @@ func (m model) abortReleaseWithRemoteDeletion(deleteRemote bool) (tea.Model, tea
 	m.mrsLoaded = false
 
 	// Go back to home screen
-	m.screen = screenHome
+	m.showHome()
 
 	return m, nil
 }
@@ -1761,6 +1694,16 @@ func (m model) renderRootPushHint() string {
 		hintBaseBranch = "root"
 	}
 
+	if state.Rollback != nil {
+		// Rollback: nothing is merged, tagged or pushed to root
+		return fmt.Sprintf("%s %s %s %s",
+			branchStyle.Render(hintBaseBranch),
+			textStyle.Render("stays as is, the rollback to"),
+			tagStyle.Render(state.Rollback.Restored),
+			textStyle.Render("is recorded in history"),
+		)
+	}
+
 	if state.RootMerge {
 		// With RootMerge: {branch} merged to base, base tagged as {tag}, then base merged to develop
 		return fmt.Sprintf("%s %s %s%s %s %s %s%s %s %s",
@@ -1813,7 +1756,7 @@ func (m model) completeRelease() (tea.Model, tea.Cmd) {
 
 	// No need to checkout root here - it's already done as part of ReleaseStepSwitchToRoot
 
-	ClearReleaseState()
+	m.clearRelease()
 	m.releaseState = nil
 	m.releaseOutputBuffer = nil
 	m.releaseCurrentScreen = ""
@@ -1853,7 +1796,7 @@ func (m model) completeRelease() (tea.Model, tea.Cmd) {
 	m.rootMergeSelection = true
 
 	// Go back to home screen
-	m.screen = screenHome
+	m.showHome()
 
 	return m, nil
 }
@@ -1925,14 +1868,14 @@ func (m *model) resumeRelease(state *ReleaseState) tea.Cmd {
 	return nil
 }
 
-// checkExistingRelease checks if there's an in-progress release on startup
+// checkExistingRelease checks if there's a single in-progress release on startup
 func checkExistingRelease() tea.Cmd {
 	return func() tea.Msg {
-		state, err := LoadReleaseState()
-		if err != nil || state == nil {
+		states, err := LoadReleaseStates()
+		if err != nil || len(states) != 1 {
 			return nil
 		}
-		return existingReleaseMsg{state: state}
+		return existingReleaseMsg{state: states[0]}
 	}
 }
 
@@ -1957,6 +1900,7 @@ func (m *model) startPipelineObserver() tea.Cmd {
 // stopPipelineObserver stops the pipeline observer
 func (m *model) stopPipelineObserver() {
 	m.pipelineObserving = false
+	m.cancelGitLabRequests("pipeline")
 }
 
 // pipelineTick returns a command that triggers a pipeline check after 7 seconds
@@ -1968,12 +1912,14 @@ func (m *model) pipelineTick() tea.Cmd {
 
 // checkPipelineStatus fetches MR and pipeline status from GitLab API
 func (m *model) checkPipelineStatus() tea.Cmd {
-	return func() tea.Msg {
-		if m.releaseState == nil || m.creds == nil {
+	if m.releaseState == nil || m.creds == nil {
+		return func() tea.Msg {
 			return pipelineStatusMsg{err: fmt.Errorf("invalid state")}
 		}
+	}
 
-		client := NewGitLabClient(m.creds.GitLabURL, m.creds.Token)
+	client := m.gitLabClient("pipeline")
+	return func() tea.Msg {
 		status := &PipelineStatus{}
 
 		// Step 1: Fetch MR status
@@ -2033,7 +1979,7 @@ func (m *model) checkPipelineStatus() tea.Cmd {
 
 		// Load pipeline jobs regex from config to filter observable jobs
 		var pipelineRegex *regexp.Regexp
-		if cfg, err := LoadConfig(); err == nil && cfg.PipelineJobsRegex != "" {
+		if cfg, _ := LoadReleaseConfig(m.releaseState); cfg != nil && cfg.PipelineJobsRegex != "" {
 			pipelineRegex, _ = regexp.Compile(cfg.PipelineJobsRegex)
 		}
 
@@ -2113,7 +2059,7 @@ func (m *model) sendPipelineNotification(success bool) {
 
 // handlePipelineStatus processes the pipeline status update
 func (m *model) handlePipelineStatus(msg pipelineStatusMsg) (tea.Model, tea.Cmd) {
-	if !m.pipelineObserving {
+	if !m.pipelineObserving || errors.Is(msg.err, context.Canceled) {
 		return m, nil
 	}
 
@@ -2192,7 +2138,7 @@ func (m *model) renderPipelineStatus() string {
 
 	// Add error indicator if there was a check failure
 	if status.Error != nil && status.Stage != PipelineStageCompleted && status.Stage != PipelineStageFailed {
-		line += " " + loadingStyle.Render("(check failed)")
+		line += " " + loadingStyle.Render("("+gitLabErrorLabel(status.Error)+")")
 	}
 
 	return line
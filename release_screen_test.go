@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReleaseScreenEngineEvents(t *testing.T) {
+	state := &ReleaseState{
+		MRBranches:  []string{"feat/a"},
+		Environment: Environment{Name: "TEST", BranchName: "test"},
+		Version:     "1.0.0",
+		CurrentStep: ReleaseStepMergeBranches,
+		WorkDir:     t.TempDir(),
+	}
+	m := &model{releaseState: state}
+
+	m.handleReleaseStepStarted(releaseStepStartedMsg{step: ReleaseStepMergeBranches})
+	if output := strings.Join(m.releaseOutputBuffer, "\n"); !strings.Contains(output, "==> Merging feat/a (1/1)") {
+		t.Errorf("started step is not titled in the output:\n%s", output)
+	}
+
+	next, err := m.completeReleaseStep(ReleaseStepResult{Step: ReleaseStepMergeBranches, Err: errors.New("merge failed")})
+	if err == nil || next != ReleaseStepMergeBranches || state.LastError == nil {
+		t.Fatalf("completeReleaseStep = %v, %v; want the merge step suspended", next, err)
+	}
+	if output := strings.Join(m.releaseOutputBuffer, "\n"); !strings.Contains(output, "ERROR: merge failed") {
+		t.Errorf("failed step is not reported in the output:\n%s", output)
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// MRSimulation is the simulated merge result of a single MR branch
+type MRSimulation struct {
+	Branch        string
+	AlreadyMerged bool
+	Conflicts     []string // Unmerged paths if the merge conflicted
+	Error         string   // Merge failure other than a conflict (e.g. missing branch)
+}
+
+// ReleaseSimulation is the outcome of a dry run of the merge and copy-content steps
+type ReleaseSimulation struct {
+	MRs          []MRSimulation
+	EnvConflicts []string // Regular env merge or cherry-pick conflicts
+	DiffStat     string   // Diffstat of the env release commit against the env branch
+	Restored     []string // Excluded files restored from the env branch
+	Removed      []string // Excluded files removed because the env branch doesn't have them
+}
+
+// ConflictCount returns the number of MRs that failed to merge
+func (s *ReleaseSimulation) ConflictCount() int {
+	count := 0
+	for _, mr := range s.MRs {
+		if len(mr.Conflicts) > 0 || mr.Error != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// simulationGit runs git in dir with a throwaway identity and without hooks,
+// so simulated merges never depend on or trigger the user's setup.
+func simulationGit(dir string, args ...string) (string, error) {
+	subcommand := args[0]
+	args = append([]string{
+		"-c", "user.name=Relix Simulation",
+		"-c", "user.email=simulation@relix.local",
+		"-c", "core.hooksPath=/dev/null",
+		"-c", "commit.gpgsign=false",
+	}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("git %s: %s", subcommand, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// simulateMerge merges ref into the worktree HEAD. On conflict the unmerged paths
+// are returned and the merge is aborted, leaving HEAD unchanged.
+func simulateMerge(treeDir, ref string) ([]string, error) {
+	if _, err := simulationGit(treeDir, "merge", "--no-edit", ref); err != nil {
+		conflicts, _ := GetConflictedFiles(treeDir)
+		simulationGit(treeDir, "merge", "--abort")
+		if len(conflicts) == 0 {
+			return nil, err
+		}
+		return conflicts, nil
+	}
+	return nil, nil
+}
+
+// SimulateRelease runs the merge and copy-content steps of the release in a temporary
+// detached worktree. Nothing is pushed, no branches are created and the user's
+// checkout is left untouched; only remote-tracking refs are updated by the fetch.
+// MRs that conflict are reported and skipped, as if dropped from the release.
+func SimulateRelease(state *ReleaseState, patterns []string) (*ReleaseSimulation, error) {
+	workDir := state.WorkDir
+	if _, err := simulationGit(workDir, "fetch"); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "relix-simulate-")
+	if err != nil {
+		return nil, err
+	}
+	treeDir := filepath.Join(tmpDir, "tree")
+
+	baseBranch := state.BaseBranch
+	if baseBranch == "" {
+		baseBranch = "root"
+	}
+	start := "origin/" + baseBranch
+	if state.SourceBranchSHA != "" {
+		start = state.SourceBranchSHA
+	} else if state.SourceBranchIsRemote {
+		start = "origin/" + state.SourceBranch
+	}
+
+	if _, err := simulationGit(workDir, "worktree", "add", "--detach", treeDir, start); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	defer func() {
+		simulationGit(workDir, "worktree", "remove", "--force", treeDir)
+		os.RemoveAll(tmpDir)
+		simulationGit(workDir, "worktree", "prune")
+	}()
+
+	sim := &ReleaseSimulation{}
+
+	// Merge MR branches into the source branch
+	for i, branch := range state.MRBranches {
+		result := MRSimulation{Branch: branch}
+		ref := "origin/" + branch
+		if i < len(state.MRCommitSHAs) && state.MRCommitSHAs[i] != "" {
+			// The release merges the commit the MR had when it was selected, as the engine does
+			ref = state.MRCommitSHAs[i]
+		}
+		if state.PromotedFrom != "" {
+			// A promoted release is shipped without merging its MRs again
+			result.AlreadyMerged = true
+		} else if _, err := simulationGit(treeDir, "cat-file", "-e", ref+"^{commit}"); err != nil {
+			result.Error = fmt.Sprintf("commit %s selected for the release is not available locally, the branch was rewritten on origin", shortSHA(ref))
+		} else if _, err := simulationGit(treeDir, "merge-base", "--is-ancestor", ref, "HEAD"); err == nil {
+			result.AlreadyMerged = true
+		} else if conflicts, err := simulateMerge(treeDir, ref); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Conflicts = conflicts
+		}
+		sim.MRs = append(sim.MRs, result)
+	}
+
+	rootHead, err := simulationGit(treeDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// Switch to the env branch state the env release branch would start from
+	envRef := "origin/" + state.Environment.BranchName
+	if _, err := simulationGit(treeDir, "checkout", "--detach", envRef); err != nil {
+		return nil, fmt.Errorf("remote branch %s does not exist", envRef)
+	}
+
+	if state.EnvMergeMode == "regular" {
+		conflicts, err := simulateMerge(treeDir, rootHead)
+		if err != nil {
+			return nil, err
+		}
+		sim.EnvConflicts = conflicts
+	} else if state.EnvMergeMode == "cherry-pick" {
+		conflicts, err := simulateCherryPicks(treeDir, state, sim.MRs, "origin/"+baseBranch, rootHead)
+		if err != nil {
+			return nil, err
+		}
+		sim.EnvConflicts = conflicts
+	} else {
+		// Squash mode: replace content with the source branch
+		if _, err := simulationGit(treeDir, "rm", "-rf", "-q", "."); err != nil {
+			return nil, err
+		}
+		if _, err := simulationGit(treeDir, "checkout", rootHead, "--", "."); err != nil {
+			return nil, err
+		}
+	}
+
+	// Squash and cherry-pick modes restore the excluded files from the env branch
+	if state.EnvMergeMode != "regular" && len(sim.EnvConflicts) == 0 {
+		excluded, _ := GetExcludedFiles(treeDir, patterns)
+		for _, file := range excluded {
+			if _, err := simulationGit(treeDir, "checkout", envRef, "--", file); err == nil {
+				sim.Restored = append(sim.Restored, file)
+			} else {
+				simulationGit(treeDir, "rm", "-rf", "-q", "--", file)
+				sim.Removed = append(sim.Removed, file)
+			}
+		}
+	}
+
+	// Index holds the env release content in all modes (merge commit, picks or staged copy)
+	if len(sim.EnvConflicts) == 0 {
+		sim.DiffStat, _ = simulationGit(treeDir, "diff", "--cached", "--stat", envRef)
+	}
+
+	return sim, nil
+}
+
+// simulateCherryPicks picks the planned commits of the MRs that merged cleanly onto the
+// worktree HEAD. Conflicting picks are skipped; the unmerged paths of all of them are returned.
+func simulateCherryPicks(treeDir string, state *ReleaseState, mrs []MRSimulation, baseRef, sourceRef string) ([]string, error) {
+	merged := *state
+	merged.MRBranches, merged.MRCommitSHAs = nil, nil
+	for i, mr := range mrs {
+		if len(mr.Conflicts) > 0 || mr.Error != "" {
+			continue
+		}
+		sha := ""
+		if i < len(state.MRCommitSHAs) {
+			sha = state.MRCommitSHAs[i]
+		}
+		merged.MRBranches = append(merged.MRBranches, mr.Branch)
+		merged.MRCommitSHAs = append(merged.MRCommitSHAs, sha)
+	}
+
+	picks, err := planCherryPicks(NewGitExecutor(treeDir, nil), &merged, baseRef, sourceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	for _, pick := range picks {
+		args := []string{"cherry-pick", "-x"}
+		if pick.Mainline {
+			args = append(args, "-m", "1")
+		}
+		_, err := simulationGit(treeDir, append(args, pick.SHA)...)
+		if err == nil {
+			continue
+		}
+
+		unmerged, _ := GetConflictedFiles(treeDir)
+		if len(unmerged) == 0 {
+			// The pick is empty: its changes are already on the env branch
+			if _, skipErr := simulationGit(treeDir, "cherry-pick", "--skip"); skipErr != nil {
+				return nil, err
+			}
+			continue
+		}
+		simulationGit(treeDir, "cherry-pick", "--abort")
+		for _, file := range unmerged {
+			if !slices.Contains(conflicts, file) {
+				conflicts = append(conflicts, file)
+			}
+		}
+	}
+	return conflicts, nil
+}
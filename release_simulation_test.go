@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestSimulateReleasePinnedCommits(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	state.MRCommitSHAs = []string{h.RemoteHead("feat/a"), h.RemoteHead("feat/b")}
+
+	// feat/b moves on origin after the selection and now conflicts with feat/a
+	if _, err := h.PushBranch("feat/b", "feat/b", map[string]string{"a.txt": "b\n"}, "change a"); err != nil {
+		t.Fatal(err)
+	}
+
+	sim, err := SimulateRelease(state, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := sim.ConflictCount(); count != 0 {
+		t.Errorf("simulation reports %d conflicting MRs, want the selected commits to merge cleanly: %+v", count, sim.MRs)
+	}
+
+	// A pinned commit missing locally fails like the merge step does
+	state.MRCommitSHAs[1] = "0123456789abcdef0123456789abcdef01234567"
+	if sim, err = SimulateRelease(state, nil); err != nil {
+		t.Fatal(err)
+	}
+	if sim.MRs[1].Error == "" {
+		t.Errorf("simulation of a missing pinned commit = %+v, want an error", sim.MRs[1])
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+)
+
+// TagCollisionError reports a release tag that already exists on origin at another commit.
+// The tag is only moved after the user confirms the move.
+type TagCollisionError struct {
+	Move TagMove
+}
+
+func (e *TagCollisionError) Error() string {
+	return fmt.Sprintf("tag %s already exists on origin at %s, the release is at %s",
+		e.Move.Tag, shortSHA(e.Move.OldSHA), shortSHA(e.Move.NewSHA))
+}
+
+// shortSHA returns the abbreviated form of a commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// tagPushCommand checks the release tag on origin and returns the command pushing only that
+// tag, empty if origin already has it at the same commit. A tag of another commit is moved
+// only if the user confirmed this exact move, otherwise a *TagCollisionError is returned.
+func (e *ReleaseEngine) tagPushCommand() (string, error) {
+	tagName := e.state.TagName
+	remoteSHA, err := e.git.RemoteTagCommitID(tagName)
+	if err != nil {
+		return "", fmt.Errorf("failed to check tag %s on origin: %w", tagName, err)
+	}
+	localSHA := e.git.GetBranchCommitID(tagName + "^{commit}")
+
+	switch {
+	case remoteSHA == "":
+		return fmt.Sprintf("git push origin refs/tags/%s", tagName), nil
+	case remoteSHA == localSHA:
+		return "", nil
+	}
+
+	move := TagMove{Tag: tagName, OldSHA: remoteSHA, NewSHA: localSHA}
+	if confirmed := e.state.TagMove; confirmed == nil || !confirmed.Confirmed ||
+		confirmed.Tag != move.Tag || confirmed.OldSHA != move.OldSHA || confirmed.NewSHA != move.NewSHA {
+		return "", &TagCollisionError{Move: move}
+	}
+	return fmt.Sprintf("git push --force origin refs/tags/%s", tagName), nil
+}
+
+// ConfirmTagMove confirms moving the colliding release tag on origin. The retried root push
+// moves it as long as origin and the release still point to the confirmed commits.
+func (e *ReleaseEngine) ConfirmTagMove() {
+	if e.state.TagMove != nil {
+		e.state.TagMove.Confirmed = true
+	}
+}
+
+// tagMoveSummary describes the tag collision decision of a release for history
+func tagMoveSummary(move *TagMove) string {
+	if move.Confirmed {
+		return fmt.Sprintf("%s moved from %s to %s", move.Tag, shortSHA(move.OldSHA), shortSHA(move.NewSHA))
+	}
+	return fmt.Sprintf("%s kept at %s, not moved to %s", move.Tag, shortSHA(move.OldSHA), shortSHA(move.NewSHA))
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// worktreesDir is the directory under the config dir holding dedicated release worktrees
+const worktreesDir = "worktrees"
+
+// InWorktree reports whether the release runs in a dedicated worktree instead of the user's checkout
+func (s *ReleaseState) InWorktree() bool {
+	return s.ProjectRoot != "" && s.ProjectRoot != s.WorkDir
+}
+
+// releaseExitCheckoutCommand returns the command leaving the release checkout after the release.
+// The dedicated worktree is detached so it never holds a branch the user may want to check out.
+func releaseExitCheckoutCommand(state *ReleaseState, baseBranch string) string {
+	if state.InWorktree() {
+		return "git checkout --detach"
+	}
+	return fmt.Sprintf("git checkout %s", baseBranch)
+}
+
+// releaseWorktreePath returns ~/.relix/worktrees/<project>-<hash>/<env branch> for the project root.
+// The hash of the full root path keeps projects with the same directory name apart, and each
+// environment gets its own worktree so concurrent releases of a project don't share one.
+func releaseWorktreePath(projectRoot, envBranch string) (string, error) {
+	dir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if abs, err := filepath.Abs(projectRoot); err == nil {
+		projectRoot = abs
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(projectRoot)))
+	project := filepath.Base(projectRoot) + "-" + hex.EncodeToString(sum[:6])
+	return filepath.Join(dir, worktreesDir, project, safeFileName(envBranch)), nil
+}
+
+// worktreeGit runs git in dir and returns trimmed combined output
+func worktreeGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// sameDir reports whether two paths point to the same directory
+func sameDir(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// worktreeBranches returns the branch checked out in each worktree of the repository by worktree path
+func worktreeBranches(workDir string) (map[string]string, error) {
+	output, err := worktreeGit(workDir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	branches := make(map[string]string)
+	path := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			path = strings.TrimPrefix(line, "worktree ")
+			branches[path] = ""
+		case strings.HasPrefix(line, "branch refs/heads/"):
+			branches[path] = strings.TrimPrefix(line, "branch refs/heads/")
+		}
+	}
+	return branches, nil
+}
+
+// PrepareReleaseWorktree creates the dedicated release worktree of the project environment
+// or resets an existing one to a clean detached state. Returns the worktree path.
+func PrepareReleaseWorktree(projectRoot, envBranch string) (string, error) {
+	path, err := releaseWorktreePath(projectRoot, envBranch)
+	if err != nil {
+		return "", err
+	}
+
+	worktrees, err := worktreeBranches(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	registered := false
+	for wt := range worktrees {
+		if sameDir(wt, path) {
+			registered = true
+			break
+		}
+	}
+	if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+		registered = false
+	}
+
+	if registered {
+		// Leftovers of an interrupted release are discarded, the worktree is relix-owned
+		for _, args := range [][]string{
+			{"reset", "--hard", "-q"},
+			{"clean", "-fdq"},
+			{"checkout", "--detach", "-q"},
+		} {
+			if _, err := worktreeGit(path, args...); err != nil {
+				return "", err
+			}
+		}
+		return path, nil
+	}
+
+	// Missing or stale worktree (e.g. pruned or deleted by hand) - recreate it
+	if err := os.RemoveAll(path); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	worktreeGit(projectRoot, "worktree", "prune")
+	if _, err := worktreeGit(projectRoot, "worktree", "add", "--detach", path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// releaseCheckoutBranches returns the local branches the release checks out in its worktree.
+// The base, env and develop branches are worked on from origin in detached HEAD, see
+// ReleaseCommands.WithWorktree, so the user's checkout may hold them.
+func releaseCheckoutBranches(state *ReleaseState) []string {
+	cmds := NewReleaseCommandsWithSourceBranch(state.WorkDir, state.Version, state.BaseBranch, &state.Environment, nil, nil, state.SourceBranch, state.SourceBranchIsRemote).
+		WithNaming(state.Naming, state.StartedAt)
+	if state.Rollback != nil {
+		cmds.WithEnvReleaseBranch(state.rollbackBranch())
+	}
+	return []string{cmds.ReleaseRootBranch(), cmds.EnvReleaseBranch()}
+}
+
+// UseReleaseWorktree moves the release into the dedicated worktree of its project and environment.
+// Git refuses to check out a branch held by another worktree, so the release is refused
+// up front if the user's checkout (or any other worktree) holds its release branches.
+func UseReleaseWorktree(state *ReleaseState) error {
+	projectRoot := state.WorkDir
+	path, err := PrepareReleaseWorktree(projectRoot, state.Environment.BranchName)
+	if err != nil {
+		return err
+	}
+
+	worktrees, err := worktreeBranches(projectRoot)
+	if err != nil {
+		return err
+	}
+	for _, branch := range releaseCheckoutBranches(state) {
+		for wt, held := range worktrees {
+			if held == branch && !sameDir(wt, path) {
+				return fmt.Errorf("branch %s is checked out in %s; switch that checkout to another branch or disable releasing in a worktree", branch, wt)
+			}
+		}
+	}
+
+	state.ProjectRoot = projectRoot
+	state.WorkDir = path
+	return nil
+}
+
+// DetachReleaseWorktree detaches HEAD of the release worktree so it holds no branch
+func DetachReleaseWorktree(workDir string) error {
+	_, err := worktreeGit(workDir, "checkout", "--detach", "-q")
+	return err
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// assertUserCheckout checks that the user's checkout stayed on root with a clean tree
+func assertUserCheckout(t *testing.T, h *GitHarness) {
+	t.Helper()
+	if branch, _ := h.Git("rev-parse", "--abbrev-ref", "HEAD"); branch != "root" {
+		t.Errorf("user checkout is on %s, want root", branch)
+	}
+	if status, _ := h.Git("status", "--porcelain"); status != "" {
+		t.Errorf("user checkout has changes:\n%s", status)
+	}
+}
+
+func TestReleaseWorktreeMergeConflict(t *testing.T) {
+	h := newTestHarness(t)
+	pushConflictingMR(t, h)
+	state := newTestRelease(h, "feat/a", "feat/c")
+
+	// The user's checkout holds root while the source branch is created from it
+	if err := UseReleaseWorktree(state); err != nil {
+		t.Fatal(err)
+	}
+	if !state.InWorktree() || sameDir(state.WorkDir, h.WorkDir) {
+		t.Fatalf("release runs in %s, want a dedicated worktree", state.WorkDir)
+	}
+	testMergeConflict(t, h, state)
+	assertUserCheckout(t, h)
+}
+
+func TestReleaseWorktreeRootMerge(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	state.RootMerge = true
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+	userRoot, _ := h.Git("rev-parse", "root")
+
+	if err := UseReleaseWorktree(state); err != nil {
+		t.Fatal(err)
+	}
+	if err := runRelease(t, state, &fakeReleaseGitLab{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, branch := range []string{"root", "develop"} {
+		assertFile(t, h, branch, "a.txt", "a")
+		assertFile(t, h, branch, "b.txt", "b")
+	}
+	if tag, _ := h.run(h.Origin, "rev-parse", "-q", "--verify", "refs/tags/"+state.TagName+"^{commit}"); tag != h.RemoteHead("root") {
+		t.Errorf("tag %s = %q, want the merge commit on root", state.TagName, tag)
+	}
+	// The release leaves the user's root to be pulled and its worktree holding no branch
+	if root, _ := h.Git("rev-parse", "root"); root != userRoot {
+		t.Errorf("user's root moved from %s to %s", userRoot, root)
+	}
+	assertUserCheckout(t, h)
+	if branch, _ := h.run(state.WorkDir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "HEAD" {
+		t.Errorf("release worktree is on %s after the release, want detached", branch)
+	}
+}
+
+func TestReleaseWorktreeRetryTagMove(t *testing.T) {
+	h := newTestHarness(t)
+	// The source branch misses the latest root commit, so the root merge makes a merge commit
+	sourceBranch := newTestRelease(h).SourceBranch
+	if _, err := h.PushBranch(sourceBranch, "root", map[string]string{"s.txt": "s\n"}, "add s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.commitFiles(map[string]string{"r.txt": "r\n"}, "add r"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Git("push", "origin", "root"); err != nil {
+		t.Fatal(err)
+	}
+	state := newTestRelease(h)
+	state.RootMerge = true
+	state.TotalSubSteps = calculateReleaseTotalSteps(state)
+
+	// The release tag already exists on origin at another commit
+	executor := h.Executor()
+	tagName := NewReleaseEngine(state, executor, nil).TagName()
+	executor.Close()
+	if _, err := h.Git("tag", "-a", tagName, "-m", "old", "root~1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Git("push", "origin", "refs/tags/"+tagName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UseReleaseWorktree(state); err != nil {
+		t.Fatal(err)
+	}
+	gitlab := &fakeReleaseGitLab{}
+	var collision *TagCollisionError
+	if err := runRelease(t, state, gitlab); !errors.As(err, &collision) {
+		t.Fatalf("release error = %v, want a tag collision", err)
+	}
+	NewReleaseEngine(state, nil, nil).ConfirmTagMove()
+	// A retry within the same second would recreate the same merge commit
+	t.Setenv("GIT_AUTHOR_DATE", "2001-01-01T00:00:00Z")
+	t.Setenv("GIT_COMMITTER_DATE", "2001-01-01T00:00:00Z")
+	if err := retryTestRelease(t, state, gitlab); err != nil {
+		t.Fatalf("retry after confirming the tag move: %v", err)
+	}
+
+	root := h.RemoteHead("root")
+	if tag, _ := h.run(h.Origin, "rev-parse", "refs/tags/"+tagName+"^{commit}"); tag != root || state.TagMove.NewSHA != root {
+		t.Errorf("tag %s = %s, confirmed move to %s, want the merge commit %s on root", tagName, tag, state.TagMove.NewSHA, root)
+	}
+	if parents, _ := h.run(h.Origin, "rev-list", "--parents", "-n1", root); len(strings.Fields(parents)) != 3 {
+		t.Errorf("root head %q is not a merge commit", parents)
+	}
+	assertFile(t, h, "root", "s.txt", "s")
+	assertFile(t, h, "root", "r.txt", "r")
+}
+
+func TestReleaseWorktreeRefusesHeldReleaseBranch(t *testing.T) {
+	h := newTestHarness(t)
+	state := newTestRelease(h)
+	if _, err := h.Git("checkout", "-b", state.SourceBranch); err != nil {
+		t.Fatal(err)
+	}
+	if err := UseReleaseWorktree(state); err == nil {
+		t.Error("release started with its source branch checked out by the user")
+	}
+}
+
+func TestReleaseWorktreePath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	projects := t.TempDir()
+
+	a, err := releaseWorktreePath(filepath.Join(projects, "a", "app"), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := releaseWorktreePath(filepath.Join(projects, "b", "app"), "test")
+	if a == b {
+		t.Errorf("projects with the same directory name share worktree %s", a)
+	}
+	if again, _ := releaseWorktreePath(filepath.Join(projects, "a", "app")+"/", "test"); again != a {
+		t.Errorf("worktree path of the same project = %s, want %s", again, a)
+	}
+	if other, _ := releaseWorktreePath(filepath.Join(projects, "a", "app"), "stage"); other == a {
+		t.Error("environments of a project share a worktree")
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startRollback plans a rollback of the environment of the selected history entry and asks
+// to confirm it
+func (m model) startRollback() (tea.Model, tea.Cmd) {
+	index := -1
+	if selected, ok := m.historyList.SelectedItem().(historyListItem); ok {
+		for i, entry := range m.historyEntries {
+			if entry.ID == selected.entry.ID {
+				index = i
+			}
+		}
+	}
+	if index < 0 {
+		return m, nil
+	}
+
+	plan, err := m.planRollback(index)
+	if err != nil {
+		m.showErrorModal = true
+		m.errorModalMsg = "Cannot roll back: " + err.Error()
+		return m, nil
+	}
+	m.rollbackPlan = plan
+	m.showRollbackConfirm = true
+	m.rollbackConfirmIndex = 1
+	return m, nil
+}
+
+// planRollback builds the release state of a rollback started on the history entry at index
+func (m model) planRollback(index int) (*ReleaseState, error) {
+	if m.selectedProject == nil {
+		return nil, fmt.Errorf("select a project first")
+	}
+	restoredEntry, revertedEntry, err := rollbackReleases(m.historyEntries, index, m.selectedProject.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := LoadHistoryDetail(restoredEntry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", restoredEntry.Tag, err)
+	}
+	reverted, err := LoadHistoryDetail(revertedEntry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", revertedEntry.Tag, err)
+	}
+
+	env := Environment{Name: restored.Environment, BranchName: restored.EnvBranch}
+	for _, known := range m.environments {
+		if known.BranchName == restored.EnvBranch {
+			env = known
+		}
+	}
+
+	workDir, err := FindProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find project root: %w", err)
+	}
+	return newRollbackState(restored, reverted, env, m.selectedProject, workDir)
+}
+
+// updateRollbackConfirm handles key events in the rollback confirmation modal
+func (m model) updateRollbackConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		return m.confirmRollback()
+	case "enter":
+		if m.rollbackConfirmIndex == 0 {
+			return m.confirmRollback()
+		}
+		m.showRollbackConfirm = false
+		return m, nil
+	case "n", "N", "esc":
+		m.showRollbackConfirm = false
+		return m, nil
+	case "tab", "right", "l", "shift+tab", "left", "h":
+		m.rollbackConfirmIndex = 1 - m.rollbackConfirmIndex
+		return m, nil
+	}
+	return m, nil
+}
+
+// confirmRollback starts the confirmed rollback like a release
+func (m model) confirmRollback() (tea.Model, tea.Cmd) {
+	m.showRollbackConfirm = false
+	plan := m.rollbackPlan
+	m.rollbackPlan = nil
+	if plan == nil {
+		return m, nil
+	}
+	return m.beginRelease(plan)
+}
+
+// overlayRollbackConfirm renders the rollback confirmation modal
+func (m model) overlayRollbackConfirm(background string) string {
+	plan := m.rollbackPlan
+	if plan == nil {
+		return background
+	}
+	rollback := plan.Rollback
+	envStyle := getReleaseEnvStyle(plan.Environment.Name)
+
+	var sb strings.Builder
+	sb.WriteString(errorTitleStyle.Render("Roll Back?"))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("Roll %s back to an earlier release:\n\n", envStyle.Render(plan.Environment.Name)))
+	sb.WriteString(fmt.Sprintf("  current: %s\n", rollback.Reverted))
+	sb.WriteString(fmt.Sprintf("  restore: %s (%s)\n\n", releaseOrangeStyle.Render(rollback.Restored), shortSHA(rollback.SHA)))
+	sb.WriteString(fmt.Sprintf("Branch %s with the content\nof %s is pushed and its MR to %s\nis created.\n\n",
+		plan.rollbackBranch(), rollback.Restored, plan.Environment.BranchName))
+
+	var yesBtn, cancelBtn string
+	if m.rollbackConfirmIndex == 0 {
+		yesBtn = buttonDangerStyle.Render("Roll back")
+		cancelBtn = buttonStyle.Render("Cancel")
+	} else {
+		yesBtn = buttonStyle.Render("Roll back")
+		cancelBtn = buttonActiveStyle.Render("Cancel")
+	}
+	sb.WriteString(fmt.Sprintf("     %s     %s", yesBtn, cancelBtn))
+
+	config := ModalConfig{
+		Width:    ModalWidth{Value: 60, Percent: false},
+		MinWidth: 50,
+		MaxWidth: 70,
+		Style:    errorBoxStyle,
+	}
+
+	modal := renderModal(sb.String(), config, m.width)
+	return placeOverlayCenter(modal, background, m.width, m.height)
+}
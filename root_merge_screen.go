@@ -41,11 +41,12 @@ func (m model) updateRootMerge(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "enter":
-		// Save selection and proceed to confirmation screen
+		// Save selection and proceed to pre-flight checks or confirmation screen
 		m.rootMergeSelection = m.rootMergeButtonIndex == 0 // 0 = Yes, 1 = No
-		m.screen = screenConfirm
-		m.initConfirmViewport()
-		return m, nil
+		if m.needsPreflight() {
+			return m.startPreflight()
+		}
+		return m.openConfirm()
 	}
 
 	return m, nil
@@ -127,21 +128,7 @@ func (m model) renderRootMergeContent(width int) string {
 // renderQuadSidebar renders MRs, Environment, Version, and Source branch sidebars stacked vertically
 func (m model) renderQuadSidebar(width int, availableHeight int) string {
 	// Collect branch names from selected MRs or release state
-	var branches []string
-	if m.releaseState != nil && len(m.releaseState.MRBranches) > 0 {
-		// Use branches from release state (for resume scenario)
-		branches = m.releaseState.MRBranches
-	} else {
-		// Get branches from list
-		items := m.list.Items()
-		for _, item := range items {
-			if mr, ok := item.(mrListItem); ok {
-				if m.selectedMRs[mr.MR().IID] {
-					branches = append(branches, mr.MR().SourceBranch)
-				}
-			}
-		}
-	}
+	branches := m.wizardMRBranches()
 
 	// Each bordered box adds 2 lines for top/bottom border
 	// We have 4 boxes, so total border overhead is 8 lines
@@ -172,19 +159,7 @@ func (m model) renderQuadSidebar(width int, availableHeight int) string {
 
 // renderQuintSidebar renders MRs, Environment, Version, Source branch, and Env merge sidebars stacked vertically
 func (m model) renderQuintSidebar(width int, availableHeight int) string {
-	var branches []string
-	if m.releaseState != nil && len(m.releaseState.MRBranches) > 0 {
-		branches = m.releaseState.MRBranches
-	} else {
-		items := m.list.Items()
-		for _, item := range items {
-			if mr, ok := item.(mrListItem); ok {
-				if m.selectedMRs[mr.MR().IID] {
-					branches = append(branches, mr.MR().SourceBranch)
-				}
-			}
-		}
-	}
+	branches := m.wizardMRBranches()
 
 	totalContentHeight := availableHeight - 10
 
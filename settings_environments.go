@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Release tab focus layout: settings layer selector, base branch, then settingsEnvColumns inputs
+// per environment row (name, branch, color), then exclude patterns textarea, pipeline regex,
+// settingsNamingCount naming template inputs, GitLab release, worktree and lazy MR details toggles, save button
+const (
+	settingsLayerIndex      = 0
+	settingsBaseBranchIndex = 1
+	settingsEnvColumns      = 3
+)
+
+// Environment row columns
+const (
+	settingsEnvNameColumn = iota
+	settingsEnvBranchColumn
+	settingsEnvColorColumn
+)
+
+// settingsEnvRow holds the inputs of one environment on the Release settings tab
+type settingsEnvRow struct {
+	name   textinput.Model
+	branch textinput.Model
+	color  textinput.Model
+	config EnvConfig // Loaded entry, keeps the per-env settings not edited on the tab
+}
+
+// newSettingsEnvInput creates a themed input for an environment row
+func newSettingsEnvInput(charLimit, width int, placeholder string) textinput.Model {
+	input := textinput.New()
+	input.CharLimit = charLimit
+	input.Width = width
+	input.Placeholder = placeholder
+	input.PlaceholderStyle = lipgloss.NewStyle().Foreground(currentTheme.Notion)
+	input.PromptStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+	input.TextStyle = lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+	input.Cursor.Style = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+	return input
+}
+
+// newSettingsEnvRow creates the inputs of an environment row filled from config
+func newSettingsEnvRow(env EnvConfig) settingsEnvRow {
+	row := settingsEnvRow{
+		name:   newSettingsEnvInput(20, 10, "NAME"),
+		branch: newSettingsEnvInput(50, 20, "branch"),
+		color:  newSettingsEnvInput(7, 8, "#RRGGBB"),
+		config: env,
+	}
+	row.name.SetValue(strings.ToUpper(env.Name))
+	row.branch.SetValue(env.BranchName)
+	row.color.SetValue(env.Color)
+	return row
+}
+
+// input returns the row input of a column
+func (r *settingsEnvRow) input(column int) *textinput.Model {
+	switch column {
+	case settingsEnvBranchColumn:
+		return &r.branch
+	case settingsEnvColorColumn:
+		return &r.color
+	default:
+		return &r.name
+	}
+}
+
+// settingsPatternsIndex returns the focus index of the exclude patterns textarea
+func (m model) settingsPatternsIndex() int {
+	return settingsBaseBranchIndex + 1 + len(m.settingsEnvs)*settingsEnvColumns
+}
+
+// settingsRegexIndex returns the focus index of the pipeline regex input
+func (m model) settingsRegexIndex() int {
+	return m.settingsPatternsIndex() + 1
+}
+
+// settingsGitLabReleaseIndex returns the focus index of the GitLab release toggle
+func (m model) settingsGitLabReleaseIndex() int {
+	return m.settingsPatternsIndex() + 2 + settingsNamingCount
+}
+
+// settingsWorktreeIndex returns the focus index of the release worktree toggle
+func (m model) settingsWorktreeIndex() int {
+	return m.settingsPatternsIndex() + 3 + settingsNamingCount
+}
+
+// settingsLazyMRDetailsIndex returns the focus index of the lazy MR details toggle
+func (m model) settingsLazyMRDetailsIndex() int {
+	return m.settingsPatternsIndex() + 4 + settingsNamingCount
+}
+
+// settingsSaveIndex returns the focus index of the save button
+func (m model) settingsSaveIndex() int {
+	return m.settingsPatternsIndex() + 5 + settingsNamingCount
+}
+
+// settingsReleaseFieldCount returns the number of focusable elements on the Release tab
+func (m model) settingsReleaseFieldCount() int {
+	return m.settingsPatternsIndex() + 6 + settingsNamingCount
+}
+
+// settingsEnvFocusIndex returns the focus index of an environment row column
+func settingsEnvFocusIndex(row, column int) int {
+	return settingsBaseBranchIndex + 1 + row*settingsEnvColumns + column
+}
+
+// settingsEnvFocus returns the environment row and column under focus
+func (m model) settingsEnvFocus() (row, column int, ok bool) {
+	if m.settingsFocusIndex <= settingsBaseBranchIndex || m.settingsFocusIndex >= m.settingsPatternsIndex() {
+		return 0, 0, false
+	}
+	index := m.settingsFocusIndex - settingsBaseBranchIndex - 1
+	return index / settingsEnvColumns, index % settingsEnvColumns, true
+}
+
+// loadSettingsEnvs fills the environment rows from config
+func (m *model) loadSettingsEnvs(envs []EnvConfig) {
+	if len(envs) == 0 {
+		envs = defaultEnvironments()
+	}
+	m.settingsEnvs = make([]settingsEnvRow, len(envs))
+	for i, env := range envs {
+		m.settingsEnvs[i] = newSettingsEnvRow(env)
+	}
+}
+
+// blurSettingsEnvs blurs all environment row inputs
+func (m *model) blurSettingsEnvs() {
+	for i := range m.settingsEnvs {
+		m.settingsEnvs[i].name.Blur()
+		m.settingsEnvs[i].branch.Blur()
+		m.settingsEnvs[i].color.Blur()
+	}
+}
+
+// addSettingsEnv inserts an empty environment row below the focused one (or at the end) and focuses it
+func (m model) addSettingsEnv() (tea.Model, tea.Cmd) {
+	at := len(m.settingsEnvs)
+	if row, _, ok := m.settingsEnvFocus(); ok {
+		at = row + 1
+	}
+	rows := make([]settingsEnvRow, 0, len(m.settingsEnvs)+1)
+	rows = append(rows, m.settingsEnvs[:at]...)
+	rows = append(rows, newSettingsEnvRow(EnvConfig{}))
+	rows = append(rows, m.settingsEnvs[at:]...)
+	m.settingsEnvs = rows
+	m.settingsFocusIndex = settingsEnvFocusIndex(at, settingsEnvNameColumn)
+	return m.updateSettingsFocus()
+}
+
+// removeSettingsEnv removes the focused environment row, keeping at least one environment
+func (m model) removeSettingsEnv() (tea.Model, tea.Cmd) {
+	row, column, ok := m.settingsEnvFocus()
+	if !ok {
+		return m, nil
+	}
+	if len(m.settingsEnvs) == 1 {
+		m.settingsError = "At least one environment is required"
+		return m, nil
+	}
+	m.settingsEnvs = append(m.settingsEnvs[:row:row], m.settingsEnvs[row+1:]...)
+	if row >= len(m.settingsEnvs) {
+		row = len(m.settingsEnvs) - 1
+	}
+	m.settingsFocusIndex = settingsEnvFocusIndex(row, column)
+	m.settingsError = ""
+	return m.updateSettingsFocus()
+}
+
+// moveSettingsEnv moves the focused environment row up (delta -1) or down (delta 1)
+func (m model) moveSettingsEnv(delta int) (tea.Model, tea.Cmd) {
+	row, column, ok := m.settingsEnvFocus()
+	target := row + delta
+	if !ok || target < 0 || target >= len(m.settingsEnvs) {
+		return m, nil
+	}
+	envs := append([]settingsEnvRow{}, m.settingsEnvs...)
+	envs[row], envs[target] = envs[target], envs[row]
+	m.settingsEnvs = envs
+	m.settingsFocusIndex = settingsEnvFocusIndex(target, column)
+	return m.updateSettingsFocus()
+}
+
+// settingsEnvConfigs returns the environments as edited on the Release tab
+func (m model) settingsEnvConfigs() []EnvConfig {
+	envs := make([]EnvConfig, len(m.settingsEnvs))
+	for i, row := range m.settingsEnvs {
+		env := row.config
+		env.Name = strings.TrimSpace(row.name.Value())
+		env.BranchName = strings.TrimSpace(row.branch.Value())
+		env.Color = strings.TrimSpace(row.color.Value())
+		envs[i] = env
+	}
+	return envs
+}
+
+// validateSettingsEnvs validates the environment rows and returns an error message if invalid
+func (m model) validateSettingsEnvs() string {
+	if len(m.settingsEnvs) == 0 {
+		return "At least one environment is required"
+	}
+	names := make(map[string]int)
+	branches := make(map[string]int)
+	for i, env := range m.settingsEnvConfigs() {
+		if env.Name == "" {
+			return fmt.Sprintf("Environment %d: name cannot be empty", i+1)
+		}
+		if env.BranchName == "" {
+			return fmt.Sprintf("Environment %d: branch cannot be empty", i+1)
+		}
+		if strings.Contains(env.BranchName, " ") {
+			return fmt.Sprintf("Environment %d: branch cannot contain spaces", i+1)
+		}
+		if env.Color != "" && !isValidHexColor(env.Color) {
+			return fmt.Sprintf("Environment %d: color must be a hex color like #FF8800", i+1)
+		}
+		if prev, ok := names[strings.ToUpper(env.Name)]; ok {
+			return fmt.Sprintf("Environment %d: name is already used by environment %d", i+1, prev)
+		}
+		if prev, ok := branches[env.BranchName]; ok {
+			return fmt.Sprintf("Environment %d: branch is already used by environment %d", i+1, prev)
+		}
+		names[strings.ToUpper(env.Name)] = i + 1
+		branches[env.BranchName] = i + 1
+	}
+	return ""
+}
+
+// renderSettingsEnvRow renders one environment row: color dot, name → branch and color inputs
+func (m model) renderSettingsEnvRow(index int) string {
+	row := m.settingsEnvs[index]
+	color := envPaletteColor(index)
+	if value := strings.TrimSpace(row.color.Value()); isValidHexColor(value) {
+		color = lipgloss.Color(value)
+	}
+	dot := lipgloss.NewStyle().Foreground(color).Render("●")
+	arrowStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
+	return fmt.Sprintf("%s  %s  %s  %s  %s",
+		dot,
+		row.name.View(),
+		arrowStyle.Render("→"),
+		row.branch.View(),
+		row.color.View(),
+	)
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// withSettingsDefaults fills unset release settings with the defaults the settings inputs show
+func withSettingsDefaults(config *AppConfig) *AppConfig {
+	if config.BaseBranch == "" {
+		config.BaseBranch = "root"
+	}
+	if len(config.Environments) == 0 {
+		config.Environments = defaultEnvironments()
+	}
+	if config.PipelineJobsRegex == "" {
+		config.PipelineJobsRegex = defaultPipelineJobsRegex
+	}
+	config.Naming = config.Naming.withDefaults()
+	return config
+}
+
+// loadSettingsLayers loads the release settings of every layer for the selected project and
+// shows the Global layer
+func (m *model) loadSettingsLayers(config *AppConfig) {
+	m.settingsConfig = config
+	m.settingsProjectID = config.SelectedProjectID
+	m.settingsProjectRoot, _ = FindProjectRoot()
+	m.settingsError = ""
+
+	layers, err := configLayers(config, m.settingsProjectID, m.settingsProjectRoot)
+	if err != nil {
+		// Never overwrite a .relix.json we failed to read
+		m.settingsError = fmt.Sprintf("Failed to read %s: %v", repoConfigFileName, err)
+		m.settingsProjectRoot = ""
+	}
+	m.settingsLayers = layers
+	m.settingsLayer = ConfigLayerGlobal
+	m.showSettingsLayer()
+}
+
+// settingsLayerAvailable reports whether a layer can be edited: the Repository layer needs
+// a readable project root, the Project layer a selected project
+func (m model) settingsLayerAvailable(layer ConfigLayer) bool {
+	switch layer {
+	case ConfigLayerRepo:
+		return m.settingsProjectRoot != ""
+	case ConfigLayerProject:
+		return m.settingsProjectID != 0
+	default:
+		return true
+	}
+}
+
+// settingsInherited returns the release settings a layer inherits from the layers below it
+func (m model) settingsInherited(layer ConfigLayer) *AppConfig {
+	if layer == ConfigLayerGlobal {
+		return withSettingsDefaults(&AppConfig{})
+	}
+	return withSettingsDefaults(mergeConfigLayers(m.settingsConfig, m.settingsLayers, layer-1))
+}
+
+// settingsLayerValues returns the release settings currently entered on the Release tab
+// along with the ones of the shown layer not edited on it
+func (m model) settingsLayerValues() *ReleaseSettings {
+	baseBranch := strings.TrimSpace(m.settingsBaseBranch.Value())
+	excludePatterns := m.settingsExcludePatterns.Value()
+	pipelineRegex := strings.TrimSpace(m.settingsPipelineRegex.Value())
+	naming := m.settingsNamingTemplates()
+	gitLabRelease := m.settingsGitLabRelease
+	return &ReleaseSettings{
+		BaseBranch:        &baseBranch,
+		Environments:      m.settingsEnvConfigs(),
+		ExcludePatterns:   &excludePatterns,
+		PipelineJobsRegex: &pipelineRegex,
+		Naming:            &naming,
+		TrackerRules:      m.settingsLayerTrackerRules(),
+		GitLabRelease:     &gitLabRelease,
+	}
+}
+
+// settingsLayerTrackerRules returns the tracker rules of the shown layer, edited in config files only
+func (m model) settingsLayerTrackerRules() []TrackerRule {
+	if layer := m.settingsLayers[m.settingsLayer]; layer != nil {
+		return layer.TrackerRules
+	}
+	return nil
+}
+
+// settingsLayerOverrides returns what the shown layer sets: all values for the Global layer,
+// only the values differing from the inherited ones for the others
+func (m model) settingsLayerOverrides() *ReleaseSettings {
+	values := m.settingsLayerValues()
+	if m.settingsLayer == ConfigLayerGlobal {
+		return values
+	}
+	return overridesOf(values, m.settingsInherited(m.settingsLayer))
+}
+
+// showSettingsLayer fills the Release tab inputs with the effective values of the shown layer
+func (m *model) showSettingsLayer() {
+	config := m.settingsInherited(m.settingsLayer)
+	m.settingsLayers[m.settingsLayer].applyTo(config)
+	withSettingsDefaults(config)
+
+	m.settingsBaseBranch.SetValue(config.BaseBranch)
+	m.loadSettingsEnvs(config.Environments)
+	m.settingsExcludePatterns.SetValue(config.ExcludePatterns)
+	m.settingsPipelineRegex.SetValue(config.PipelineJobsRegex)
+	m.loadSettingsNaming(config.Naming)
+	m.settingsGitLabRelease = config.GitLabRelease
+}
+
+// switchSettingsLayer keeps the edits of the shown layer and shows the next available layer
+// in the direction of delta. The shown layer must be valid to leave it.
+func (m model) switchSettingsLayer(delta int) (tea.Model, tea.Cmd) {
+	next := m.settingsLayer
+	for {
+		next += ConfigLayer(delta)
+		if next < ConfigLayerGlobal || next >= configLayerCount {
+			return m, nil
+		}
+		if m.settingsLayerAvailable(next) {
+			break
+		}
+	}
+
+	if m.settingsError = m.validateReleaseSettings(); m.settingsError != "" {
+		return m, nil
+	}
+	m.settingsLayers[m.settingsLayer] = m.settingsLayerOverrides()
+	m.settingsLayer = next
+	m.showSettingsLayer()
+	return m, nil
+}
+
+// saveSettingsLayers writes the edited layers: Global and Project to config, Repository to .relix.json
+func (m *model) saveSettingsLayers(config *AppConfig) error {
+	m.settingsLayers[m.settingsLayer] = m.settingsLayerOverrides()
+
+	global := m.settingsLayers[ConfigLayerGlobal]
+	config.BaseBranch = *global.BaseBranch
+	config.Environments = global.Environments
+	config.ExcludePatterns = *global.ExcludePatterns
+	config.PipelineJobsRegex = *global.PipelineJobsRegex
+	config.Naming = *global.Naming
+	config.GitLabRelease = *global.GitLabRelease
+
+	if m.settingsProjectID != 0 {
+		if project := m.settingsLayers[ConfigLayerProject]; project.IsEmpty() {
+			delete(config.Projects, m.settingsProjectID)
+		} else {
+			if config.Projects == nil {
+				config.Projects = make(map[int]*ReleaseSettings)
+			}
+			config.Projects[m.settingsProjectID] = project
+		}
+	}
+
+	if m.settingsProjectRoot != "" {
+		if err := SaveRepoConfig(m.settingsProjectRoot, m.settingsLayers[ConfigLayerRepo]); err != nil {
+			return fmt.Errorf("failed to save %s: %w", repoConfigFileName, err)
+		}
+	}
+	return nil
+}
+
+// settingsLayerOverridesSummary lists the settings the shown layer overrides
+func (m model) settingsLayerOverridesSummary() string {
+	if m.settingsLayer == ConfigLayerGlobal {
+		return ""
+	}
+	overrides := m.settingsLayerOverrides()
+	var fields []string
+	if overrides.BaseBranch != nil {
+		fields = append(fields, "base branch")
+	}
+	if overrides.Environments != nil {
+		fields = append(fields, "environments")
+	}
+	if overrides.ExcludePatterns != nil {
+		fields = append(fields, "exclude patterns")
+	}
+	if overrides.PipelineJobsRegex != nil {
+		fields = append(fields, "pipeline regex")
+	}
+	if overrides.Naming != nil {
+		fields = append(fields, "naming templates")
+	}
+	if overrides.TrackerRules != nil {
+		fields = append(fields, "tracker rules")
+	}
+	if overrides.GitLabRelease != nil {
+		fields = append(fields, "GitLab release")
+	}
+	if len(fields) == 0 {
+		return "Nothing overridden, all values are inherited."
+	}
+	return "Overrides: " + strings.Join(fields, ", ") + "."
+}
+
+// renderSettingsLayerSelector renders the layer switcher of the Release tab
+func (m model) renderSettingsLayerSelector(width int) string {
+	var b strings.Builder
+
+	project := m.settingsConfig.SelectedProjectName
+	if project == "" {
+		project = "the selected project"
+	}
+	repoPath := "the project root"
+	if m.settingsProjectRoot != "" {
+		repoPath = filepath.Join(m.settingsProjectRoot, repoConfigFileName)
+	}
+	desc := fmt.Sprintf("Global: ~/.relix/config.json. Repository: %s, checked in and shared with the team. "+
+		"Project: your overrides for %s. Each layer overrides the previous one; "+
+		"values equal to the inherited ones are not stored.", repoPath, project)
+	b.WriteString(helpStyle.Width(width).Render(desc))
+	b.WriteString("\n")
+
+	prompt := "  "
+	if m.settingsFocusIndex == settingsLayerIndex {
+		prompt = lipgloss.NewStyle().Foreground(currentTheme.Accent).Render("> ")
+	}
+	b.WriteString(prompt)
+	for layer := ConfigLayerGlobal; layer < configLayerCount; layer++ {
+		switch {
+		case layer == m.settingsLayer:
+			b.WriteString(settingsTabActiveStyle.Render(layer.String()))
+		case m.settingsLayerAvailable(layer):
+			b.WriteString(settingsTabStyle.Render(layer.String()))
+		default:
+			b.WriteString(helpStyle.Render(" " + layer.String() + " "))
+		}
+		b.WriteString(" ")
+	}
+	if summary := m.settingsLayerOverridesSummary(); summary != "" {
+		b.WriteString("\n")
+		b.WriteString(helpStyle.Render(summary))
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Naming template inputs of the Release tab, in focus order
+const (
+	settingsNamingSourceBranch = iota
+	settingsNamingEnvBranch
+	settingsNamingTag
+	settingsNamingCommitTitle
+	settingsNamingCount
+)
+
+// settingsNamingLabels are the labels of the naming template inputs
+var settingsNamingLabels = [settingsNamingCount]string{
+	"Source branch",
+	"Env release branch",
+	"Tag",
+	"Commit title",
+}
+
+// settingsNamingLabelWidth is the width of the naming template labels column
+const settingsNamingLabelWidth = 20
+
+// settingsNamingIndex returns the focus index of a naming template input
+func (m model) settingsNamingIndex(field int) int {
+	return m.settingsRegexIndex() + 1 + field
+}
+
+// settingsNamingFocus returns the naming template input under focus
+func (m model) settingsNamingFocus() (field int, ok bool) {
+	field = m.settingsFocusIndex - m.settingsNamingIndex(0)
+	if field < 0 || field >= len(m.settingsNaming) {
+		return 0, false
+	}
+	return field, true
+}
+
+// loadSettingsNaming fills the naming template inputs
+func (m *model) loadSettingsNaming(naming NamingTemplates) {
+	naming = naming.withDefaults()
+	defaults := defaultNamingTemplates()
+	values := [settingsNamingCount]string{naming.SourceBranch, naming.EnvBranch, naming.Tag, naming.CommitTitle}
+	placeholders := [settingsNamingCount]string{defaults.SourceBranch, defaults.EnvBranch, defaults.Tag, defaults.CommitTitle}
+
+	m.settingsNaming = make([]textinput.Model, settingsNamingCount)
+	for i := range m.settingsNaming {
+		m.settingsNaming[i] = newSettingsEnvInput(200, 40, placeholders[i])
+		m.settingsNaming[i].SetValue(values[i])
+	}
+}
+
+// blurSettingsNaming blurs all naming template inputs
+func (m *model) blurSettingsNaming() {
+	for i := range m.settingsNaming {
+		m.settingsNaming[i].Blur()
+	}
+}
+
+// settingsNamingTemplates returns the naming templates as edited on the Release tab
+func (m model) settingsNamingTemplates() NamingTemplates {
+	value := func(field int) string {
+		if field >= len(m.settingsNaming) {
+			return ""
+		}
+		return strings.TrimSpace(m.settingsNaming[field].Value())
+	}
+	return NamingTemplates{
+		SourceBranch: value(settingsNamingSourceBranch),
+		EnvBranch:    value(settingsNamingEnvBranch),
+		Tag:          value(settingsNamingTag),
+		CommitTitle:  value(settingsNamingCommitTitle),
+	}.withDefaults()
+}
+
+// validateSettingsNaming validates the naming templates and returns an error message if invalid
+func (m model) validateSettingsNaming() string {
+	for i := range m.settingsNaming {
+		if strings.TrimSpace(m.settingsNaming[i].Value()) == "" {
+			return fmt.Sprintf("%s template cannot be empty", settingsNamingLabels[i])
+		}
+	}
+	return validateNamingTemplates(m.settingsNamingTemplates())
+}
+
+// renderSettingsNamingRow renders a naming template input with its label and an example of the result
+func (m model) renderSettingsNamingRow(field int) string {
+	labelStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground).Width(settingsNamingLabelWidth)
+	row := labelStyle.Render(settingsNamingLabels[field]) + m.settingsNaming[field].View()
+
+	example := renderNamingTemplate(strings.TrimSpace(m.settingsNaming[field].Value()), NamingVars{
+		Version:   "1.0.0",
+		Env:       "dev",
+		EnvBranch: "develop",
+		N:         1,
+	})
+	exampleStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
+	return row + "\n" + strings.Repeat(" ", settingsNamingLabelWidth) + exampleStyle.Render("  e.g. "+example)
+}
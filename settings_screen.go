@@ -15,10 +15,8 @@ import (
 // Settings tabs
 var settingsTabs = []string{"Release", "Theme"}
 
-// Number of focusable elements per tab
-// 0=base branch, 1=env1 name, 2=env1 branch, 3=env2 name, 4=env2 branch,
-// 5=env3 name, 6=env3 branch, 7=env4 name, 8=env4 branch, 9=textarea, 10=pipeline regex, 11=save button
-const settingsReleaseFieldCount = 12
+// Number of focusable elements on the Theme tab.
+// The Release tab count depends on the number of environments, see settingsReleaseFieldCount.
 const settingsThemeFieldCount = 2 // theme list, save button
 
 // Default regex matching Package/Deploy jobs for known apps and environments
@@ -40,12 +38,10 @@ func (m model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		(&m).updateTextareaTheme()
 		m.screen = m.settingsPreviousScreen
 		m.settingsBaseBranch.Blur()
-		for i := 0; i < 4; i++ {
-			m.settingsEnvNames[i].Blur()
-			m.settingsEnvBranches[i].Blur()
-		}
+		m.blurSettingsEnvs()
 		m.settingsExcludePatterns.Blur()
 		m.settingsPipelineRegex.Blur()
+		m.blurSettingsNaming()
 		m.settingsError = ""
 		m.settingsFocusIndex = 0
 		return m, nil
@@ -66,12 +62,10 @@ func (m model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Switch to next tab
 		if m.settingsTab < len(settingsTabs)-1 {
 			m.settingsBaseBranch.Blur()
-			for i := 0; i < 4; i++ {
-				m.settingsEnvNames[i].Blur()
-				m.settingsEnvBranches[i].Blur()
-			}
+			m.blurSettingsEnvs()
 			m.settingsExcludePatterns.Blur()
 			m.settingsPipelineRegex.Blur()
+			m.blurSettingsNaming()
 			m.settingsTab++
 			m.settingsFocusIndex = 0
 			if m.settingsTab == 1 {
@@ -103,97 +97,160 @@ func (m model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateSettingsRelease handles key events on the Release settings tab
 func (m model) updateSettingsRelease(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fieldCount := m.settingsReleaseFieldCount()
+	patternsIndex := m.settingsPatternsIndex()
+	regexIndex := m.settingsRegexIndex()
+	gitLabReleaseIndex := m.settingsGitLabReleaseIndex()
+	worktreeIndex := m.settingsWorktreeIndex()
+	lazyDetailsIndex := m.settingsLazyMRDetailsIndex()
+	saveIndex := m.settingsSaveIndex()
+	envRow, envColumn, onEnv := m.settingsEnvFocus()
+	namingField, onNaming := m.settingsNamingFocus()
+
 	switch msg.String() {
 	case "tab":
-		m.settingsFocusIndex = (m.settingsFocusIndex + 1) % settingsReleaseFieldCount
+		m.settingsFocusIndex = (m.settingsFocusIndex + 1) % fieldCount
 		return m.updateSettingsFocus()
 
 	case "shift+tab":
-		m.settingsFocusIndex = (m.settingsFocusIndex - 1 + settingsReleaseFieldCount) % settingsReleaseFieldCount
+		m.settingsFocusIndex = (m.settingsFocusIndex - 1 + fieldCount) % fieldCount
 		return m.updateSettingsFocus()
 
+	case "ctrl+n":
+		return m.addSettingsEnv()
+
+	case "ctrl+x":
+		return m.removeSettingsEnv()
+
+	case "alt+up":
+		return m.moveSettingsEnv(-1)
+
+	case "alt+down":
+		return m.moveSettingsEnv(1)
+
 	case "down":
 		// Jump to same column in next row (skip textarea internals)
-		// 0=base → 1=env1 name; 1→3→5→7→9; 2→4→6→8→9; 9→break; 10→11
-		if m.settingsFocusIndex == 9 {
+		if m.settingsFocusIndex == patternsIndex {
 			// textarea: move focus to next field if cursor is on last line
 			if m.settingsExcludePatterns.Line() >= m.settingsExcludePatterns.LineCount()-1 {
-				m.settingsFocusIndex = 10
+				m.settingsFocusIndex = regexIndex
 				return m.updateSettingsFocus()
 			}
 			break
 		}
 		switch {
-		case m.settingsFocusIndex == 0:
-			m.settingsFocusIndex = 1
-		case m.settingsFocusIndex == 7: // last env name → textarea
-			m.settingsFocusIndex = 9
-		case m.settingsFocusIndex == 8: // last env branch → textarea
-			m.settingsFocusIndex = 9
-		case m.settingsFocusIndex%2 == 1 && m.settingsFocusIndex < 8: // env name → next env name
-			m.settingsFocusIndex += 2
-		case m.settingsFocusIndex%2 == 0 && m.settingsFocusIndex < 9: // env branch → next env branch
-			m.settingsFocusIndex += 2
-		case m.settingsFocusIndex == 10: // pipeline regex → save button
-			m.settingsFocusIndex = 11
-		case m.settingsFocusIndex == 11:
+		case m.settingsFocusIndex == settingsLayerIndex: // layer selector → base branch
+			m.settingsFocusIndex = settingsBaseBranchIndex
+		case m.settingsFocusIndex == settingsBaseBranchIndex: // base branch → first env name
+			m.settingsFocusIndex = settingsEnvFocusIndex(0, settingsEnvNameColumn)
+		case onEnv && envRow == len(m.settingsEnvs)-1: // last env row → textarea
+			m.settingsFocusIndex = patternsIndex
+		case onEnv: // env column → same column of next env
+			m.settingsFocusIndex = settingsEnvFocusIndex(envRow+1, envColumn)
+		case m.settingsFocusIndex == regexIndex: // pipeline regex → first naming template
+			m.settingsFocusIndex = m.settingsNamingIndex(0)
+		case onNaming: // naming template → next one, the last one → GitLab release toggle
+			m.settingsFocusIndex++
+		case m.settingsFocusIndex == gitLabReleaseIndex: // GitLab release toggle → worktree toggle
+			m.settingsFocusIndex = worktreeIndex
+		case m.settingsFocusIndex == worktreeIndex: // worktree toggle → lazy MR details toggle
+			m.settingsFocusIndex = lazyDetailsIndex
+		case m.settingsFocusIndex == lazyDetailsIndex: // lazy MR details toggle → save button
+			m.settingsFocusIndex = saveIndex
+		case m.settingsFocusIndex == saveIndex:
 			return m, nil
 		}
 		return m.updateSettingsFocus()
 
 	case "up":
 		// Jump to same column in previous row
-		if m.settingsFocusIndex == 9 {
-			// textarea: move focus to previous field if cursor is on first line
+		if m.settingsFocusIndex == patternsIndex {
+			// textarea: move focus to last env name if cursor is on first line
 			if m.settingsExcludePatterns.Line() == 0 {
-				m.settingsFocusIndex = 8
+				m.settingsFocusIndex = settingsEnvFocusIndex(len(m.settingsEnvs)-1, settingsEnvNameColumn)
 				return m.updateSettingsFocus()
 			}
 			break
 		}
 		switch {
-		case m.settingsFocusIndex == 0:
+		case m.settingsFocusIndex == settingsLayerIndex:
 			return m, nil
-		case m.settingsFocusIndex == 1: // first env name → base branch
-			m.settingsFocusIndex = 0
-		case m.settingsFocusIndex == 2: // first env branch → base branch
-			m.settingsFocusIndex = 0
-		case m.settingsFocusIndex == 10: // pipeline regex → textarea
-			m.settingsFocusIndex = 9
-		case m.settingsFocusIndex == 11: // save → pipeline regex
-			m.settingsFocusIndex = 10
-		case m.settingsFocusIndex%2 == 1: // env name → prev env name
-			m.settingsFocusIndex -= 2
-		case m.settingsFocusIndex%2 == 0: // env branch → prev env branch
-			m.settingsFocusIndex -= 2
+		case m.settingsFocusIndex == settingsBaseBranchIndex: // base branch → layer selector
+			m.settingsFocusIndex = settingsLayerIndex
+		case onEnv && envRow == 0: // first env row → base branch
+			m.settingsFocusIndex = settingsBaseBranchIndex
+		case onEnv: // env column → same column of previous env
+			m.settingsFocusIndex = settingsEnvFocusIndex(envRow-1, envColumn)
+		case m.settingsFocusIndex == regexIndex: // pipeline regex → textarea
+			m.settingsFocusIndex = patternsIndex
+		case onNaming && namingField == 0: // first naming template → pipeline regex
+			m.settingsFocusIndex = regexIndex
+		case onNaming: // naming template → previous one
+			m.settingsFocusIndex--
+		case m.settingsFocusIndex == gitLabReleaseIndex: // GitLab release toggle → last naming template
+			m.settingsFocusIndex = m.settingsNamingIndex(settingsNamingCount - 1)
+		case m.settingsFocusIndex == worktreeIndex: // worktree toggle → GitLab release toggle
+			m.settingsFocusIndex = gitLabReleaseIndex
+		case m.settingsFocusIndex == lazyDetailsIndex: // lazy MR details toggle → worktree toggle
+			m.settingsFocusIndex = worktreeIndex
+		case m.settingsFocusIndex == saveIndex: // save → lazy MR details toggle
+			m.settingsFocusIndex = lazyDetailsIndex
 		}
 		return m.updateSettingsFocus()
 
 	case "enter":
-		// If on save button (index 11), validate and save
-		if m.settingsFocusIndex == 11 {
+		// If on save button, validate and save
+		if m.settingsFocusIndex == saveIndex {
 			m.settingsError = m.validateReleaseSettings()
 			if m.settingsError == "" {
-				m.saveAllSettings()
+				if err := m.saveAllSettings(); err != nil {
+					m.settingsError = err.Error()
+					return m, nil
+				}
 				m.screen = m.settingsPreviousScreen
 				m.settingsExcludePatterns.Blur()
 				m.settingsPipelineRegex.Blur()
 				m.settingsBaseBranch.Blur()
-				for i := 0; i < 4; i++ {
-					m.settingsEnvNames[i].Blur()
-					m.settingsEnvBranches[i].Blur()
-				}
+				m.blurSettingsEnvs()
+				m.blurSettingsNaming()
 				m.settingsFocusIndex = 0
 			}
 			return m, nil
 		}
-		// If on textarea (index 9), pass through for newline
-		if m.settingsFocusIndex == 9 {
+		// If on textarea, pass through for newline
+		if m.settingsFocusIndex == patternsIndex {
 			break
 		}
+		// Toggles are switched in place
+		if m.settingsFocusIndex == worktreeIndex {
+			m.settingsReleaseWorktree = !m.settingsReleaseWorktree
+			return m, nil
+		}
+		if m.settingsFocusIndex == lazyDetailsIndex {
+			m.settingsLazyMRDetails = !m.settingsLazyMRDetails
+			return m, nil
+		}
+		if m.settingsFocusIndex == gitLabReleaseIndex {
+			m.settingsGitLabRelease = !m.settingsGitLabRelease
+			return m, nil
+		}
 		// On any text input, move to next field
-		m.settingsFocusIndex = (m.settingsFocusIndex + 1) % settingsReleaseFieldCount
+		m.settingsFocusIndex = (m.settingsFocusIndex + 1) % fieldCount
 		return m.updateSettingsFocus()
+
+	case " ":
+		if m.settingsFocusIndex == worktreeIndex {
+			m.settingsReleaseWorktree = !m.settingsReleaseWorktree
+			return m, nil
+		}
+		if m.settingsFocusIndex == lazyDetailsIndex {
+			m.settingsLazyMRDetails = !m.settingsLazyMRDetails
+			return m, nil
+		}
+		if m.settingsFocusIndex == gitLabReleaseIndex {
+			m.settingsGitLabRelease = !m.settingsGitLabRelease
+			return m, nil
+		}
 	}
 
 	// Route key events to the focused input
@@ -240,7 +297,10 @@ func (m model) updateSettingsTheme(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.settingsFocusIndex == 1 {
 			m.settingsError = m.validatePatterns()
 			if m.settingsError == "" {
-				m.saveAllSettings()
+				if err := m.saveAllSettings(); err != nil {
+					m.settingsThemeError = err.Error()
+					return m, nil
+				}
 				m.screen = m.settingsPreviousScreen
 				m.settingsFocusIndex = 0
 			}
@@ -252,6 +312,17 @@ func (m model) updateSettingsTheme(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// loadSettingsFromConfig fills the Release tab from the config file and the settings layers of the selected project
+func (m *model) loadSettingsFromConfig() {
+	config, err := LoadConfig()
+	if err != nil {
+		config = &AppConfig{}
+	}
+	m.settingsReleaseWorktree = config.ReleaseInWorktree
+	m.settingsLazyMRDetails = config.LazyMRDetails
+	m.loadSettingsLayers(config)
+}
+
 // loadSettingsThemes reloads themes from config file
 func (m *model) loadSettingsThemes() {
 	config, err := LoadConfig()
@@ -315,57 +386,74 @@ func (m *model) updateTextareaTheme() {
 	}
 	updateInputTheme(&m.settingsBaseBranch)
 	updateInputTheme(&m.settingsPipelineRegex)
-	for i := 0; i < 4; i++ {
-		updateInputTheme(&m.settingsEnvNames[i])
-		updateInputTheme(&m.settingsEnvBranches[i])
+	for i := range m.settingsEnvs {
+		updateInputTheme(&m.settingsEnvs[i].name)
+		updateInputTheme(&m.settingsEnvs[i].branch)
+		updateInputTheme(&m.settingsEnvs[i].color)
+	}
+	for i := range m.settingsNaming {
+		updateInputTheme(&m.settingsNaming[i])
 	}
 }
 
 // updateSettingsReleaseInput routes key events to the currently focused input
 func (m model) updateSettingsReleaseInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Jump between env name ↔ env branch on the same row via left/right at boundaries
-	if msg.String() == "right" {
-		if m.settingsFocusIndex%2 == 1 && m.settingsFocusIndex >= 1 && m.settingsFocusIndex <= 7 {
-			idx := (m.settingsFocusIndex - 1) / 2
-			if m.settingsEnvNames[idx].Position() >= len(m.settingsEnvNames[idx].Value()) {
-				m.settingsFocusIndex++
-				m.settingsEnvBranches[idx].SetCursor(0)
-				return m.updateSettingsFocus()
-			}
+	envRow, envColumn, onEnv := m.settingsEnvFocus()
+
+	// Switch the shown settings layer
+	if m.settingsFocusIndex == settingsLayerIndex {
+		switch msg.String() {
+		case "left", "h":
+			return m.switchSettingsLayer(-1)
+		case "right", "l":
+			return m.switchSettingsLayer(1)
 		}
+		return m, nil
 	}
-	if msg.String() == "left" {
-		if m.settingsFocusIndex%2 == 0 && m.settingsFocusIndex >= 2 && m.settingsFocusIndex <= 8 {
-			idx := (m.settingsFocusIndex - 2) / 2
-			if m.settingsEnvBranches[idx].Position() == 0 {
-				m.settingsFocusIndex--
-				m.settingsEnvNames[idx].SetCursor(len(m.settingsEnvNames[idx].Value()))
-				return m.updateSettingsFocus()
-			}
+
+	// Jump between env name ↔ branch ↔ color on the same row via left/right at boundaries
+	if onEnv {
+		input := m.settingsEnvs[envRow].input(envColumn)
+		if msg.String() == "right" && envColumn < settingsEnvColumns-1 && input.Position() >= len(input.Value()) {
+			m.settingsFocusIndex++
+			m.settingsEnvs[envRow].input(envColumn + 1).SetCursor(0)
+			return m.updateSettingsFocus()
+		}
+		if msg.String() == "left" && envColumn > 0 && input.Position() == 0 {
+			m.settingsFocusIndex--
+			prev := m.settingsEnvs[envRow].input(envColumn - 1)
+			prev.SetCursor(len(prev.Value()))
+			return m.updateSettingsFocus()
 		}
 	}
 
 	var cmd tea.Cmd
-	switch m.settingsFocusIndex {
-	case 0: // Base branch
+	switch {
+	case m.settingsFocusIndex == settingsBaseBranchIndex: // Base branch
 		m.settingsBaseBranch, cmd = m.settingsBaseBranch.Update(msg)
-	case 1, 3, 5, 7: // Env names (indices 1,3,5,7)
-		idx := (m.settingsFocusIndex - 1) / 2
-		m.settingsEnvNames[idx], cmd = m.settingsEnvNames[idx].Update(msg)
-		// Force uppercase
-		upper := strings.ToUpper(m.settingsEnvNames[idx].Value())
-		if upper != m.settingsEnvNames[idx].Value() {
-			pos := m.settingsEnvNames[idx].Position()
-			m.settingsEnvNames[idx].SetValue(upper)
-			m.settingsEnvNames[idx].SetCursor(pos)
+	case onEnv:
+		input := m.settingsEnvs[envRow].input(envColumn)
+		*input, cmd = input.Update(msg)
+		if envColumn == settingsEnvNameColumn {
+			// Force uppercase
+			upper := strings.ToUpper(input.Value())
+			if upper != input.Value() {
+				pos := input.Position()
+				input.SetValue(upper)
+				input.SetCursor(pos)
+			}
+		}
+		if envColumn == settingsEnvColorColumn {
+			// Live color validation, the rest is validated on save
+			m.settingsError = ""
+			if color := strings.TrimSpace(input.Value()); color != "" && !isValidHexColor(color) {
+				m.settingsError = fmt.Sprintf("Environment %d: color must be a hex color like #FF8800", envRow+1)
+			}
 		}
-	case 2, 4, 6, 8: // Env branches (indices 2,4,6,8)
-		idx := (m.settingsFocusIndex - 2) / 2
-		m.settingsEnvBranches[idx], cmd = m.settingsEnvBranches[idx].Update(msg)
-	case 9: // Textarea
+	case m.settingsFocusIndex == m.settingsPatternsIndex(): // Textarea
 		m.settingsExcludePatterns, cmd = m.settingsExcludePatterns.Update(msg)
 		m.settingsError = m.validatePatterns()
-	case 10: // Pipeline regex
+	case m.settingsFocusIndex == m.settingsRegexIndex(): // Pipeline regex
 		m.settingsPipelineRegex, cmd = m.settingsPipelineRegex.Update(msg)
 		// Live regex validation
 		regexStr := strings.TrimSpace(m.settingsPipelineRegex.Value())
@@ -378,6 +466,12 @@ func (m model) updateSettingsReleaseInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.settingsError = ""
 		}
+	default:
+		if field, ok := m.settingsNamingFocus(); ok { // Naming template
+			m.settingsNaming[field], cmd = m.settingsNaming[field].Update(msg)
+			// Live template validation
+			m.settingsError = m.validateSettingsNaming()
+		}
 	}
 	return m, cmd
 }
@@ -386,29 +480,27 @@ func (m model) updateSettingsReleaseInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m model) updateSettingsFocus() (tea.Model, tea.Cmd) {
 	// Blur everything first
 	m.settingsBaseBranch.Blur()
-	for i := 0; i < 4; i++ {
-		m.settingsEnvNames[i].Blur()
-		m.settingsEnvBranches[i].Blur()
-	}
+	m.blurSettingsEnvs()
 	m.settingsExcludePatterns.Blur()
 	m.settingsPipelineRegex.Blur()
+	m.blurSettingsNaming()
 
 	// Focus the right input
+	if envRow, envColumn, ok := m.settingsEnvFocus(); ok {
+		return m, m.settingsEnvs[envRow].input(envColumn).Focus()
+	}
+	if field, ok := m.settingsNamingFocus(); ok {
+		return m, m.settingsNaming[field].Focus()
+	}
 	switch m.settingsFocusIndex {
-	case 0:
+	case settingsBaseBranchIndex:
 		return m, m.settingsBaseBranch.Focus()
-	case 1, 3, 5, 7:
-		idx := (m.settingsFocusIndex - 1) / 2
-		return m, m.settingsEnvNames[idx].Focus()
-	case 2, 4, 6, 8:
-		idx := (m.settingsFocusIndex - 2) / 2
-		return m, m.settingsEnvBranches[idx].Focus()
-	case 9:
+	case m.settingsPatternsIndex():
 		return m, m.settingsExcludePatterns.Focus()
-	case 10:
+	case m.settingsRegexIndex():
 		return m, m.settingsPipelineRegex.Focus()
 	}
-	// Index 11 = save button, nothing to focus
+	// Layer selector, worktree toggle and save button, nothing to focus
 	return m, nil
 }
 
@@ -492,19 +584,9 @@ func (m *model) validateReleaseSettings() string {
 		return "Base branch cannot contain spaces"
 	}
 
-	// Validate environment names and branches
-	for i := 0; i < 4; i++ {
-		name := strings.TrimSpace(m.settingsEnvNames[i].Value())
-		branch := strings.TrimSpace(m.settingsEnvBranches[i].Value())
-		if name == "" {
-			return fmt.Sprintf("Environment %d: name cannot be empty", i+1)
-		}
-		if branch == "" {
-			return fmt.Sprintf("Environment %d: branch cannot be empty", i+1)
-		}
-		if strings.Contains(branch, " ") {
-			return fmt.Sprintf("Environment %d: branch cannot contain spaces", i+1)
-		}
+	// Validate environments
+	if err := m.validateSettingsEnvs(); err != "" {
+		return err
 	}
 
 	// Validate exclude patterns
@@ -520,37 +602,52 @@ func (m *model) validateReleaseSettings() string {
 		}
 	}
 
+	// Validate naming templates
+	if err := m.validateSettingsNaming(); err != "" {
+		return err
+	}
+
 	return ""
 }
 
-// saveAllSettings saves all settings across tabs to config file
-func (m *model) saveAllSettings() {
+// saveAllSettings saves all settings across tabs to config file and the repository .relix.json
+func (m *model) saveAllSettings() error {
 	config, err := LoadConfig()
 	if err != nil {
 		config = &AppConfig{}
 	}
-	// Release tab: base branch
-	config.BaseBranch = strings.TrimSpace(m.settingsBaseBranch.Value())
-	// Release tab: environments
-	config.Environments = make([]EnvConfig, 4)
-	for i := 0; i < 4; i++ {
-		config.Environments[i] = EnvConfig{
-			Name:       strings.TrimSpace(m.settingsEnvNames[i].Value()),
-			BranchName: strings.TrimSpace(m.settingsEnvBranches[i].Value()),
-		}
+	// Release tab: settings of every layer
+	if err := m.saveSettingsLayers(config); err != nil {
+		return err
 	}
-	// Release tab: exclude patterns
-	config.ExcludePatterns = m.settingsExcludePatterns.Value()
-	// Release tab: pipeline jobs regex
-	config.PipelineJobsRegex = strings.TrimSpace(m.settingsPipelineRegex.Value())
+	// Release tab: release worktree
+	config.ReleaseInWorktree = m.settingsReleaseWorktree
+	// Release tab: lazy MR details
+	config.LazyMRDetails = m.settingsLazyMRDetails
 	// Theme tab: selected theme
 	if m.settingsThemeIndex < len(m.settingsThemes) {
 		config.SelectedTheme = m.settingsThemes[m.settingsThemeIndex].Name
 	}
-	SaveConfig(config)
+	if err := SaveConfig(config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
 
-	// Rebuild runtime environments from saved config
+	// Rebuild runtime environments and tracker rules from saved config
 	m.environments = getEnvironments()
+	m.trackerRules = getTrackerRules()
+
+	// Keep the environment selection on the same branch; environments may have been removed or reordered
+	selectedIndex := 0
+	for i, env := range m.environments {
+		if m.selectedEnv != nil && env.BranchName == m.selectedEnv.BranchName {
+			selectedIndex = i
+		}
+	}
+	m.envSelectIndex = selectedIndex
+	if m.selectedEnv != nil {
+		m.selectedEnv = &m.environments[selectedIndex]
+	}
+	return nil
 }
 
 // settingsContentWidth returns the usable content width inside the settings screen
@@ -623,7 +720,7 @@ func (m *model) scrollSettingsToFocus(fieldLines map[int][2]int) {
 	bottomMargin := 1
 
 	// Save button (last field): ensure at least 1 empty line visible below it
-	if m.settingsFocusIndex == settingsReleaseFieldCount-1 {
+	if m.settingsTab == 0 && m.settingsFocusIndex == m.settingsSaveIndex() {
 		offset := fieldBottom + 2 - vpHeight
 		if offset < 0 {
 			offset = 0
@@ -735,37 +832,37 @@ func (m model) renderReleaseSettings() releaseSettingsResult {
 
 	contentWidth := m.settingsContentWidth()
 
+	// --- Settings layer ---
+	write(settingsLabelStyle.Render("Settings layer"))
+	write("\n")
+	layerStart := line
+	write(m.renderSettingsLayerSelector(contentWidth))
+	fl[settingsLayerIndex] = [2]int{layerStart, line} // layer selector
+	write("\n\n")
+
 	// --- Base branch ---
 	write(settingsLabelStyle.Render("Base branch"))
 	write("\n")
 	write(helpStyle.Render("Branch from which release branches are created and merged back to."))
 	write("\n")
 
-	fl[0] = [2]int{line, line} // base branch input
+	fl[settingsBaseBranchIndex] = [2]int{line, line} // base branch input
 	write(m.settingsBaseBranch.View())
 	write("\n\n")
 
 	// --- Environment branches ---
 	write(settingsLabelStyle.Render("Environment branches"))
 	write("\n")
-	write(helpStyle.Render("Customize environment names and their git branch mappings."))
+	envDesc := "Customize environment names, their git branch mappings and colors (empty color = theme palette). " +
+		"ctrl+n: add • ctrl+x: remove • alt+↑/↓: move."
+	write(helpStyle.Width(contentWidth).Render(envDesc))
 	write("\n")
 
-	envColors := []lipgloss.Color{currentTheme.EnvDevelop, currentTheme.EnvTest, currentTheme.EnvStage, currentTheme.EnvProd}
-	arrowStyle := lipgloss.NewStyle().Foreground(currentTheme.Notion)
-
-	for i := 0; i < 4; i++ {
-		dot := lipgloss.NewStyle().Foreground(envColors[i]).Render("●")
-		m.settingsEnvNames[i].Width = 10
-		m.settingsEnvBranches[i].Width = 20
-		fl[i*2+1] = [2]int{line, line} // env name
-		fl[i*2+2] = [2]int{line, line} // env branch (same row)
-		write(fmt.Sprintf("%s  %s  %s  %s",
-			dot,
-			m.settingsEnvNames[i].View(),
-			arrowStyle.Render("→"),
-			m.settingsEnvBranches[i].View(),
-		))
+	for i := range m.settingsEnvs {
+		for column := 0; column < settingsEnvColumns; column++ {
+			fl[settingsEnvFocusIndex(i, column)] = [2]int{line, line} // env inputs share the row
+		}
+		write(m.renderSettingsEnvRow(i))
 		write("\n")
 	}
 
@@ -782,7 +879,7 @@ func (m model) renderReleaseSettings() releaseSettingsResult {
 	m.settingsExcludePatterns.SetWidth(contentWidth)
 	m.settingsExcludePatterns.SetHeight(6)
 	write(m.settingsExcludePatterns.View())
-	fl[9] = [2]int{textareaStart, line} // textarea (multi-line)
+	fl[m.settingsPatternsIndex()] = [2]int{textareaStart, line} // textarea (multi-line)
 
 	// --- Pipeline jobs regex ---
 	write("\n\n")
@@ -793,9 +890,94 @@ func (m model) renderReleaseSettings() releaseSettingsResult {
 	write(helpStyle.Width(contentWidth).Render(desc2))
 	write("\n")
 
-	fl[10] = [2]int{line, line} // pipeline regex input
+	fl[m.settingsRegexIndex()] = [2]int{line, line} // pipeline regex input
 	write(m.settingsPipelineRegex.View())
 
+	// --- Naming templates ---
+	write("\n\n")
+	write(settingsLabelStyle.Render("Naming templates"))
+	write("\n")
+	desc4 := "Names of the branches, the tag and the release commit title (also the environment MR title). " +
+		"Placeholders: {version}, {env} (lowercase environment name), {envBranch}, {n} (release number within the version), {date} (YYYY-MM-DD). " +
+		"Release numbers are read back from commit titles on the environment branch, so the title needs {version} and {n}."
+	write(helpStyle.Width(contentWidth).Render(desc4))
+	write("\n")
+	for i := range m.settingsNaming {
+		if i > 0 {
+			write("\n")
+		}
+		fl[m.settingsNamingIndex(i)] = [2]int{line, line + 1} // naming template input and its example
+		write(m.renderSettingsNamingRow(i))
+	}
+
+	// --- GitLab release ---
+	write("\n\n")
+	write(settingsLabelStyle.Render("GitLab release"))
+	write("\n")
+	desc5 := "After the root push, create a GitLab Release of the tag with the release notes " +
+		"and links to the environment MR and its pipeline. The tag is annotated with the release commit title."
+	write(helpStyle.Width(contentWidth).Render(desc5))
+	write("\n")
+
+	fl[m.settingsGitLabReleaseIndex()] = [2]int{line, line} // GitLab release toggle
+	releaseCheckbox := "[ ] "
+	if m.settingsGitLabRelease {
+		releaseCheckbox = "[✓] "
+	}
+	releaseToggleStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+	if m.settingsFocusIndex == m.settingsGitLabReleaseIndex() {
+		releaseToggleStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+	}
+	write(releaseToggleStyle.Render(releaseCheckbox + "Create a GitLab Release"))
+
+	// --- Release worktree ---
+	write("\n\n")
+	write(settingsLabelStyle.Render("Release worktree"))
+	write("\n")
+	desc3 := "Run releases in a dedicated git worktree under ~/.relix/worktrees/<project> " +
+		"instead of your working copy, so you can keep working while a release runs."
+	write(helpStyle.Width(contentWidth).Render(desc3))
+	write("\n")
+
+	fl[m.settingsWorktreeIndex()] = [2]int{line, line} // worktree toggle
+	checkbox := "[ ] "
+	if m.settingsReleaseWorktree {
+		checkbox = "[✓] "
+	}
+	toggleStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+	if m.settingsFocusIndex == m.settingsWorktreeIndex() {
+		toggleStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+	}
+	toggleLabel := "Release in a dedicated worktree"
+	if m.settingsLayer != ConfigLayerGlobal {
+		toggleLabel += " (global setting, not stored in the layer)"
+	}
+	write(toggleStyle.Render(checkbox + toggleLabel))
+
+	// --- Lazy MR details ---
+	write("\n\n")
+	write(settingsLabelStyle.Render("MR details"))
+	write("\n")
+	desc6 := "Fetch commits, changes and discussions of an MR only when it is focused in the list, " +
+		"so long MR lists open fast. Otherwise the details of all MRs are fetched when the list loads."
+	write(helpStyle.Width(contentWidth).Render(desc6))
+	write("\n")
+
+	fl[m.settingsLazyMRDetailsIndex()] = [2]int{line, line} // lazy MR details toggle
+	lazyCheckbox := "[ ] "
+	if m.settingsLazyMRDetails {
+		lazyCheckbox = "[✓] "
+	}
+	lazyToggleStyle := lipgloss.NewStyle().Foreground(currentTheme.Foreground)
+	if m.settingsFocusIndex == m.settingsLazyMRDetailsIndex() {
+		lazyToggleStyle = lipgloss.NewStyle().Foreground(currentTheme.Accent)
+	}
+	lazyToggleLabel := "Fetch MR details on focus"
+	if m.settingsLayer != ConfigLayerGlobal {
+		lazyToggleLabel += " (global setting, not stored in the layer)"
+	}
+	write(lazyToggleStyle.Render(lazyCheckbox + lazyToggleLabel))
+
 	// Error hint
 	if m.settingsError != "" {
 		write("\n")
@@ -804,10 +986,10 @@ func (m model) renderReleaseSettings() releaseSettingsResult {
 
 	// Save button (centered)
 	write("\n\n")
-	fl[11] = [2]int{line, line} // save button
+	fl[m.settingsSaveIndex()] = [2]int{line, line} // save button
 	buttonText := "Save and close"
 	var btnStyle lipgloss.Style
-	if m.settingsFocusIndex == 11 && m.settingsError == "" {
+	if m.settingsFocusIndex == m.settingsSaveIndex() && m.settingsError == "" {
 		btnStyle = buttonActiveStyle
 	} else {
 		btnStyle = buttonStyle
@@ -916,10 +1098,9 @@ func (m model) renderThemeSettings() string {
 			renderColor("warning_fg", resolved.WarningForeground),
 			renderColor("error", resolved.Error),
 			renderColor("error_fg", resolved.ErrorForeground),
-			renderColor("env_develop", resolved.EnvDevelop),
-			renderColor("env_test", resolved.EnvTest),
-			renderColor("env_stage", resolved.EnvStage),
-			renderColor("env_prod", resolved.EnvProd),
+		}
+		for i, color := range resolved.EnvColors {
+			colorEntries = append(colorEntries, renderColor(fmt.Sprintf("env_colors[%d]", i), color))
 		}
 	}
 
@@ -23,10 +23,14 @@ func (m *model) initSourceBranchInput() tea.Cmd {
 	ti.TextStyle = lipgloss.NewStyle().Foreground(currentTheme.Foreground)
 	ti.Cursor.Style = lipgloss.NewStyle().Foreground(currentTheme.Accent)
 
-	// Set default value based on version
+	// Set default value from the source branch template
 	version := m.versionInput.Value()
 	if version != "" {
-		ti.SetValue("release/rpb-" + version + "-root")
+		vars := NamingVars{Version: version}
+		if m.selectedEnv != nil {
+			vars.Env, vars.EnvBranch = m.selectedEnv.Name, m.selectedEnv.BranchName
+		}
+		ti.SetValue(renderNamingTemplate(getNamingTemplates().SourceBranch, vars))
 	}
 
 	m.sourceBranchInput = ti
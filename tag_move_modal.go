@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tagCollision returns the pending tag move of a release suspended on a tag collision, or nil
+func (m model) tagCollision() *TagMove {
+	state := m.releaseState
+	if state == nil || state.LastError == nil || state.LastError.Code != "TAG_COLLISION" {
+		return nil
+	}
+	return state.TagMove
+}
+
+// updateTagMoveConfirm handles key events in the tag move confirmation modal
+func (m model) updateTagMoveConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.showTagMoveConfirm = false
+		return m.moveReleaseTag()
+	case "enter":
+		m.showTagMoveConfirm = false
+		if m.tagMoveConfirmIndex == 0 {
+			return m.moveReleaseTag()
+		}
+		return m, nil
+	case "n", "N", "esc":
+		m.showTagMoveConfirm = false
+		return m, nil
+	case "tab", "right", "l", "shift+tab", "left", "h":
+		m.tagMoveConfirmIndex = 1 - m.tagMoveConfirmIndex
+		return m, nil
+	}
+	return m, nil
+}
+
+// moveReleaseTag confirms moving the colliding tag and retries the root push
+func (m model) moveReleaseTag() (tea.Model, tea.Cmd) {
+	move := m.tagCollision()
+	if move == nil {
+		return m, nil
+	}
+	NewReleaseEngine(m.releaseState, nil, nil).ConfirmTagMove()
+	m.appendReleaseOutput("")
+	m.appendReleaseOutput(fmt.Sprintf("Moving tag %s on origin from %s to %s", move.Tag, shortSHA(move.OldSHA), shortSHA(move.NewSHA)))
+	return m.retryRelease()
+}
+
+// overlayTagMoveConfirm renders the tag move confirmation modal
+func (m model) overlayTagMoveConfirm(background string) string {
+	move := m.tagCollision()
+	if move == nil {
+		return background
+	}
+
+	var sb strings.Builder
+	sb.WriteString(errorTitleStyle.Render("Move Tag?"))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("Tag %s already exists on origin.\n\n", releaseOrangeStyle.Render(move.Tag)))
+	sb.WriteString(fmt.Sprintf("  origin:  %s\n", move.OldSHA))
+	sb.WriteString(fmt.Sprintf("  release: %s\n\n", move.NewSHA))
+	sb.WriteString("Moving it rewrites the tag for everyone\nwho has already fetched it.\n\n")
+
+	var yesBtn, cancelBtn string
+	if m.tagMoveConfirmIndex == 0 {
+		yesBtn = buttonDangerStyle.Render("Yes, move")
+		cancelBtn = buttonStyle.Render("Cancel")
+	} else {
+		yesBtn = buttonStyle.Render("Yes, move")
+		cancelBtn = buttonActiveStyle.Render("Cancel")
+	}
+	sb.WriteString(fmt.Sprintf("     %s     %s", yesBtn, cancelBtn))
+
+	config := ModalConfig{
+		Width:    ModalWidth{Value: 60, Percent: false},
+		MinWidth: 50,
+		MaxWidth: 70,
+		Style:    errorBoxStyle,
+	}
+
+	modal := renderModal(sb.String(), config, m.width)
+	return placeOverlayCenter(modal, background, m.width, m.height)
+}
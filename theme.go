@@ -30,11 +30,13 @@ type ThemeConfig struct {
 	ErrorForeground  string `json:"error_foreground,omitempty"`
 	Muted            string `json:"muted,omitempty"`            // Subtle background for inactive elements
 	MutedForeground  string `json:"muted_foreground,omitempty"` // Text color on muted background
-	// Optional environment color overrides
+	// Optional environment color overrides for the first four environments
 	EnvDevelop string `json:"env_develop,omitempty"`
 	EnvTest    string `json:"env_test,omitempty"`
 	EnvStage   string `json:"env_stage,omitempty"`
 	EnvProd    string `json:"env_prod,omitempty"`
+	// Optional environment palette, cycled by environment position. Overrides the slots above.
+	EnvColors []string `json:"env_colors,omitempty"`
 }
 
 // ThemeColors holds resolved lipgloss colors for the current theme
@@ -54,11 +56,9 @@ type ThemeColors struct {
 	ErrorForeground  lipgloss.Color // Text color on error background
 	Muted            lipgloss.Color // Subtle background for inactive elements (buttons, code blocks, borders)
 	MutedForeground  lipgloss.Color // Text color on muted background
-	// Environment colors
-	EnvDevelop lipgloss.Color
-	EnvTest    lipgloss.Color
-	EnvStage   lipgloss.Color
-	EnvProd    lipgloss.Color
+	// Environment palette, cycled by environment position, with paired foregrounds
+	EnvColors      []lipgloss.Color
+	EnvForegrounds []lipgloss.Color
 }
 
 // Default indigo theme colors (hardcoded fallback)
@@ -76,10 +76,18 @@ var defaultThemeColors = ThemeColors{
 	ErrorForeground:   lipgloss.Color("#D7D7FF"),
 	Muted:             lipgloss.Color("#2A2A3C"),
 	MutedForeground:   lipgloss.Color("#686889"),
-	EnvDevelop:        lipgloss.Color("#5F5FDF"),
-	EnvTest:           lipgloss.Color("#FFD600"),
-	EnvStage:          lipgloss.Color("#00D588"),
-	EnvProd:           lipgloss.Color("#FF84A8"),
+	EnvColors: []lipgloss.Color{
+		lipgloss.Color("#5F5FDF"),
+		lipgloss.Color("#FFD600"),
+		lipgloss.Color("#00D588"),
+		lipgloss.Color("#FF84A8"),
+	},
+	EnvForegrounds: []lipgloss.Color{
+		lipgloss.Color("231"),
+		lipgloss.Color("#D7D7FF"),
+		lipgloss.Color("#D7D7FF"),
+		lipgloss.Color("#D7D7FF"),
+	},
 }
 
 // currentTheme holds the active theme colors
@@ -161,13 +169,45 @@ func themeFromConfig(tc ThemeConfig) ThemeColors {
 		MutedForeground:   resolveForegroundColor(tc.MutedForeground, tc.Foreground, defaultThemeColors.MutedForeground),
 	}
 	// Environment colors default to base theme colors if not specified
-	colors.EnvDevelop = resolveColor(tc.EnvDevelop, colors.Accent)
-	colors.EnvTest = resolveColor(tc.EnvTest, colors.Warning)
-	colors.EnvStage = resolveColor(tc.EnvStage, colors.Success)
-	colors.EnvProd = resolveColor(tc.EnvProd, colors.Error)
+	colors.EnvColors = []lipgloss.Color{
+		resolveColor(tc.EnvDevelop, colors.Accent),
+		resolveColor(tc.EnvTest, colors.Warning),
+		resolveColor(tc.EnvStage, colors.Success),
+		resolveColor(tc.EnvProd, colors.Error),
+	}
+	colors.EnvForegrounds = []lipgloss.Color{
+		colors.AccentForeground,
+		colors.WarningForeground,
+		colors.SuccessForeground,
+		colors.ErrorForeground,
+	}
+	// An explicit palette replaces the slots; colors past the fourth extend it
+	for i, c := range tc.EnvColors {
+		if !isValidHexColor(c) {
+			continue
+		}
+		if i < len(colors.EnvColors) {
+			colors.EnvColors[i] = lipgloss.Color(c)
+			continue
+		}
+		colors.EnvColors = append(colors.EnvColors, lipgloss.Color(c))
+		colors.EnvForegrounds = append(colors.EnvForegrounds, contrastForeground(c))
+	}
 	return colors
 }
 
+// contrastForeground returns black or white text, whichever reads better on a #RRGGBB background
+func contrastForeground(hex string) lipgloss.Color {
+	r, _ := strconv.ParseUint(hex[1:3], 16, 8)
+	g, _ := strconv.ParseUint(hex[3:5], 16, 8)
+	b, _ := strconv.ParseUint(hex[5:7], 16, 8)
+	// Perceived brightness (ITU-R BT.601 luma)
+	if 299*r+587*g+114*b > 150000 {
+		return lipgloss.Color("#1C1C1C")
+	}
+	return lipgloss.Color("#FFFFFF")
+}
+
 // loadThemeFromConfig loads and applies the selected theme from config
 func loadThemeFromConfig() {
 	config, err := LoadConfig()
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracker API types of tracker rules
+const (
+	trackerTypeJira   = "jira"
+	trackerTypeGitLab = "gitlab"
+)
+
+// TrackerIssueDetails holds the issue fields shown next to its key
+type TrackerIssueDetails struct {
+	Title    string
+	Status   string
+	Assignee string // Empty if unassigned
+}
+
+// TrackerIssueResult is the outcome of fetching an issue. Both fields are nil while it is being fetched.
+type TrackerIssueResult struct {
+	Details *TrackerIssueDetails
+	Err     error
+}
+
+// TrackerClient fetches issues from an issue tracker
+type TrackerClient interface {
+	GetIssue(id string) (*TrackerIssueDetails, error)
+}
+
+// issueIDTemplate returns the template expanding a match into the tracker issue ID
+func (r TrackerRule) issueIDTemplate() string {
+	if r.IssueID != "" {
+		return r.IssueID
+	}
+	if strings.EqualFold(r.Type, trackerTypeGitLab) {
+		return "$1"
+	}
+	return "$0"
+}
+
+// newTrackerClient creates the API client of a rule, nil if the rule has no tracker API.
+// GitLab trackers default to the GitLab URL and token of the credentials.
+func newTrackerClient(rule TrackerRule, creds *Credentials) (TrackerClient, error) {
+	token := ""
+	if rule.TokenEnv != "" {
+		token = os.Getenv(rule.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("%s: environment variable %s is not set", rule.Name, rule.TokenEnv)
+		}
+	}
+	baseURL := strings.TrimSuffix(rule.APIURL, "/")
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	switch strings.ToLower(rule.Type) {
+	case "":
+		return nil, nil
+	case trackerTypeJira:
+		if baseURL == "" {
+			return nil, fmt.Errorf("%s: api_url is required for Jira", rule.Name)
+		}
+		return &JiraClient{baseURL: baseURL, token: token, client: client}, nil
+	case trackerTypeGitLab:
+		if creds != nil {
+			credsURL := strings.TrimSuffix(creds.GitLabURL, "/")
+			if baseURL == "" {
+				baseURL = credsURL
+			}
+			// Never send the GitLab token to another server
+			if token == "" && baseURL == credsURL {
+				token = creds.Token
+			}
+		}
+		if baseURL == "" {
+			return nil, fmt.Errorf("%s: api_url is required without GitLab credentials", rule.Name)
+		}
+		if rule.Project == "" {
+			return nil, fmt.Errorf("%s: project is required for GitLab issues", rule.Name)
+		}
+		return &GitLabIssuesClient{baseURL: baseURL, token: token, project: rule.Project, client: client}, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown tracker type %q, use %s or %s", rule.Name, rule.Type, trackerTypeJira, trackerTypeGitLab)
+	}
+}
+
+// fetchTrackerIssues fetches the details of issues in parallel, keyed by issue key.
+// Issues of rules without a tracker API are skipped.
+func fetchTrackerIssues(issues []TrackerIssue, creds *Credentials) map[string]*TrackerIssueResult {
+	results := make(map[string]*TrackerIssueResult)
+	clients := make(map[TrackerRule]TrackerClient)
+	clientErrs := make(map[TrackerRule]error)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, issue := range issues {
+		if _, ok := clients[issue.Rule]; !ok && clientErrs[issue.Rule] == nil {
+			client, err := newTrackerClient(issue.Rule, creds)
+			clients[issue.Rule], clientErrs[issue.Rule] = client, err
+		}
+		if err := clientErrs[issue.Rule]; err != nil {
+			results[issue.Key] = &TrackerIssueResult{Err: err}
+			continue
+		}
+		client := clients[issue.Rule]
+		if client == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(issue TrackerIssue) {
+			defer wg.Done()
+			details, err := client.GetIssue(issue.ID)
+			mu.Lock()
+			results[issue.Key] = &TrackerIssueResult{Details: details, Err: err}
+			mu.Unlock()
+		}(issue)
+	}
+	wg.Wait()
+	return results
+}
+
+// trackerGet sends a GET request to a tracker API and decodes the JSON response into v
+func trackerGet(client *http.Client, reqURL string, auth func(*http.Request), tracker, id string, v any) error {
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	auth(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("network error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		return fmt.Errorf("%s authentication failed", tracker)
+	case resp.StatusCode == 404:
+		return fmt.Errorf("%s issue %s not found", tracker, id)
+	case resp.StatusCode != 200:
+		return fmt.Errorf("%s API error: status %d", tracker, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// JiraClient fetches issues from the Jira REST API
+type JiraClient struct {
+	baseURL string
+	token   string // Bearer token, or "user:token" for basic auth
+	client  *http.Client
+}
+
+// auth sets the authorization header of a Jira request
+func (c *JiraClient) auth(req *http.Request) {
+	if user, token, ok := strings.Cut(c.token, ":"); ok {
+		req.SetBasicAuth(user, token)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+// GetIssue retrieves the summary, status and assignee of a Jira issue by key
+func (c *JiraClient) GetIssue(id string) (*TrackerIssueDetails, error) {
+	reqURL := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,status,assignee", c.baseURL, url.PathEscape(id))
+
+	var issue struct {
+		Fields struct {
+			Summary string `json:"summary"`
+			Status  struct {
+				Name string `json:"name"`
+			} `json:"status"`
+			Assignee *struct {
+				DisplayName string `json:"displayName"`
+			} `json:"assignee"`
+		} `json:"fields"`
+	}
+	if err := trackerGet(c.client, reqURL, c.auth, "Jira", id, &issue); err != nil {
+		return nil, err
+	}
+
+	details := &TrackerIssueDetails{Title: issue.Fields.Summary, Status: issue.Fields.Status.Name}
+	if issue.Fields.Assignee != nil {
+		details.Assignee = issue.Fields.Assignee.DisplayName
+	}
+	return details, nil
+}
+
+// GitLabIssuesClient fetches issues of a GitLab project
+type GitLabIssuesClient struct {
+	baseURL string
+	token   string
+	project string // Project path or ID
+	client  *http.Client
+}
+
+// auth sets the token header of a GitLab request
+func (c *GitLabIssuesClient) auth(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+}
+
+// GetIssue retrieves the title, status and assignee of a project issue by IID. The status is
+// the value of a scoped "Status::" label if the issue has one, its state otherwise.
+func (c *GitLabIssuesClient) GetIssue(id string) (*TrackerIssueDetails, error) {
+	reqURL := fmt.Sprintf("%s/api/v4/projects/%s/issues/%s", c.baseURL, url.PathEscape(c.project), url.PathEscape(id))
+
+	var issue struct {
+		Title     string   `json:"title"`
+		State     string   `json:"state"`
+		Labels    []string `json:"labels"`
+		Assignees []struct {
+			Name string `json:"name"`
+		} `json:"assignees"`
+	}
+	if err := trackerGet(c.client, reqURL, c.auth, "GitLab", "#"+id, &issue); err != nil {
+		return nil, err
+	}
+
+	details := &TrackerIssueDetails{Title: issue.Title, Status: gitLabIssueState(issue.State)}
+	for _, label := range issue.Labels {
+		if prefix, value, ok := strings.Cut(label, "::"); ok && strings.EqualFold(prefix, "status") {
+			details.Status = value
+			break
+		}
+	}
+	names := make([]string, len(issue.Assignees))
+	for i, assignee := range issue.Assignees {
+		names[i] = assignee.Name
+	}
+	details.Assignee = strings.Join(names, ", ")
+	return details, nil
+}
+
+// gitLabIssueState returns the display status of a GitLab issue state
+func gitLabIssueState(state string) string {
+	switch state {
+	case "opened":
+		return "Open"
+	case "closed":
+		return "Closed"
+	default:
+		return state
+	}
+}
+
+// allowedIssueStatuses returns the issue statuses allowed for releasing to the environment
+func (e EnvConfig) allowedIssueStatuses() []string {
+	var statuses []string
+	for _, status := range strings.Split(e.IssueStatuses, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// issueStatusAllowed reports whether an issue in the status can be released to the environment.
+// Any status is allowed if the environment lists none.
+func issueStatusAllowed(env *EnvConfig, status string) bool {
+	if env == nil {
+		return true
+	}
+	allowed := env.allowedIssueStatuses()
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, s := range allowed {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// stubIssues are the issues served by the tracker stub in the tests
+var stubIssues = map[string]TrackerIssueDetails{
+	"PROJ-1": {Title: "Login form", Status: "Ready for Test", Assignee: "Ann"},
+	"PROJ-2": {Title: "Search", Status: "In Progress"},
+	"7":      {Title: "Export", Status: "closed", Assignee: "Bob"},
+	"8":      {Title: "Import", Status: "Review"},
+}
+
+func TestJiraClient(t *testing.T) {
+	stub := NewTrackerStub(stubIssues)
+	defer stub.Close()
+	stub.Token = "secret"
+	t.Setenv("RELIX_TEST_JIRA_TOKEN", "secret")
+
+	rule := TrackerRule{Name: "Jira", Pattern: `PROJ-\d+`, Type: "jira", APIURL: stub.URL() + "/", TokenEnv: "RELIX_TEST_JIRA_TOKEN"}
+	client, err := newTrackerClient(rule, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := client.GetIssue("PROJ-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *details != stubIssues["PROJ-1"] {
+		t.Errorf("PROJ-1 = %+v", *details)
+	}
+	if details, err := client.GetIssue("PROJ-2"); err != nil || details.Assignee != "" {
+		t.Errorf("unassigned PROJ-2 = %+v, %v", details, err)
+	}
+	if _, err := client.GetIssue("PROJ-404"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing issue error = %v", err)
+	}
+
+	stub.Token = "other"
+	if _, err := client.GetIssue("PROJ-1"); err == nil || !strings.Contains(err.Error(), "authentication failed") {
+		t.Errorf("wrong token error = %v", err)
+	}
+}
+
+func TestGitLabIssuesClient(t *testing.T) {
+	stub := NewTrackerStub(stubIssues)
+	defer stub.Close()
+	stub.Token = "gitlab-token"
+
+	// The token of the credentials is used for the GitLab instance they belong to
+	creds := &Credentials{GitLabURL: stub.URL(), Token: "gitlab-token"}
+	rule := TrackerRule{Name: "Issues", Pattern: `#(\d+)`, Type: "gitlab", Project: "group/project"}
+	client, err := newTrackerClient(rule, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		id   string
+		want TrackerIssueDetails
+	}{
+		{"7", TrackerIssueDetails{Title: "Export", Status: "Closed", Assignee: "Bob"}},
+		{"8", TrackerIssueDetails{Title: "Import", Status: "Review"}},
+	}
+	for _, tt := range tests {
+		details, err := client.GetIssue(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *details != tt.want {
+			t.Errorf("#%s = %+v, want %+v", tt.id, *details, tt.want)
+		}
+	}
+
+	// Never send the GitLab token to another server
+	otherStub := NewTrackerStub(stubIssues)
+	defer otherStub.Close()
+	otherStub.Token = "gitlab-token"
+	rule.APIURL = otherStub.URL()
+	other, err := newTrackerClient(rule, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.GetIssue("7"); err == nil || !strings.Contains(err.Error(), "authentication failed") {
+		t.Errorf("request to another server error = %v, want it sent without the GitLab token", err)
+	}
+}
+
+func TestNewTrackerClientErrors(t *testing.T) {
+	tests := []struct {
+		rule TrackerRule
+		want string
+	}{
+		{TrackerRule{Name: "Jira", Type: "jira"}, "api_url is required"},
+		{TrackerRule{Name: "Issues", Type: "gitlab", APIURL: "https://gitlab.local"}, "project is required"},
+		{TrackerRule{Name: "Issues", Type: "gitlab", Project: "group/project"}, "api_url is required without GitLab credentials"},
+		{TrackerRule{Name: "Jira", Type: "jira", APIURL: "https://jira.local", TokenEnv: "RELIX_TEST_UNSET_TOKEN"}, "RELIX_TEST_UNSET_TOKEN is not set"},
+		{TrackerRule{Name: "YouTrack", Type: "youtrack"}, "unknown tracker type"},
+	}
+	for _, tt := range tests {
+		if _, err := newTrackerClient(tt.rule, nil); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("newTrackerClient(%+v) error = %v, want %q", tt.rule, err, tt.want)
+		}
+	}
+	if client, err := newTrackerClient(TrackerRule{Name: "Links"}, nil); client != nil || err != nil {
+		t.Errorf("rule without type = %v, %v; want no client", client, err)
+	}
+}
+
+func TestFetchTrackerIssuesStatusWarnings(t *testing.T) {
+	stub := NewTrackerStub(stubIssues)
+	defer stub.Close()
+
+	rules := []TrackerRule{
+		{Name: "Jira", Pattern: `PROJ-\d+`, URL: "https://jira.local/browse/$0", Type: "jira", APIURL: stub.URL()},
+		{Name: "Issues", Pattern: `#(\d+)`, URL: "https://gitlab.local/group/project/-/issues/$1", Type: "gitlab", APIURL: stub.URL(), Project: "group/project"},
+		{Name: "Links", Pattern: `LINK-\d+`, URL: "https://links.local/$0"},
+	}
+	issues := findTrackerIssues(rules, "feature/PROJ-1-login", "PROJ-2 search, fixes #7 and #8, see LINK-1", "feature/PROJ-1-more")
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.Key)
+	}
+	if got := strings.Join(keys, " "); got != "PROJ-1 PROJ-2 #7 #8 LINK-1" {
+		t.Fatalf("found issues %s", got)
+	}
+	if issues[2].ID != "7" || issues[2].URL != "https://gitlab.local/group/project/-/issues/7" {
+		t.Errorf("#7 = %+v", issues[2])
+	}
+
+	m := model{trackerIssues: fetchTrackerIssues(issues, nil)}
+	if _, ok := m.trackerIssues["LINK-1"]; ok {
+		t.Error("issue of a rule without tracker API was fetched")
+	}
+
+	env := &EnvConfig{Name: "test", IssueStatuses: "Ready for Test, Closed"}
+	tests := []struct {
+		key        string
+		notAllowed bool
+	}{
+		{"PROJ-1", false}, // Ready for Test
+		{"PROJ-2", true},  // In Progress
+		{"#7", false},     // Closed, matched case-insensitively
+		{"#8", true},      // Review
+		{"LINK-1", false}, // Not fetched
+	}
+	for i, tt := range tests {
+		if got := m.issueNotAllowed(issues[i], env); got != tt.notAllowed {
+			t.Errorf("issueNotAllowed(%s) = %v, want %v", tt.key, got, tt.notAllowed)
+		}
+		// Any status is allowed when the environment lists none
+		if m.issueNotAllowed(issues[i], &EnvConfig{Name: "dev"}) || m.issueNotAllowed(issues[i], nil) {
+			t.Errorf("%s not allowed without issue statuses", tt.key)
+		}
+	}
+
+	markdown := m.trackerIssuesListMarkdown(issues, env)
+	for _, want := range []string{
+		"- [PROJ-1](https://jira.local/browse/PROJ-1) Login form `Ready for Test` → Ann\n",
+		"- [PROJ-2](https://jira.local/browse/PROJ-2) Search `In Progress` *not ready for TEST, expected Ready for Test or Closed*\n",
+		"- [#8](https://gitlab.local/group/project/-/issues/8) Import `Review` *not ready for TEST",
+		"- [LINK-1](https://links.local/LINK-1)\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("issues list misses %q:\n%s", want, markdown)
+		}
+	}
+}
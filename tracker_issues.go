@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadTrackerIssues returns a command fetching the details of the issues not fetched yet
+func (m *model) loadTrackerIssues(issues []TrackerIssue) tea.Cmd {
+	var pending []TrackerIssue
+	for _, issue := range issues {
+		if issue.Rule.Type == "" || m.trackerIssues[issue.Key] != nil {
+			continue
+		}
+		// Mark as being fetched so it is requested once
+		m.trackerIssues[issue.Key] = &TrackerIssueResult{}
+		pending = append(pending, issue)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	creds := m.creds
+	return func() tea.Msg {
+		return trackerIssuesMsg{issues: fetchTrackerIssues(pending, creds)}
+	}
+}
+
+// highlightedMRIssues returns the issues linked to the MR under the list cursor
+func (m model) highlightedMRIssues() []TrackerIssue {
+	if item, ok := m.list.SelectedItem().(mrListItem); ok && item.MR() != nil {
+		return findTrackerIssues(m.trackerRules, item.MR().SourceBranch, item.MR().Title)
+	}
+	return nil
+}
+
+// selectedMRsIssues returns the issues linked to the MRs selected for release, or to the
+// branches of the release being promoted
+func (m model) selectedMRsIssues() []TrackerIssue {
+	if m.promotion != nil {
+		return findTrackerIssues(m.trackerRules, m.promotion.MRBranches...)
+	}
+	var texts []string
+	for _, mr := range m.selectedMRDetails() {
+		texts = append(texts, mr.SourceBranch, mr.Title)
+	}
+	return findTrackerIssues(m.trackerRules, texts...)
+}
+
+// selectedEnvConfig returns the configuration of the selected environment, or nil
+func (m model) selectedEnvConfig() *EnvConfig {
+	if m.selectedEnv == nil {
+		return nil
+	}
+	return envConfigByBranch(getEnvironmentConfigs(), m.selectedEnv.BranchName)
+}
+
+// issueNotAllowed reports whether a fetched issue is in a status not allowed for the environment
+func (m model) issueNotAllowed(issue TrackerIssue, env *EnvConfig) bool {
+	result := m.trackerIssues[issue.Key]
+	return result != nil && result.Details != nil && !issueStatusAllowed(env, result.Details.Status)
+}
+
+// trackerIssuesListMarkdown renders issues as a markdown list of links with their fetched title,
+// status and assignee. Issues in a status not allowed for env are marked, env may be nil.
+func (m model) trackerIssuesListMarkdown(issues []TrackerIssue, env *EnvConfig) string {
+	var b strings.Builder
+	for _, issue := range issues {
+		fmt.Fprintf(&b, "- [%s](%s)", issue.Key, issue.URL)
+
+		result := m.trackerIssues[issue.Key]
+		switch {
+		case result == nil:
+		case result.Err != nil:
+			b.WriteString(" *unavailable: " + result.Err.Error() + "*")
+		case result.Details == nil:
+			b.WriteString(" loading...")
+		default:
+			details := result.Details
+			fmt.Fprintf(&b, " %s `%s`", details.Title, details.Status)
+			if details.Assignee != "" {
+				b.WriteString(" → " + details.Assignee)
+			}
+			if m.issueNotAllowed(issue, env) {
+				fmt.Fprintf(&b, " *not ready for %s, expected %s*",
+					strings.ToUpper(env.Name), strings.Join(env.allowedIssueStatuses(), " or "))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"regexp"
+)
+
+// TrackerRule links issue keys found in branch names and MR titles to an issue tracker
+type TrackerRule struct {
+	Name    string `json:"name"`    // Tracker name shown in open options, e.g. "Jira Task"
+	Pattern string `json:"pattern"` // Go regexp matching an issue key, e.g. "RUSSPASS-(\\d+)"
+	URL     string `json:"url"`     // Issue URL template: $0 is the whole key, $1 or ${name} a capture group
+
+	// Optional issue details fetched from the tracker API
+	Type     string `json:"type,omitempty"`      // Tracker API: "jira" or "gitlab", links only if empty
+	APIURL   string `json:"api_url,omitempty"`   // Tracker base URL, the GitLab URL of the credentials if empty for "gitlab"
+	Project  string `json:"project,omitempty"`   // GitLab project path or ID holding the issues
+	IssueID  string `json:"issue_id,omitempty"`  // Issue ID template, "$0" for "jira" and "$1" for "gitlab" if empty
+	TokenEnv string `json:"token_env,omitempty"` // Environment variable with the API token, "user:token" for basic auth
+}
+
+// TrackerIssue is an issue key matched by a tracker rule
+type TrackerIssue struct {
+	Tracker string // Name of the rule that matched
+	Key     string // Whole match, e.g. "RUSSPASS-1234"
+	URL     string
+	ID      string      // Issue ID in the tracker API, e.g. "RUSSPASS-1234" or "42"
+	Rule    TrackerRule // Rule that matched
+}
+
+// defaultTrackerRules returns the rules used when no config layer sets tracker rules
+func defaultTrackerRules() []TrackerRule {
+	return []TrackerRule{
+		{Name: "Jira Task", Pattern: `RUSSPASS-(\d+)`, URL: "https://itpm.mos.ru/browse/RUSSPASS-$1"},
+	}
+}
+
+// trackerRulesOf returns the tracker rules of a config with defaults if unset
+func trackerRulesOf(config *AppConfig) []TrackerRule {
+	if config == nil || config.TrackerRules == nil {
+		return defaultTrackerRules()
+	}
+	return config.TrackerRules
+}
+
+// getTrackerRules returns the tracker rules of the selected project
+func getTrackerRules() []TrackerRule {
+	config, _ := LoadSelectedProjectConfig()
+	return trackerRulesOf(config)
+}
+
+// findTrackerIssues returns the issues the rules match in the texts, each key once,
+// ordered by rule and then by text. Rules with an invalid pattern are skipped.
+func findTrackerIssues(rules []TrackerRule, texts ...string) []TrackerIssue {
+	var issues []TrackerIssue
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		for _, text := range texts {
+			for _, match := range re.FindAllStringSubmatchIndex(text, -1) {
+				key := text[match[0]:match[1]]
+				if key == "" || seen[key] {
+					continue
+				}
+				seen[key] = true
+				url := string(re.ExpandString(nil, rule.URL, text, match))
+				id := string(re.ExpandString(nil, rule.issueIDTemplate(), text, match))
+				issues = append(issues, TrackerIssue{Tracker: rule.Name, Key: key, URL: url, ID: id, Rule: rule})
+			}
+		}
+	}
+	return issues
+}
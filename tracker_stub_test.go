@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// TrackerStub is a local HTTP server answering the Jira and GitLab issue endpoints relix
+// uses, so tracker clients can run without a real tracker.
+type TrackerStub struct {
+	Server *httptest.Server
+	Issues map[string]TrackerIssueDetails // Issues by Jira key or GitLab IID
+	Token  string                         // Required token if set: Bearer or PRIVATE-TOKEN
+}
+
+// NewTrackerStub starts a stub serving the given issues
+func NewTrackerStub(issues map[string]TrackerIssueDetails) *TrackerStub {
+	s := &TrackerStub{Issues: issues}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/2/issue/{key}", s.serveJiraIssue)
+	mux.HandleFunc("GET /api/v4/projects/{project}/issues/{iid}", s.serveGitLabIssue)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// URL returns the base URL of the stub, usable as a tracker rule api_url
+func (s *TrackerStub) URL() string {
+	return s.Server.URL
+}
+
+// Close shuts the stub down
+func (s *TrackerStub) Close() {
+	s.Server.Close()
+}
+
+// authorized reports whether a request carries the required token
+func (s *TrackerStub) authorized(r *http.Request) bool {
+	if s.Token == "" {
+		return true
+	}
+	return r.Header.Get("Authorization") == "Bearer "+s.Token || r.Header.Get("PRIVATE-TOKEN") == s.Token
+}
+
+// issue looks up an issue, writing the error response if the request cannot be served
+func (s *TrackerStub) issue(w http.ResponseWriter, r *http.Request, id string) (TrackerIssueDetails, bool) {
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return TrackerIssueDetails{}, false
+	}
+	issue, ok := s.Issues[id]
+	if !ok {
+		http.NotFound(w, r)
+	}
+	return issue, ok
+}
+
+// serveJiraIssue answers GET /rest/api/2/issue/{key}
+func (s *TrackerStub) serveJiraIssue(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	issue, ok := s.issue(w, r, key)
+	if !ok {
+		return
+	}
+
+	fields := map[string]any{
+		"summary":  issue.Title,
+		"status":   map[string]string{"name": issue.Status},
+		"assignee": nil,
+	}
+	if issue.Assignee != "" {
+		fields["assignee"] = map[string]string{"displayName": issue.Assignee}
+	}
+	writeStubJSON(w, map[string]any{"key": key, "fields": fields})
+}
+
+// serveGitLabIssue answers GET /api/v4/projects/{project}/issues/{iid}. The status is
+// returned as the state if it is "opened" or "closed", as a "Status::" label otherwise.
+func (s *TrackerStub) serveGitLabIssue(w http.ResponseWriter, r *http.Request) {
+	issue, ok := s.issue(w, r, r.PathValue("iid"))
+	if !ok {
+		return
+	}
+
+	state, labels := "opened", []string{}
+	switch strings.ToLower(issue.Status) {
+	case "opened", "closed":
+		state = strings.ToLower(issue.Status)
+	default:
+		labels = append(labels, "Status::"+issue.Status)
+	}
+	assignees := []map[string]string{}
+	if issue.Assignee != "" {
+		assignees = append(assignees, map[string]string{"name": issue.Assignee})
+	}
+	writeStubJSON(w, map[string]any{
+		"title":     issue.Title,
+		"state":     state,
+		"labels":    labels,
+		"assignees": assignees,
+	})
+}
+
+// writeStubJSON writes v as a JSON response
+func writeStubJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
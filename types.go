@@ -21,11 +21,13 @@ const (
 	screenSourceBranch
 	screenEnvMerge
 	screenRootMerge
+	screenPreflight
 	screenConfirm
 	screenRelease
 	screenHistoryList
 	screenHistoryDetail
 	screenSettings
+	screenConflict
 )
 
 // Environment represents a deployment environment
@@ -61,27 +63,27 @@ func (i listItem) FilterValue() string { return i.title }
 
 // MergeRequest represents a GitLab merge request
 type MergeRequest struct {
-	ID           int       `json:"id"`
-	IID          int       `json:"iid"`
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	State        string    `json:"state"`
-	SourceBranch string    `json:"source_branch"`
-	TargetBranch string    `json:"target_branch"`
-	CreatedAt    time.Time `json:"created_at"`
-	Draft        bool      `json:"draft"`
-	Author       struct {
-		ID       int    `json:"id"`
-		Username string `json:"username"`
-		Name     string `json:"name"`
-	} `json:"author"`
-	WebURL                      string `json:"web_url"`
-	UserNotesCount              int    `json:"user_notes_count"`
-	ChangesCount                string `json:"changes_count"`
-	HasConflicts                bool   `json:"has_conflicts"`
-	BlockingDiscussionsResolved bool   `json:"blocking_discussions_resolved"`
-	SHA                         string `json:"sha"`              // HEAD commit of source branch
-	MergeCommitSHA              string `json:"merge_commit_sha"` // Commit SHA after merge
+	ID                          int        `json:"id"`
+	IID                         int        `json:"iid"`
+	ProjectID                   int        `json:"project_id"`
+	Title                       string     `json:"title"`
+	Description                 string     `json:"description"`
+	State                       string     `json:"state"`
+	SourceBranch                string     `json:"source_branch"`
+	TargetBranch                string     `json:"target_branch"`
+	CreatedAt                   time.Time  `json:"created_at"`
+	Draft                       bool       `json:"draft"`
+	Labels                      []string   `json:"labels"`
+	Author                      GitLabUser `json:"author"`
+	Milestone                   *Milestone `json:"milestone"`
+	HeadPipeline                *Pipeline  `json:"head_pipeline"` // Only set by the single MR endpoint
+	WebURL                      string     `json:"web_url"`
+	UserNotesCount              int        `json:"user_notes_count"`
+	ChangesCount                string     `json:"changes_count"`
+	HasConflicts                bool       `json:"has_conflicts"`
+	BlockingDiscussionsResolved bool       `json:"blocking_discussions_resolved"`
+	SHA                         string     `json:"sha"`              // HEAD commit of source branch
+	MergeCommitSHA              string     `json:"merge_commit_sha"` // Commit SHA after merge
 }
 
 // MergeRequestDetails contains additional MR details
@@ -91,9 +93,84 @@ type MergeRequestDetails struct {
 		Additions int `json:"additions"`
 		Deletions int `json:"deletions"`
 	} `json:"diff_stats"`
-	CommitsCount        int `json:"-"`
-	DiscussionsTotal    int `json:"-"`
-	DiscussionsResolved int `json:"-"`
+	CommitsCount        int  `json:"-"`
+	DiscussionsTotal    int  `json:"-"`
+	DiscussionsResolved int  `json:"-"`
+	DetailsLoaded       bool `json:"-"` // False for MRs listed in lazy mode until their details are fetched
+
+	LabelDetails []Label      `json:"-"`
+	Commits      []Commit     `json:"-"`
+	Discussions  []Discussion `json:"-"`
+	Approvals    *Approvals   `json:"-"`
+
+	// Why a part of the details could not be fetched, nil if it was
+	ChangesErr     error `json:"-"`
+	CommitsErr     error `json:"-"`
+	DiscussionsErr error `json:"-"`
+	ApprovalsErr   error `json:"-"`
+}
+
+// GitLabUser is a GitLab user as referenced by MRs, notes and approvals
+type GitLabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// Label is a GitLab project label
+type Label struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// Milestone is a GitLab milestone
+type Milestone struct {
+	ID      int    `json:"id"`
+	IID     int    `json:"iid"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	DueDate string `json:"due_date"` // YYYY-MM-DD, empty without a due date
+	WebURL  string `json:"web_url"`
+}
+
+// Commit is a commit of a merge request
+type Commit struct {
+	ID           string    `json:"id"`
+	ShortID      string    `json:"short_id"`
+	Title        string    `json:"title"`
+	AuthorName   string    `json:"author_name"`
+	AuthoredDate time.Time `json:"authored_date"`
+	WebURL       string    `json:"web_url"`
+}
+
+// Discussion is a discussion thread of a merge request
+type Discussion struct {
+	ID             string `json:"id"`
+	IndividualNote bool   `json:"individual_note"`
+	Notes          []Note `json:"notes"`
+}
+
+// Note is a comment in a discussion
+type Note struct {
+	ID         int        `json:"id"`
+	Body       string     `json:"body"`
+	Author     GitLabUser `json:"author"`
+	System     bool       `json:"system"`
+	Resolvable bool       `json:"resolvable"`
+	Resolved   bool       `json:"resolved"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Approvals is the approval state of a merge request
+type Approvals struct {
+	Approved          bool `json:"approved"`
+	ApprovalsRequired int  `json:"approvals_required"`
+	ApprovalsLeft     int  `json:"approvals_left"`
+	ApprovedBy        []struct {
+		User GitLabUser `json:"user"`
+	} `json:"approved_by"`
 }
 
 // mrListItem represents a merge request in the list
@@ -115,6 +192,18 @@ type fetchMRsMsg struct {
 	err error
 }
 
+// mrDetailsMsg is sent when the details of a lazily listed MR are fetched
+type mrDetailsMsg struct {
+	mr      *MergeRequestDetails // List item the details belong to
+	details *MergeRequestDetails
+	err     error
+}
+
+// trackerIssuesMsg is sent when linked issue details are fetched
+type trackerIssuesMsg struct {
+	issues map[string]*TrackerIssueResult
+}
+
 // Project represents a GitLab project
 type Project struct {
 	ID                int    `json:"id"`
@@ -133,8 +222,15 @@ type fetchProjectsMsg struct {
 
 // EnvConfig represents a configurable environment with its display name and git branch
 type EnvConfig struct {
-	Name       string `json:"name"`        // Display name (shown UPPERCASED in UI)
-	BranchName string `json:"branch_name"` // Git branch name
+	Name       string `json:"name"`            // Display name (shown UPPERCASED in UI)
+	BranchName string `json:"branch_name"`     // Git branch name
+	Color      string `json:"color,omitempty"` // Hex color (#RRGGBB), theme palette if empty
+
+	// Optional per-environment settings
+	ExcludePatterns   string `json:"exclude_patterns,omitempty"`   // Extra file patterns excluded from releases to this environment, one per line
+	EnvMergeMode      string `json:"env_merge_mode,omitempty"`     // Preselected env merge mode: "squash", "regular" or "cherry-pick"
+	IssueStatuses     string `json:"issue_statuses,omitempty"`     // Comma separated linked issue statuses allowed for releases to this environment
+	PreflightBlocking string `json:"preflight_blocking,omitempty"` // Comma separated pre-flight checks whose failures block releases to this environment, or "all"
 }
 
 // AppConfig represents the application configuration saved to file
@@ -145,10 +241,20 @@ type AppConfig struct {
 	SelectedProjectShortName string `json:"selected_project_short_name"`
 
 	// Release settings
-	BaseBranch        string      `json:"base_branch"`                       // Base branch for releases (default "root")
-	Environments      []EnvConfig `json:"environments,omitempty"`            // Customizable environment branches
-	ExcludePatterns   string      `json:"exclude_patterns"`                  // File patterns to exclude from release, one per line
-	PipelineJobsRegex string      `json:"pipeline_jobs_regex,omitempty"`     // Regex to match observable pipeline job names
+	BaseBranch        string          `json:"base_branch"`                   // Base branch for releases (default "root")
+	Environments      []EnvConfig     `json:"environments,omitempty"`        // Customizable environment branches
+	ExcludePatterns   string          `json:"exclude_patterns"`              // File patterns to exclude from release, one per line
+	PipelineJobsRegex string          `json:"pipeline_jobs_regex,omitempty"` // Regex to match observable pipeline job names
+	ReleaseInWorktree bool            `json:"release_in_worktree,omitempty"` // Run releases in ~/.relix/worktrees/<project>-<hash> instead of the user's checkout
+	Naming            NamingTemplates `json:"naming,omitzero"`               // Branch, tag and commit title templates
+	TrackerRules      []TrackerRule   `json:"tracker_rules,omitempty"`       // Issue tracker links of keys in branch names and MR titles
+	GitLabRelease     bool            `json:"gitlab_release,omitempty"`      // Create a GitLab Release of the tag after the root push
+
+	// Per-project release settings overrides by project ID, applied over the repository .relix.json
+	Projects map[int]*ReleaseSettings `json:"projects,omitempty"`
+
+	// MR list settings
+	LazyMRDetails bool `json:"lazy_mr_details,omitempty"` // Fetch MR details only when the MR is focused in the list
 
 	// Theme settings
 	SelectedTheme string        `json:"selected_theme,omitempty"` // Name of the active theme
@@ -171,10 +277,50 @@ const (
 	ReleaseStepPushAndCreateMR             // Step 7: create GitLab MR (branches already pushed)
 	ReleaseStepWaitForRootPush             // Step 8: waiting for user to press "Push root branches" button
 	ReleaseStepPushRootBranches            // Step 9: tag/push source branch, merge to root, push root, merge to develop, push develop
+	ReleaseStepCreateRelease               // Step 9b: create the GitLab Release of the tag (if enabled)
 	ReleaseStepSwitchToRoot                // Step 10: switch back to root branch
 	ReleaseStepComplete                    // Done
 )
 
+// SkippedMR is an MR removed from the merge queue during a release
+type SkippedMR struct {
+	Branch string `json:"branch"`
+	URL    string `json:"url,omitempty"`
+	IID    int    `json:"iid,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ReleaseNoteEntry is the release notes item of an MR in its section
+type ReleaseNoteEntry struct {
+	IID     int    `json:"iid"`
+	Section string `json:"section"`
+	Text    string `json:"text"` // Markdown list item with the linked issues
+}
+
+// TagMove is a release tag that already existed on origin at another commit
+type TagMove struct {
+	Tag       string `json:"tag"`
+	OldSHA    string `json:"old_sha"`   // Commit of the tag on origin
+	NewSHA    string `json:"new_sha"`   // Commit of the release
+	Confirmed bool   `json:"confirmed"` // The user confirmed moving the tag
+}
+
+// CherryPick is a commit picked onto the env release branch in cherry-pick env merge mode
+type CherryPick struct {
+	Branch   string `json:"branch"` // MR source branch the commit belongs to
+	SHA      string `json:"sha"`
+	Mainline bool   `json:"mainline,omitempty"` // Merge commit of the MR, picked against its first parent
+}
+
+// Rollback is a redeploy of a previous release of the environment, undoing the releases after it
+type Rollback struct {
+	RestoredID string `json:"restored_id"`           // History ID of the release redeployed
+	Restored   string `json:"restored"`              // Name of the release redeployed
+	RevertedID string `json:"reverted_id,omitempty"` // History ID of the release rolled back
+	Reverted   string `json:"reverted,omitempty"`    // Name of the release rolled back
+	SHA        string `json:"sha"`                   // Env release commit whose tree is restored
+}
+
 // ReleaseError holds error details for a failed step
 type ReleaseError struct {
 	Step    ReleaseStep `json:"step"`
@@ -194,17 +340,30 @@ type ReleaseState struct {
 	BaseBranch           string      `json:"base_branch"`            // Base branch (e.g. "root") for crash-recovery
 	SourceBranch         string      `json:"source_branch"`          // Source branch for accumulating MRs (e.g. release/rpb_1.0.0_root)
 	SourceBranchIsRemote bool        `json:"source_branch_is_remote"` // Whether source branch exists on remote (determines checkout strategy)
+	SourceBranchSHA      string      `json:"source_branch_sha,omitempty"` // Commit the remote source branch must point at, set when promoting a release
+	PromotedFrom         string      `json:"promoted_from,omitempty"` // Release promoted to this environment as is, without merging MRs
+	Rollback             *Rollback   `json:"rollback,omitempty"`      // Previous release redeployed to the environment instead of MRs
 	RootMerge            bool        `json:"root_merge"`             // Whether to merge release to root and root to develop
-	EnvMergeMode         string      `json:"env_merge_mode"`         // "squash" (default) or "regular" - how to merge root to env
+	EnvMergeMode         string      `json:"env_merge_mode"`         // "squash" (default), "regular" or "cherry-pick" - how to merge root to env
 	ProjectID            int         `json:"project_id"`
+	ProjectName          string      `json:"project_name,omitempty"` // Project short name for the pending releases list
+	ProjectPath          string      `json:"project_path,omitempty"` // Project path with namespace
+	Naming               NamingTemplates `json:"naming,omitzero"`     // Naming templates the release was started with
+	StartedAt            time.Time   `json:"started_at,omitzero"`      // Release start, the {date} of the naming templates
+	ReleaseNotes         string      `json:"release_notes,omitempty"`  // Markdown release notes of the selected MRs, the env MR description
+	ReleaseNoteEntries   []ReleaseNoteEntry `json:"release_note_entries,omitempty"` // Release notes items of the MRs, ReleaseNotes is rebuilt from them when an MR is dropped
+	GitLabRelease        bool        `json:"gitlab_release,omitempty"` // Whether to create a GitLab Release of the tag after the root push
 
 	// Progress tracking
-	CurrentStep       ReleaseStep `json:"current_step"`
-	LastSuccessStep   ReleaseStep `json:"last_success_step"`
-	CurrentMRIndex    int         `json:"current_mr_index"` // For step 2: which MR we're merging
-	MergedBranches    []string    `json:"merged_branches"`  // Successfully merged branches
-	TotalSubSteps     int         `json:"total_sub_steps"`
-	CompletedSubSteps int         `json:"completed_sub_steps"`
+	CurrentStep       ReleaseStep  `json:"current_step"`
+	LastSuccessStep   ReleaseStep  `json:"last_success_step"`
+	CurrentMRIndex    int          `json:"current_mr_index"`            // For step 2: which MR we're merging
+	MergedBranches    []string     `json:"merged_branches"`             // Successfully merged branches
+	SkippedMRs        []SkippedMR  `json:"skipped_mrs,omitempty"`       // MRs removed from the merge queue mid-release
+	CherryPicks       []CherryPick `json:"cherry_picks,omitempty"`      // Commits to pick onto the env release branch in cherry-pick mode, in order
+	CherryPickIndex   int          `json:"cherry_pick_index,omitempty"` // Next commit of CherryPicks to pick
+	TotalSubSteps     int          `json:"total_sub_steps"`
+	CompletedSubSteps int          `json:"completed_sub_steps"`
 
 	// Error info
 	LastError   *ReleaseError `json:"last_error,omitempty"`
@@ -218,10 +377,15 @@ type ReleaseState struct {
 	CreatedMRIID int    `json:"created_mr_iid,omitempty"`
 
 	// Tag info (created during root push step)
-	TagName string `json:"tag_name,omitempty"`
+	TagName       string   `json:"tag_name,omitempty"`
+	ReleaseNumber int      `json:"release_number,omitempty"` // Ordinal number of the release within the version ({n})
+	TagMove       *TagMove `json:"tag_move,omitempty"`       // Collision of the tag with another commit on origin
 
 	// Working directory
-	WorkDir string `json:"work_dir"` // Project root path
+	WorkDir     string `json:"work_dir"`               // Directory the release runs in (project root or release worktree)
+	ProjectRoot string `json:"project_root,omitempty"` // User's checkout when the release runs in a dedicated worktree
+
+	UpdatedAt time.Time `json:"updated_at,omitempty"` // Last time the state was saved
 }
 
 // ReleaseButton represents an action button in the release screen
@@ -234,6 +398,9 @@ const (
 	ReleaseButtonPushRoot
 	ReleaseButtonComplete
 	ReleaseButtonOpen // Single "Open" button replaces OpenMR and OpenPipeline
+	ReleaseButtonResolve
+	ReleaseButtonQueue
+	ReleaseButtonMoveTag
 )
 
 // Bubble Tea messages for release execution
@@ -254,9 +421,10 @@ type releaseCommandEndMsg struct {
 }
 
 type releaseStepCompleteMsg struct {
-	step   ReleaseStep
-	err    error
-	output string
+	step        ReleaseStep
+	err         error
+	output      string
+	cherryPicks []CherryPick // Commits planned by the env checkout in cherry-pick mode
 }
 
 type existingReleaseMsg struct {
@@ -275,6 +443,29 @@ type setProgramMsg struct {
 
 type releaseSubStepDoneMsg struct{}
 
+// releaseStepStartedMsg is sent when the release engine starts running a step
+type releaseStepStartedMsg struct {
+	step ReleaseStep
+}
+
+// releaseWorktreeMsg is sent when the dedicated release worktree is prepared
+type releaseWorktreeMsg struct {
+	state *ReleaseState
+	err   error
+}
+
+// releaseSimulationMsg is sent when the release simulation in a temporary worktree completes
+type releaseSimulationMsg struct {
+	simulation *ReleaseSimulation
+	err        error
+}
+
+// conflictEditorDoneMsg is sent when the external editor opened from the conflict screen exits
+type conflictEditorDoneMsg struct {
+	file string
+	err  error
+}
+
 // sourceBranchCheckMsg is sent when the source branch remote check completes
 type sourceBranchCheckMsg struct {
 	branchName   string // The branch name that was checked
@@ -303,9 +494,27 @@ const (
 
 // Pipeline represents a GitLab pipeline (API response)
 type Pipeline struct {
-	ID     int    `json:"id"`
-	Status string `json:"status"`
-	WebURL string `json:"web_url"`
+	ID        int       `json:"id"`
+	Status    string    `json:"status"`
+	Ref       string    `json:"ref"`
+	SHA       string    `json:"sha"`
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updated_at"`
+	WebURL    string    `json:"web_url"`
+}
+
+// GitLabRelease is a GitLab Release of a tag to create or update
+type GitLabRelease struct {
+	Name        string
+	TagName     string
+	Description string              // Markdown release notes
+	Links       []GitLabReleaseLink // Asset links, only set on creation
+}
+
+// GitLabReleaseLink is an asset link of a GitLab Release
+type GitLabReleaseLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 // PipelineJob represents a GitLab pipeline job (API response)
@@ -351,6 +560,8 @@ type HistoryIndexEntry struct {
 	MRCount     int       `json:"mr_count"`
 	Status      string    `json:"status"` // "completed" or "aborted"
 	Version     string    `json:"version"`
+	Number      int       `json:"number,omitempty"`     // Ordinal number of the release within the version
+	ProjectID   int       `json:"project_id,omitempty"` // GitLab project of the release
 }
 
 // ThemeANSIMap records the ANSI escape sequences lipgloss produced for each
@@ -364,19 +575,48 @@ type ThemeANSIMap struct {
 }
 
 // ReleaseHistoryEntry represents full release details stored in individual files
+
 type ReleaseHistoryEntry struct {
 	HistoryIndexEntry
-	MRBranches     []string      `json:"mr_branches"`
-	MRURLs         []string      `json:"mr_urls,omitempty"`         // MR URLs corresponding to each branch
-	MRIIDs         []int         `json:"mr_iids,omitempty"`         // MR IIDs corresponding to each branch
-	MRCommitSHAs   []string      `json:"mr_commit_shas,omitempty"`  // Commit SHAs of branch heads at release time
-	SourceBranch   string        `json:"source_branch"`
-	EnvBranch      string        `json:"env_branch"`
-	RootMerge      bool          `json:"root_merge"`
-	EnvMergeMode   string        `json:"env_merge_mode,omitempty"` // "squash" or "regular"
-	CreatedMRURL   string        `json:"created_mr_url"`
-	TerminalOutput []string      `json:"terminal_output"`
-	ThemeANSIMap   *ThemeANSIMap `json:"theme_ansi_map,omitempty"`
+	MRBranches       []string      `json:"mr_branches"`
+	MRURLs           []string      `json:"mr_urls,omitempty"`        // MR URLs corresponding to each branch
+	MRIIDs           []int         `json:"mr_iids,omitempty"`        // MR IIDs corresponding to each branch
+	MRCommitSHAs     []string      `json:"mr_commit_shas,omitempty"` // Commit SHAs of branch heads at release time
+	SkippedMRs       []SkippedMR   `json:"skipped_mrs,omitempty"`    // MRs removed from the merge queue mid-release
+	SourceBranch     string        `json:"source_branch"`
+	SourceBranchSHA  string        `json:"source_branch_sha,omitempty"` // Source branch head the release was made of
+	PromotedFrom     string        `json:"promoted_from,omitempty"`     // Release this one was promoted from
+	EnvBranch        string        `json:"env_branch"`
+	EnvReleaseSHA    string        `json:"env_release_sha,omitempty"`    // Env release branch head, the content deployed to the environment
+	Rollback         *Rollback     `json:"rollback,omitempty"`           // Releases linked by a rollback
+	RolledBackBy     string        `json:"rolled_back_by,omitempty"`     // Rollback that undid this release
+	TagName          string        `json:"tag_name,omitempty"`           // Full release tag name
+	GitLabReleaseURL string        `json:"gitlab_release_url,omitempty"` // GitLab Release page of the tag
+	TagMove          *TagMove      `json:"tag_move,omitempty"`           // Tag collision on origin and whether the tag was moved
+	ReleaseNotes     string        `json:"release_notes,omitempty"`      // Markdown release notes
+	RootMerge        bool          `json:"root_merge"`
+	EnvMergeMode     string        `json:"env_merge_mode,omitempty"` // "squash", "regular" or "cherry-pick"
+	CreatedMRURL     string        `json:"created_mr_url"`
+	TerminalOutput   []string      `json:"terminal_output"`
+	ThemeANSIMap     *ThemeANSIMap `json:"theme_ansi_map,omitempty"`
+}
+
+// promotionCheckedMsg is sent when the source branch of a release to promote is verified
+type promotionCheckedMsg struct {
+	entry *ReleaseHistoryEntry
+	err   error
+}
+
+// mrDriftMsg is sent when the pinned MR commits of a release to retry are compared with origin
+type mrDriftMsg struct {
+	drifts []MRDrift
+	err    error
+}
+
+// preflightMsg is sent when the pre-flight checks of the selected MRs are done
+type preflightMsg struct {
+	run     int // Pre-flight run the results belong to
+	results []PreflightMR
 }
 
 // fetchHistoryMsg is sent when history index is loaded
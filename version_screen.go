@@ -331,7 +331,7 @@ func (m model) renderVersionInput(width int) string {
 		branchSuffix = "env"
 	}
 
-	branchPart := fmt.Sprintf("release/rpb-%s-%s", version, branchSuffix)
+	branchPart := renderNamingTemplate(getNamingTemplates().EnvBranch, NamingVars{Version: version, Env: envName, EnvBranch: branchSuffix})
 	hint := envHintBaseStyle.Render("Release branch ") +
 		getEnvBranchStyle(envName).Render(branchPart) +
 		envHintBaseStyle.Render(" -> ") +